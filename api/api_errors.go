@@ -32,6 +32,9 @@ const (
 	EPaymentChannelDisabled
 )
 
+// EInvalidParams is the JSON-RPC error code for invalid method parameters.
+const EInvalidParams = -32602
+
 var (
 	RPCErrors = jsonrpc.NewErrors()
 
@@ -67,6 +70,8 @@ var (
 	_ error                 = (*ErrNullRound)(nil)
 	_ jsonrpc.RPCErrorCodec = (*ErrNullRound)(nil)
 	_ error                 = (*errPaymentChannelDisabled)(nil)
+	_ error                 = (*ErrInvalidParams)(nil)
+	_ jsonrpc.RPCErrorCodec = (*ErrInvalidParams)(nil)
 )
 
 func init() {
@@ -82,6 +87,7 @@ func init() {
 	RPCErrors.Register(EExecutionReverted, new(*ErrExecutionReverted))
 	RPCErrors.Register(ENullRound, new(*ErrNullRound))
 	RPCErrors.Register(EPaymentChannelDisabled, new(*errPaymentChannelDisabled))
+	RPCErrors.Register(EInvalidParams, new(*ErrInvalidParams))
 }
 
 func ErrorIsIn(err error, errorTypes []error) bool {
@@ -195,6 +201,36 @@ func NewErrExecutionRevertedFromResult(res *InvocResult) error {
 	return NewErrExecutionReverted(res.MsgRct.ExitCode, res.Error, reason, cbytes)
 }
 
+// ErrInvalidParams signals that the parameters of a method are invalid, such as a malformed or
+// negative gas price in a call.
+type ErrInvalidParams struct {
+	Message string
+}
+
+// NewErrInvalidParams creates an ErrInvalidParams reporting err.
+func NewErrInvalidParams(err error) *ErrInvalidParams {
+	return &ErrInvalidParams{Message: err.Error()}
+}
+
+func (e *ErrInvalidParams) Error() string {
+	return e.Message
+}
+
+func (e *ErrInvalidParams) FromJSONRPCError(jerr jsonrpc.JSONRPCError) error {
+	if jerr.Code != EInvalidParams {
+		return fmt.Errorf("unexpected error code: %d", jerr.Code)
+	}
+	e.Message = jerr.Message
+	return nil
+}
+
+func (e *ErrInvalidParams) ToJSONRPCError() (jsonrpc.JSONRPCError, error) {
+	return jsonrpc.JSONRPCError{
+		Code:    EInvalidParams,
+		Message: e.Message,
+	}, nil
+}
+
 type ErrNullRound struct {
 	Epoch   abi.ChainEpoch
 	Message string
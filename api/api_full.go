@@ -93,6 +93,18 @@ type FullNode interface {
 	//            Note: The API returns an error if the index does not have data for the specified epoch and backfill is set to false.
 	ChainValidateIndex(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error) //perm:write
 
+	// ChainBackfillIndex starts backfilling the chain index in the background over the epochs from
+	// `from` to `to` inclusive, e.g. so that eth_getLogs can query a range that predates the snapshot
+	// a node was started from. Each epoch is processed as by ChainValidateIndex with backfill set to
+	// true, and the backfill stops at the first epoch that fails. Only one backfill can run at a time.
+	// It returns the initial progress of the backfill, which can then be followed through
+	// ChainBackfillIndexStatus.
+	ChainBackfillIndex(ctx context.Context, from, to abi.ChainEpoch) (*types.IndexBackfill, error) //perm:admin
+
+	// ChainBackfillIndexStatus returns the progress of the running chain index backfill, or of the
+	// last one if none is running.
+	ChainBackfillIndexStatus(ctx context.Context) (*types.IndexBackfill, error) //perm:read
+
 	// MethodGroup: Chain
 	// The Chain method group contains methods for interacting with the
 	// blockchain, but that do not require any form of state computation.
@@ -825,6 +837,11 @@ type FullNode interface {
 	EthAccounts(ctx context.Context) ([]ethtypes.EthAddress, error) //perm:read
 	// EthAddressToFilecoinAddress converts an EthAddress into an f410 Filecoin Address
 	EthAddressToFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error) //perm:read
+	// EthAddressToRobustFilecoinAddress returns the robust Filecoin address (f1, f2 or f3) of the
+	// actor at the given EthAddress. It fails if the actor only has an ID address.
+	// Actors other than accounts are looked up by searching the whole address map of the init
+	// actor, which is costly, so the method isn't served by the gateway.
+	EthAddressToRobustFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error) //perm:read
 
 	// `FilecoinAddressToEthAddress` converts any Filecoin address to an EthAddress.
 	//
@@ -871,8 +888,12 @@ type FullNode interface {
 	EthGetBlockReceipts(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash) ([]*ethtypes.EthTxReceipt, error)                              //perm:read
 	EthGetBlockReceiptsLimited(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) //perm:read
 	EthGetTransactionReceiptLimited(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*ethtypes.EthTxReceipt, error)             //perm:read
-	EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash ethtypes.EthHash, txIndex ethtypes.EthUint64) (*ethtypes.EthTx, error)        //perm:read
-	EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkNum string, txIndex ethtypes.EthUint64) (*ethtypes.EthTx, error)                 //perm:read
+	// EthGetTransactionReceipts returns the receipts of several transactions in the order of their
+	// hashes, with null entries for transactions that can't be found.
+	EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error)                              //perm:read
+	EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) //perm:read
+	EthGetTransactionByBlockHashAndIndex(ctx context.Context, blkHash ethtypes.EthHash, txIndex ethtypes.EthUint64) (*ethtypes.EthTx, error)   //perm:read
+	EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkNum string, txIndex ethtypes.EthUint64) (*ethtypes.EthTx, error)            //perm:read
 
 	EthGetCode(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)                                  //perm:read
 	EthGetStorageAt(ctx context.Context, address ethtypes.EthAddress, position ethtypes.EthBytes, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) //perm:read
@@ -885,9 +906,38 @@ type FullNode interface {
 	EthGasPrice(ctx context.Context) (ethtypes.EthBigInt, error)                                                                                                     //perm:read
 	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthFeeHistory, error)                                                                          //perm:read
 
-	EthMaxPriorityFeePerGas(ctx context.Context) (ethtypes.EthBigInt, error)                                             //perm:read
-	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error)                                 //perm:read
-	EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) //perm:read
+	// EthGetProof returns the account at address with the IPLD blocks proving it, and the values of
+	// the given storage slots. Storage proofs aren't available. See ethtypes.EthProof.
+	EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) //perm:read
+
+	EthMaxPriorityFeePerGas(ctx context.Context) (ethtypes.EthBigInt, error)             //perm:read
+	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error) //perm:read
+	EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error)         //perm:read
+
+	// EthCallDetailed takes the same parameters as EthCall. For contract creations, it returns the
+	// runtime code of the created contract along with the address it would be deployed at, which
+	// is derived from the sender's nonce.
+	// If EthCall.ReportTouched is set, it lists the addresses the call touched: the actors it invoked
+	// and those whose code it read. Accounts whose balance was only read with BALANCE aren't listed,
+	// as the FVM doesn't trace balance lookups.
+	EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) //perm:read
+
+	// EthCallMany executes a sequence of calls at a block, each one on the state left by the previous
+	// ones, so that e.g. a contract can be deployed and then called. A call reverting doesn't stop
+	// the sequence; its error is reported in its result. Passing {"traceReverts": true} as fourth
+	// parameter, after the state overrides, attaches the call frame of the calls that revert to
+	// their result.
+	EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) //perm:read
+
+	// EthCallAtStateRoot executes a call like EthCall, but on the state tree with the given root
+	// rather than on the state at a block, e.g. to simulate calls on a checkpointed state. It takes
+	// the call and the state root, and applies the call at the epoch of the heaviest tipset.
+	EthCallAtStateRoot(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) //perm:read
+
+	// EthTraceCall executes a call like EthCall and returns its trace, as Geth's debug_traceCall
+	// does with the callTracer or the prestateTracer. It takes the call, the block and the trace
+	// config, which may carry state and block overrides. Reverted calls are traced too.
+	EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) //perm:read
 
 	EthSendRawTransaction(ctx context.Context, rawTx ethtypes.EthBytes) (ethtypes.EthHash, error) //perm:read
 	// EthSendRawTransactionUntrusted sends a transaction from and untrusted source, using MpoolPushUntrusted to submit the message.
@@ -896,6 +946,18 @@ type FullNode interface {
 	// Returns event logs matching given filter spec.
 	EthGetLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error) //perm:read
 
+	// Returns an approximate number of event logs matching given filter spec, without loading them.
+	// The count comes from the chain index and may differ from the number of logs returned by
+	// EthGetLogs if the index hasn't caught up with the requested range yet. Unlike EthGetLogs, the
+	// count is not subject to the max results limit, so it can be used to decide whether a query
+	// needs to be split up before running it.
+	EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) //perm:read
+
+	// Returns a description of how EthGetLogs would execute the given filter spec, without
+	// executing it: the block range it resolves to, the number of tipsets it scans and the criteria
+	// the chain index narrows the scan with. Meant to help operators diagnose slow queries.
+	EthExplainLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) //perm:admin
+
 	// Polling method for a filter, returns event logs which occurred since last poll.
 	// (requires write perm since timestamp of last filter execution will be written)
 	EthGetFilterChanges(ctx context.Context, id ethtypes.EthFilterID) (*ethtypes.EthFilterResult, error) //perm:read
@@ -921,7 +983,9 @@ type FullNode interface {
 	//  - newHeads: notify when new blocks arrive.
 	//  - pendingTransactions: notify when new messages arrive in the message pool.
 	//  - logs: notify new event logs that match a criteria
-	// params contains additional parameters used with the log event type
+	// params contains additional parameters used with the log event type, which are matched like the
+	// criteria of EthGetLogs. When a tipset is reverted, the logs it emitted are sent again with removed
+	// set to true.
 	// The client will receive a stream of EthSubscriptionResponse values until EthUnsubscribe is called.
 	EthSubscribe(ctx context.Context, params jsonrpc.RawParams) (ethtypes.EthSubscriptionID, error) //perm:read
 
@@ -958,6 +1022,20 @@ type FullNode interface {
 	// Implmements OpenEthereum-compatible API method trace_transaction
 	EthTraceTransaction(ctx context.Context, txHash string) ([]*ethtypes.EthTraceTransaction, error) //perm:read
 
+	// EthReplayTransaction re-executes a mined transaction on top of the state it was originally
+	// executed on, returning its outcome, the gas it used and its trace, to help debugging past
+	// transactions.
+	EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) //perm:read
+
+	// EthTraceBlockByNumber replays the transactions of the given block and returns their traces, as
+	// Geth's debug_traceBlockByNumber does with the callTracer, keyed by transaction hash. It takes
+	// the block number or tag and the trace config, which may leave out the messages that don't
+	// invoke the EVM.
+	EthTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) //perm:read
+
+	// EthTraceBlockByHash is like EthTraceBlockByNumber, but takes the hash of the block.
+	EthTraceBlockByHash(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) //perm:read
+
 	// Implements OpenEthereum-compatible API method trace_filter
 	EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) //perm:read
 
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,6 +17,10 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+
+	"github.com/filecoin-project/lotus/chain/types"
 )
 
 func goRoot() (string, error) {
@@ -150,3 +157,36 @@ func TestRetryWrappedErrorIsInTrue(t *testing.T) {
 	errorsToRetry := []error{&jsonrpc.RPCConnectionError{}}
 	require.True(t, ErrorIsIn(xerrors.Errorf("wrapped: %w", &jsonrpc.RPCConnectionError{}), errorsToRetry))
 }
+
+func TestErrExecutionRevertedFromResult(t *testing.T) {
+	// The return data of a contract reverting with Error("my reason").
+	reason := "my reason"
+	revertData := "08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		fmt.Sprintf("%064x", len(reason)) +
+		hex.EncodeToString([]byte(reason)) + strings.Repeat("00", 32-len(reason))
+	data, err := hex.DecodeString(revertData)
+	require.NoError(t, err)
+	var ret bytes.Buffer
+	cb := abi.CborBytes(data)
+	require.NoError(t, cb.MarshalCBOR(&ret))
+
+	err = NewErrExecutionRevertedFromResult(&InvocResult{
+		MsgRct: &types.MessageReceipt{ExitCode: exitcode.ExitCode(33), Return: ret.Bytes()},
+		Error:  "contract reverted",
+	})
+	var reverted *ErrExecutionReverted
+	require.ErrorAs(t, err, &reverted)
+	require.Contains(t, reverted.Message, "revert reason=[Error(my reason)]")
+
+	// The raw return data is carried in the data field of the JSON-RPC error, as Ethereum tools
+	// expect, and survives the round trip.
+	jerr, err := reverted.ToJSONRPCError()
+	require.NoError(t, err)
+	require.EqualValues(t, EExecutionReverted, jerr.Code)
+	require.Equal(t, "0x"+revertData, jerr.Data)
+
+	var decoded ErrExecutionReverted
+	require.NoError(t, decoded.FromJSONRPCError(jerr))
+	require.Equal(t, *reverted, decoded)
+}
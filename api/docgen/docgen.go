@@ -425,6 +425,9 @@ func init() {
 		FromBlock: pstring("2301220"),
 		Address:   []ethtypes.EthAddress{ethaddr},
 	})
+	addExample(&ethtypes.EthFilterResult{
+		Results: []interface{}{struct{}{}},
+	})
 
 	after := ethtypes.EthUint64(0)
 	count := ethtypes.EthUint64(100)
@@ -14,6 +14,7 @@ func CreateEthRPCAliases(as apitypes.Aliaser) {
 	as.AliasMethod("eth_getTransactionByHash", "Filecoin.EthGetTransactionByHash")
 	as.AliasMethod("eth_getTransactionCount", "Filecoin.EthGetTransactionCount")
 	as.AliasMethod("eth_getTransactionReceipt", "Filecoin.EthGetTransactionReceipt")
+	as.AliasMethod("eth_getTransactionReceipts", "Filecoin.EthGetTransactionReceipts")
 	as.AliasMethod("eth_getBlockReceipts", "Filecoin.EthGetBlockReceipts")
 	as.AliasMethod("eth_getTransactionByBlockHashAndIndex", "Filecoin.EthGetTransactionByBlockHashAndIndex")
 	as.AliasMethod("eth_getTransactionByBlockNumberAndIndex", "Filecoin.EthGetTransactionByBlockNumberAndIndex")
@@ -21,6 +22,7 @@ func CreateEthRPCAliases(as apitypes.Aliaser) {
 	as.AliasMethod("eth_getCode", "Filecoin.EthGetCode")
 	as.AliasMethod("eth_getStorageAt", "Filecoin.EthGetStorageAt")
 	as.AliasMethod("eth_getBalance", "Filecoin.EthGetBalance")
+	as.AliasMethod("eth_getProof", "Filecoin.EthGetProof")
 	as.AliasMethod("eth_chainId", "Filecoin.EthChainId")
 	as.AliasMethod("eth_syncing", "Filecoin.EthSyncing")
 	as.AliasMethod("eth_feeHistory", "Filecoin.EthFeeHistory")
@@ -30,8 +32,13 @@ func CreateEthRPCAliases(as apitypes.Aliaser) {
 	as.AliasMethod("eth_sendRawTransaction", "Filecoin.EthSendRawTransaction")
 	as.AliasMethod("eth_estimateGas", "Filecoin.EthEstimateGas")
 	as.AliasMethod("eth_call", "Filecoin.EthCall")
+	as.AliasMethod("eth_callDetailed", "Filecoin.EthCallDetailed")
+	as.AliasMethod("eth_callMany", "Filecoin.EthCallMany")
+	as.AliasMethod("eth_callAtStateRoot", "Filecoin.EthCallAtStateRoot")
+	as.AliasMethod("debug_traceCall", "Filecoin.EthTraceCall")
 
 	as.AliasMethod("eth_getLogs", "Filecoin.EthGetLogs")
+	as.AliasMethod("eth_estimateLogsCount", "Filecoin.EthEstimateLogsCount")
 	as.AliasMethod("eth_getFilterChanges", "Filecoin.EthGetFilterChanges")
 	as.AliasMethod("eth_getFilterLogs", "Filecoin.EthGetFilterLogs")
 	as.AliasMethod("eth_newFilter", "Filecoin.EthNewFilter")
@@ -44,6 +51,9 @@ func CreateEthRPCAliases(as apitypes.Aliaser) {
 	as.AliasMethod("trace_block", "Filecoin.EthTraceBlock")
 	as.AliasMethod("trace_replayBlockTransactions", "Filecoin.EthTraceReplayBlockTransactions")
 	as.AliasMethod("trace_transaction", "Filecoin.EthTraceTransaction")
+	as.AliasMethod("debug_replayTransaction", "Filecoin.EthReplayTransaction")
+	as.AliasMethod("debug_traceBlockByNumber", "Filecoin.EthTraceBlockByNumber")
+	as.AliasMethod("debug_traceBlockByHash", "Filecoin.EthTraceBlockByHash")
 	as.AliasMethod("trace_filter", "Filecoin.EthTraceFilter")
 
 	as.AliasMethod("net_version", "Filecoin.NetVersion")
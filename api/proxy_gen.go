@@ -114,6 +114,10 @@ type FullNodeStruct struct {
 }
 
 type FullNodeMethods struct {
+	ChainBackfillIndex func(p0 context.Context, p1 abi.ChainEpoch, p2 abi.ChainEpoch) (*types.IndexBackfill, error) `perm:"admin"`
+
+	ChainBackfillIndexStatus func(p0 context.Context) (*types.IndexBackfill, error) `perm:"read"`
+
 	ChainBlockstoreInfo func(p0 context.Context) (map[string]interface{}, error) `perm:"read"`
 
 	ChainCheckBlockstore func(p0 context.Context) error `perm:"admin"`
@@ -180,14 +184,26 @@ type FullNodeMethods struct {
 
 	EthAddressToFilecoinAddress func(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) `perm:"read"`
 
+	EthAddressToRobustFilecoinAddress func(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) `perm:"read"`
+
 	EthBlockNumber func(p0 context.Context) (ethtypes.EthUint64, error) `perm:"read"`
 
-	EthCall func(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) `perm:"read"`
+	EthCall func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) `perm:"read"`
+
+	EthCallAtStateRoot func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) `perm:"read"`
+
+	EthCallDetailed func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) `perm:"read"`
+
+	EthCallMany func(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) `perm:"read"`
 
 	EthChainId func(p0 context.Context) (ethtypes.EthUint64, error) `perm:"read"`
 
 	EthEstimateGas func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthUint64, error) `perm:"read"`
 
+	EthEstimateLogsCount func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) `perm:"read"`
+
+	EthExplainLogs func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) `perm:"admin"`
+
 	EthFeeHistory func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) `perm:"read"`
 
 	EthGasPrice func(p0 context.Context) (ethtypes.EthBigInt, error) `perm:"read"`
@@ -216,6 +232,8 @@ type FullNodeMethods struct {
 
 	EthGetMessageCidByTransactionHash func(p0 context.Context, p1 *ethtypes.EthHash) (*cid.Cid, error) `perm:"read"`
 
+	EthGetProof func(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) `perm:"read"`
+
 	EthGetStorageAt func(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) `perm:"read"`
 
 	EthGetTransactionByBlockHashAndIndex func(p0 context.Context, p1 ethtypes.EthHash, p2 ethtypes.EthUint64) (*ethtypes.EthTx, error) `perm:"read"`
@@ -234,6 +252,10 @@ type FullNodeMethods struct {
 
 	EthGetTransactionReceiptLimited func(p0 context.Context, p1 ethtypes.EthHash, p2 abi.ChainEpoch) (*ethtypes.EthTxReceipt, error) `perm:"read"`
 
+	EthGetTransactionReceipts func(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) `perm:"read"`
+
+	EthGetTransactionReceiptsLimited func(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) `perm:"read"`
+
 	EthMaxPriorityFeePerGas func(p0 context.Context) (ethtypes.EthBigInt, error) `perm:"read"`
 
 	EthNewBlockFilter func(p0 context.Context) (ethtypes.EthFilterID, error) `perm:"read"`
@@ -244,6 +266,8 @@ type FullNodeMethods struct {
 
 	EthProtocolVersion func(p0 context.Context) (ethtypes.EthUint64, error) `perm:"read"`
 
+	EthReplayTransaction func(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) `perm:"read"`
+
 	EthSendRawTransaction func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) `perm:"read"`
 
 	EthSendRawTransactionUntrusted func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) `perm:"read"`
@@ -254,6 +278,12 @@ type FullNodeMethods struct {
 
 	EthTraceBlock func(p0 context.Context, p1 string) ([]*ethtypes.EthTraceBlock, error) `perm:"read"`
 
+	EthTraceBlockByHash func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) `perm:"read"`
+
+	EthTraceBlockByNumber func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) `perm:"read"`
+
+	EthTraceCall func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) `perm:"read"`
+
 	EthTraceFilter func(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) `perm:"read"`
 
 	EthTraceReplayBlockTransactions func(p0 context.Context, p1 string, p2 []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error) `perm:"read"`
@@ -658,12 +688,18 @@ type GatewayMethods struct {
 
 	EthBlockNumber func(p0 context.Context) (ethtypes.EthUint64, error) ``
 
-	EthCall func(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) ``
+	EthCall func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) ``
+
+	EthCallDetailed func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) ``
+
+	EthCallMany func(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) ``
 
 	EthChainId func(p0 context.Context) (ethtypes.EthUint64, error) ``
 
 	EthEstimateGas func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthUint64, error) ``
 
+	EthEstimateLogsCount func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) ``
+
 	EthFeeHistory func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) ``
 
 	EthGasPrice func(p0 context.Context) (ethtypes.EthBigInt, error) ``
@@ -690,6 +726,8 @@ type GatewayMethods struct {
 
 	EthGetMessageCidByTransactionHash func(p0 context.Context, p1 *ethtypes.EthHash) (*cid.Cid, error) ``
 
+	EthGetProof func(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) ``
+
 	EthGetStorageAt func(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) ``
 
 	EthGetTransactionByBlockHashAndIndex func(p0 context.Context, p1 ethtypes.EthHash, p2 ethtypes.EthUint64) (*ethtypes.EthTx, error) ``
@@ -704,6 +742,8 @@ type GatewayMethods struct {
 
 	EthGetTransactionReceipt func(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthTxReceipt, error) ``
 
+	EthGetTransactionReceipts func(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) ``
+
 	EthMaxPriorityFeePerGas func(p0 context.Context) (ethtypes.EthBigInt, error) ``
 
 	EthNewBlockFilter func(p0 context.Context) (ethtypes.EthFilterID, error) ``
@@ -714,6 +754,8 @@ type GatewayMethods struct {
 
 	EthProtocolVersion func(p0 context.Context) (ethtypes.EthUint64, error) ``
 
+	EthReplayTransaction func(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) ``
+
 	EthSendRawTransaction func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) ``
 
 	EthSubscribe func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthSubscriptionID, error) ``
@@ -722,6 +764,12 @@ type GatewayMethods struct {
 
 	EthTraceBlock func(p0 context.Context, p1 string) ([]*ethtypes.EthTraceBlock, error) ``
 
+	EthTraceBlockByHash func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) ``
+
+	EthTraceBlockByNumber func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) ``
+
+	EthTraceCall func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) ``
+
 	EthTraceFilter func(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) ``
 
 	EthTraceReplayBlockTransactions func(p0 context.Context, p1 string, p2 []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error) ``
@@ -1365,6 +1413,28 @@ func (s *EthSubscriberStub) EthSubscription(p0 context.Context, p1 jsonrpc.RawPa
 	return ErrNotSupported
 }
 
+func (s *FullNodeStruct) ChainBackfillIndex(p0 context.Context, p1 abi.ChainEpoch, p2 abi.ChainEpoch) (*types.IndexBackfill, error) {
+	if s.Internal.ChainBackfillIndex == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.ChainBackfillIndex(p0, p1, p2)
+}
+
+func (s *FullNodeStub) ChainBackfillIndex(p0 context.Context, p1 abi.ChainEpoch, p2 abi.ChainEpoch) (*types.IndexBackfill, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *FullNodeStruct) ChainBackfillIndexStatus(p0 context.Context) (*types.IndexBackfill, error) {
+	if s.Internal.ChainBackfillIndexStatus == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.ChainBackfillIndexStatus(p0)
+}
+
+func (s *FullNodeStub) ChainBackfillIndexStatus(p0 context.Context) (*types.IndexBackfill, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) ChainBlockstoreInfo(p0 context.Context) (map[string]interface{}, error) {
 	if s.Internal.ChainBlockstoreInfo == nil {
 		return *new(map[string]interface{}), ErrNotSupported
@@ -1728,6 +1798,17 @@ func (s *FullNodeStub) EthAddressToFilecoinAddress(p0 context.Context, p1 ethtyp
 	return *new(address.Address), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthAddressToRobustFilecoinAddress(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) {
+	if s.Internal.EthAddressToRobustFilecoinAddress == nil {
+		return *new(address.Address), ErrNotSupported
+	}
+	return s.Internal.EthAddressToRobustFilecoinAddress(p0, p1)
+}
+
+func (s *FullNodeStub) EthAddressToRobustFilecoinAddress(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) {
+	return *new(address.Address), ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthBlockNumber == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -1739,17 +1820,50 @@ func (s *FullNodeStub) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, e
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
-func (s *FullNodeStruct) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *FullNodeStruct) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	if s.Internal.EthCall == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
 	}
-	return s.Internal.EthCall(p0, p1, p2)
+	return s.Internal.EthCall(p0, p1)
 }
 
-func (s *FullNodeStub) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *FullNodeStub) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	return *new(ethtypes.EthBytes), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthCallAtStateRoot(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	if s.Internal.EthCallAtStateRoot == nil {
+		return *new(ethtypes.EthBytes), ErrNotSupported
+	}
+	return s.Internal.EthCallAtStateRoot(p0, p1)
+}
+
+func (s *FullNodeStub) EthCallAtStateRoot(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	return *new(ethtypes.EthBytes), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	if s.Internal.EthCallDetailed == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthCallDetailed(p0, p1)
+}
+
+func (s *FullNodeStub) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	if s.Internal.EthCallMany == nil {
+		return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+	}
+	return s.Internal.EthCallMany(p0, p1)
+}
+
+func (s *FullNodeStub) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthChainId(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthChainId == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -1772,6 +1886,28 @@ func (s *FullNodeStub) EthEstimateGas(p0 context.Context, p1 jsonrpc.RawParams)
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	if s.Internal.EthEstimateLogsCount == nil {
+		return *new(ethtypes.EthUint64), ErrNotSupported
+	}
+	return s.Internal.EthEstimateLogsCount(p0, p1)
+}
+
+func (s *FullNodeStub) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	return *new(ethtypes.EthUint64), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthExplainLogs(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	if s.Internal.EthExplainLogs == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthExplainLogs(p0, p1)
+}
+
+func (s *FullNodeStub) EthExplainLogs(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthFeeHistory(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) {
 	if s.Internal.EthFeeHistory == nil {
 		return *new(ethtypes.EthFeeHistory), ErrNotSupported
@@ -1926,6 +2062,17 @@ func (s *FullNodeStub) EthGetMessageCidByTransactionHash(p0 context.Context, p1
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	if s.Internal.EthGetProof == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthGetProof(p0, p1, p2, p3)
+}
+
+func (s *FullNodeStub) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthGetStorageAt(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
 	if s.Internal.EthGetStorageAt == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
@@ -2025,6 +2172,28 @@ func (s *FullNodeStub) EthGetTransactionReceiptLimited(p0 context.Context, p1 et
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceipts == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceipts(p0, p1)
+}
+
+func (s *FullNodeStub) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthGetTransactionReceiptsLimited(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceiptsLimited == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceiptsLimited(p0, p1, p2)
+}
+
+func (s *FullNodeStub) EthGetTransactionReceiptsLimited(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthMaxPriorityFeePerGas(p0 context.Context) (ethtypes.EthBigInt, error) {
 	if s.Internal.EthMaxPriorityFeePerGas == nil {
 		return *new(ethtypes.EthBigInt), ErrNotSupported
@@ -2080,6 +2249,17 @@ func (s *FullNodeStub) EthProtocolVersion(p0 context.Context) (ethtypes.EthUint6
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	if s.Internal.EthReplayTransaction == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthReplayTransaction(p0, p1)
+}
+
+func (s *FullNodeStub) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthSendRawTransaction(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) {
 	if s.Internal.EthSendRawTransaction == nil {
 		return *new(ethtypes.EthHash), ErrNotSupported
@@ -2135,6 +2315,39 @@ func (s *FullNodeStub) EthTraceBlock(p0 context.Context, p1 string) ([]*ethtypes
 	return *new([]*ethtypes.EthTraceBlock), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByHash == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByHash(p0, p1)
+}
+
+func (s *FullNodeStub) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByNumber == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByNumber(p0, p1)
+}
+
+func (s *FullNodeStub) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	if s.Internal.EthTraceCall == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthTraceCall(p0, p1)
+}
+
+func (s *FullNodeStub) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthTraceFilter(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	if s.Internal.EthTraceFilter == nil {
 		return *new([]*ethtypes.EthTraceFilterResult), ErrNotSupported
@@ -4291,17 +4504,39 @@ func (s *GatewayStub) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, er
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
-func (s *GatewayStruct) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *GatewayStruct) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	if s.Internal.EthCall == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
 	}
-	return s.Internal.EthCall(p0, p1, p2)
+	return s.Internal.EthCall(p0, p1)
 }
 
-func (s *GatewayStub) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *GatewayStub) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	return *new(ethtypes.EthBytes), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	if s.Internal.EthCallDetailed == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthCallDetailed(p0, p1)
+}
+
+func (s *GatewayStub) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *GatewayStruct) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	if s.Internal.EthCallMany == nil {
+		return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+	}
+	return s.Internal.EthCallMany(p0, p1)
+}
+
+func (s *GatewayStub) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+}
+
 func (s *GatewayStruct) EthChainId(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthChainId == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -4324,6 +4559,17 @@ func (s *GatewayStub) EthEstimateGas(p0 context.Context, p1 jsonrpc.RawParams) (
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	if s.Internal.EthEstimateLogsCount == nil {
+		return *new(ethtypes.EthUint64), ErrNotSupported
+	}
+	return s.Internal.EthEstimateLogsCount(p0, p1)
+}
+
+func (s *GatewayStub) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	return *new(ethtypes.EthUint64), ErrNotSupported
+}
+
 func (s *GatewayStruct) EthFeeHistory(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) {
 	if s.Internal.EthFeeHistory == nil {
 		return *new(ethtypes.EthFeeHistory), ErrNotSupported
@@ -4467,6 +4713,17 @@ func (s *GatewayStub) EthGetMessageCidByTransactionHash(p0 context.Context, p1 *
 	return nil, ErrNotSupported
 }
 
+func (s *GatewayStruct) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	if s.Internal.EthGetProof == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthGetProof(p0, p1, p2, p3)
+}
+
+func (s *GatewayStub) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthGetStorageAt(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
 	if s.Internal.EthGetStorageAt == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
@@ -4544,6 +4801,17 @@ func (s *GatewayStub) EthGetTransactionReceipt(p0 context.Context, p1 ethtypes.E
 	return nil, ErrNotSupported
 }
 
+func (s *GatewayStruct) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceipts == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceipts(p0, p1)
+}
+
+func (s *GatewayStub) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
 func (s *GatewayStruct) EthMaxPriorityFeePerGas(p0 context.Context) (ethtypes.EthBigInt, error) {
 	if s.Internal.EthMaxPriorityFeePerGas == nil {
 		return *new(ethtypes.EthBigInt), ErrNotSupported
@@ -4599,6 +4867,17 @@ func (s *GatewayStub) EthProtocolVersion(p0 context.Context) (ethtypes.EthUint64
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	if s.Internal.EthReplayTransaction == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthReplayTransaction(p0, p1)
+}
+
+func (s *GatewayStub) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthSendRawTransaction(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) {
 	if s.Internal.EthSendRawTransaction == nil {
 		return *new(ethtypes.EthHash), ErrNotSupported
@@ -4643,6 +4922,39 @@ func (s *GatewayStub) EthTraceBlock(p0 context.Context, p1 string) ([]*ethtypes.
 	return *new([]*ethtypes.EthTraceBlock), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByHash == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByHash(p0, p1)
+}
+
+func (s *GatewayStub) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByNumber == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByNumber(p0, p1)
+}
+
+func (s *GatewayStub) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	if s.Internal.EthTraceCall == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthTraceCall(p0, p1)
+}
+
+func (s *GatewayStub) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthTraceFilter(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	if s.Internal.EthTraceFilter == nil {
 		return *new([]*ethtypes.EthTraceFilterResult), ErrNotSupported
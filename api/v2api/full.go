@@ -146,6 +146,13 @@ type FullNode interface {
 	// EthAddressToFilecoinAddress converts an Ethereum address to a Filecoin f410 address.
 	EthAddressToFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error) //perm:read
 
+	// EthAddressToRobustFilecoinAddress returns the robust Filecoin address (f1, f2 or f3) of the
+	// actor at the given Ethereum address, as opposed to its f410 or ID address. It fails if the
+	// actor only has an ID address.
+	// Actors other than accounts are looked up by searching the whole address map of the init
+	// actor, which is costly, so the method isn't served by the gateway.
+	EthAddressToRobustFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error) //perm:read
+
 	// FilecoinAddressToEthAddress converts any Filecoin address to an EthAddress.
 	//
 	// This method supports all Filecoin address types:
@@ -236,11 +243,15 @@ type FullNode interface {
 
 	// EthGetBlockByNumber retrieves a block by its number or a special tag like "latest" or
 	// "finalized". If fullTxInfo is true, it includes full transaction objects; otherwise, it
-	// includes only transaction hashes.
+	// includes only transaction hashes. The number of a null round resolves to the block preceding
+	// it.
 	// Maps to JSON-RPC method: "eth_getBlockByNumber".
 	EthGetBlockByNumber(ctx context.Context, blkNum string, fullTxInfo bool) (ethtypes.EthBlock, error) //perm:read
 
-	// EthGetTransactionByHash retrieves a transaction by its hash.
+	// EthGetTransactionByHash retrieves a transaction by its hash. As in Ethereum, the block fields
+	// describe the transaction's inclusion on the canonical chain; a transaction whose block was
+	// reorged out is reported as pending once it's back in the mpool. Use
+	// EthGetTransactionByBlockHashAndIndex to retrieve it from the orphaned block.
 	// Maps to JSON-RPC method: "eth_getTransactionByHash".
 	EthGetTransactionByHash(ctx context.Context, txHash *ethtypes.EthHash) (*ethtypes.EthTx, error) //perm:read
 
@@ -279,6 +290,16 @@ type FullNode interface {
 	// optional limit on the chain epoch for state resolution.
 	EthGetTransactionReceiptLimited(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*ethtypes.EthTxReceipt, error) //perm:read
 
+	// EthGetTransactionReceipts retrieves the receipts of several transactions by their hashes. The
+	// receipts are returned in the order of the hashes, with null entries for transactions that
+	// can't be found.
+	// Maps to JSON-RPC method: "eth_getTransactionReceipts".
+	EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) //perm:read
+
+	// EthGetTransactionReceiptsLimited retrieves the receipts of several transactions by their
+	// hashes, with an optional limit on the chain epoch for state resolution.
+	EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) //perm:read
+
 	// EthGetBlockReceipts retrieves all transaction receipts for a block identified by its number,
 	// hash or a special tag like "latest" or "finalized".
 	// Maps to JSON-RPC method: "eth_getBlockReceipts".
@@ -303,10 +324,20 @@ type FullNode interface {
 	EthGetStorageAt(ctx context.Context, address ethtypes.EthAddress, position ethtypes.EthBytes, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) //perm:read
 
 	// EthGetBalance retrieves the balance of an Ethereum address at a specific block state,
-	// identified by its number, hash, or a special tag like "latest" or "finalized".
+	// identified by its number, hash, or a special tag like "latest" or "finalized". At "pending",
+	// the messages of the message pool are applied on top of the head first, up to a tenth of a
+	// block's gas limit, so that in-flight transfers are accounted for.
 	// Maps to JSON-RPC method: "eth_getBalance".
 	EthGetBalance(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBigInt, error) //perm:read
 
+	// EthGetProof retrieves the account of an Ethereum address and the values of the given storage
+	// slots at a specific block state, identified by its number, hash, or a special tag like
+	// "latest" or "finalized". The Filecoin state isn't a Merkle Patricia trie: the account proof
+	// holds the raw IPLD blocks read from the state root to the actor, and storage proofs aren't
+	// available. See ethtypes.EthProof.
+	// Maps to JSON-RPC method: "eth_getProof".
+	EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) //perm:read
+
 	// EthTraceAPI methods
 
 	// EthTraceBlock returns an OpenEthereum-compatible trace of the given block.
@@ -343,6 +374,22 @@ type FullNode interface {
 	// Maps to JSON-RPC method: "trace_transaction".
 	EthTraceTransaction(ctx context.Context, txHash string) ([]*ethtypes.EthTraceTransaction, error) //perm:read
 
+	// EthReplayTransaction re-executes a mined transaction on top of the state it was originally
+	// executed on, returning its outcome, the gas it used and its trace.
+	// Maps to JSON-RPC method: "debug_replayTransaction".
+	EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) //perm:read
+
+	// EthTraceBlockByNumber replays the transactions of the given block and returns their traces, as
+	// Geth's debug_traceBlockByNumber does with the callTracer, keyed by transaction hash. It takes
+	// the block number or tag and the trace config, which may leave out the messages that don't
+	// invoke the EVM.
+	// Maps to JSON-RPC method: "debug_traceBlockByNumber".
+	EthTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) //perm:read
+
+	// EthTraceBlockByHash is like EthTraceBlockByNumber, but takes the hash of the block.
+	// Maps to JSON-RPC method: "debug_traceBlockByHash".
+	EthTraceBlockByHash(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) //perm:read
+
 	// EthTraceFilter returns traces matching the given filter criteria.
 	// Maps to JSON-RPC method: "trace_filter".
 	EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) //perm:read
@@ -366,9 +413,40 @@ type FullNode interface {
 	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error) //perm:read
 
 	// EthCall executes a read-only call to a contract at a specific block state, identified by
-	// its number, hash, or a special tag like "latest" or "finalized".
+	// its number, hash, or a special tag like "latest" or "finalized". The block defaults to
+	// "latest" and may be followed by a set of state overrides (nonce, balance, code) applied to the
+	// state before the call is made. At "pending", the messages of the message pool are applied on
+	// top of the head first, up to a tenth of a block's gas limit, starting with those of the caller.
 	// Maps to JSON-RPC method: "eth_call".
-	EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) //perm:read
+	EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) //perm:read
+
+	// EthCallDetailed takes the same parameters as EthCall. For contract creations, it returns the
+	// runtime code of the created contract along with the address it would be deployed at.
+	// If EthCall.ReportTouched is set, it lists the addresses the call touched: the actors it invoked
+	// and those whose code it read. Accounts whose balance was only read with BALANCE aren't listed,
+	// as the FVM doesn't trace balance lookups.
+	// Maps to JSON-RPC method: "eth_callDetailed".
+	EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) //perm:read
+
+	// EthCallMany executes a sequence of calls at a block, each one on the state left by the
+	// previous ones, so that e.g. a contract can be deployed and then called. A call reverting
+	// doesn't stop the sequence; its error is reported in its result. Passing
+	// {"traceReverts": true} as fourth parameter, after the state overrides, attaches the call
+	// frame of the calls that revert to their result.
+	// Maps to JSON-RPC method: "eth_callMany".
+	EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) //perm:read
+
+	// EthCallAtStateRoot executes a call like EthCall, but on the state tree with the given root
+	// rather than on the state at a block, e.g. to simulate calls on a checkpointed state. It takes
+	// the call and the state root, and applies the call at the epoch of the heaviest tipset.
+	// Maps to JSON-RPC method: "eth_callAtStateRoot".
+	EthCallAtStateRoot(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) //perm:read
+
+	// EthTraceCall executes a call like EthCall and returns its trace, as Geth's debug_traceCall
+	// does with the callTracer or the prestateTracer. It takes the call, the block and the trace
+	// config, which may carry state and block overrides. Reverted calls are traced too.
+	// Maps to JSON-RPC method: "debug_traceCall".
+	EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) //perm:read
 
 	// EthEventsAPI methods
 
@@ -376,6 +454,17 @@ type FullNode interface {
 	// Maps to JSON-RPC method: "eth_getLogs".
 	EthGetLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error) //perm:read
 
+	// EthEstimateLogsCount returns an approximate number of event logs matching the given filter
+	// specification, counted from the chain index without loading the logs. It is not subject to
+	// the max results limit of EthGetLogs and can be used to decide whether to paginate a query.
+	// Maps to JSON-RPC method: "eth_estimateLogsCount".
+	EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) //perm:read
+
+	// EthExplainLogs describes how EthGetLogs would execute the given filter specification without
+	// executing it: the resolved block range, the number of tipsets scanned and the criteria the
+	// chain index narrows the scan with. Meant to help operators diagnose slow queries.
+	EthExplainLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) //perm:admin
+
 	// EthNewBlockFilter installs a persistent filter to notify when a new block arrives.
 	// Maps to JSON-RPC method: "eth_newBlockFilter".
 	EthNewBlockFilter(ctx context.Context) (ethtypes.EthFilterID, error) //perm:read
@@ -409,7 +498,9 @@ type FullNode interface {
 	//  - pendingTransactions: notify when new messages arrive in the message pool.
 	//  - logs: notify new event logs that match a criteria
 	//
-	// params contains additional parameters used with the log event type
+	// params contains additional parameters used with the log event type, which are matched like the
+	// criteria of EthGetLogs. When a tipset is reverted, the logs it emitted are sent again with removed
+	// set to true.
 	// The client will receive a stream of EthSubscriptionResponse values until EthUnsubscribe is called.
 	EthSubscribe(ctx context.Context, params jsonrpc.RawParams) (ethtypes.EthSubscriptionID, error) //perm:read
 
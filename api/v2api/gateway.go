@@ -21,6 +21,7 @@ type Gateway interface {
 	StateGetActor(context.Context, address.Address, types.TipSetSelector) (*types.Actor, error)
 	StateGetID(context.Context, address.Address, types.TipSetSelector) (*address.Address, error)
 	EthAddressToFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error)
+	EthAddressToRobustFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error)
 	FilecoinAddressToEthAddress(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthAddress, error)
 	Web3ClientVersion(ctx context.Context) (string, error)
 	EthChainId(ctx context.Context) (ethtypes.EthUint64, error)
@@ -45,21 +46,33 @@ type Gateway interface {
 	EthGetTransactionCount(ctx context.Context, sender ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthUint64, error)
 	EthGetTransactionReceipt(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthTxReceipt, error)
 	EthGetTransactionReceiptLimited(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*ethtypes.EthTxReceipt, error)
+	EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error)
+	EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error)
 	EthGetBlockReceipts(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash) ([]*ethtypes.EthTxReceipt, error)
 	EthGetBlockReceiptsLimited(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error)
 	EthGetCode(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
 	EthGetStorageAt(ctx context.Context, address ethtypes.EthAddress, position ethtypes.EthBytes, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
 	EthGetBalance(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBigInt, error)
+	EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error)
 	EthTraceBlock(ctx context.Context, blkNum string) ([]*ethtypes.EthTraceBlock, error)
 	EthTraceReplayBlockTransactions(ctx context.Context, blkNum string, traceTypes []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error)
 	EthTraceTransaction(ctx context.Context, txHash string) ([]*ethtypes.EthTraceTransaction, error)
+	EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error)
+	EthTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error)
+	EthTraceBlockByHash(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error)
 	EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error)
 	EthGasPrice(ctx context.Context) (ethtypes.EthBigInt, error)
 	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthFeeHistory, error)
 	EthMaxPriorityFeePerGas(ctx context.Context) (ethtypes.EthBigInt, error)
 	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error)
-	EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
+	EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error)
+	EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error)
+	EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error)
+	EthCallAtStateRoot(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error)
+	EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error)
 	EthGetLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error)
+	EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error)
+	EthExplainLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error)
 	EthNewBlockFilter(ctx context.Context) (ethtypes.EthFilterID, error)
 	EthNewPendingTransactionFilter(ctx context.Context) (ethtypes.EthFilterID, error)
 	EthNewFilter(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthFilterID, error)
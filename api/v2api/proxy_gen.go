@@ -30,14 +30,26 @@ type FullNodeMethods struct {
 
 	EthAddressToFilecoinAddress func(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) `perm:"read"`
 
+	EthAddressToRobustFilecoinAddress func(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) `perm:"read"`
+
 	EthBlockNumber func(p0 context.Context) (ethtypes.EthUint64, error) `perm:"read"`
 
-	EthCall func(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) `perm:"read"`
+	EthCall func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) `perm:"read"`
+
+	EthCallAtStateRoot func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) `perm:"read"`
+
+	EthCallDetailed func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) `perm:"read"`
+
+	EthCallMany func(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) `perm:"read"`
 
 	EthChainId func(p0 context.Context) (ethtypes.EthUint64, error) `perm:"read"`
 
 	EthEstimateGas func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthUint64, error) `perm:"read"`
 
+	EthEstimateLogsCount func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) `perm:"read"`
+
+	EthExplainLogs func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) `perm:"admin"`
+
 	EthFeeHistory func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) `perm:"read"`
 
 	EthGasPrice func(p0 context.Context) (ethtypes.EthBigInt, error) `perm:"read"`
@@ -66,6 +78,8 @@ type FullNodeMethods struct {
 
 	EthGetMessageCidByTransactionHash func(p0 context.Context, p1 *ethtypes.EthHash) (*cid.Cid, error) `perm:"read"`
 
+	EthGetProof func(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) `perm:"read"`
+
 	EthGetStorageAt func(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) `perm:"read"`
 
 	EthGetTransactionByBlockHashAndIndex func(p0 context.Context, p1 ethtypes.EthHash, p2 ethtypes.EthUint64) (*ethtypes.EthTx, error) `perm:"read"`
@@ -84,6 +98,10 @@ type FullNodeMethods struct {
 
 	EthGetTransactionReceiptLimited func(p0 context.Context, p1 ethtypes.EthHash, p2 abi.ChainEpoch) (*ethtypes.EthTxReceipt, error) `perm:"read"`
 
+	EthGetTransactionReceipts func(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) `perm:"read"`
+
+	EthGetTransactionReceiptsLimited func(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) `perm:"read"`
+
 	EthMaxPriorityFeePerGas func(p0 context.Context) (ethtypes.EthBigInt, error) `perm:"read"`
 
 	EthNewBlockFilter func(p0 context.Context) (ethtypes.EthFilterID, error) `perm:"read"`
@@ -94,6 +112,8 @@ type FullNodeMethods struct {
 
 	EthProtocolVersion func(p0 context.Context) (ethtypes.EthUint64, error) `perm:"read"`
 
+	EthReplayTransaction func(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) `perm:"read"`
+
 	EthSendRawTransaction func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) `perm:"read"`
 
 	EthSendRawTransactionUntrusted func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) `perm:"read"`
@@ -104,6 +124,12 @@ type FullNodeMethods struct {
 
 	EthTraceBlock func(p0 context.Context, p1 string) ([]*ethtypes.EthTraceBlock, error) `perm:"read"`
 
+	EthTraceBlockByHash func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) `perm:"read"`
+
+	EthTraceBlockByNumber func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) `perm:"read"`
+
+	EthTraceCall func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) `perm:"read"`
+
 	EthTraceFilter func(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) `perm:"read"`
 
 	EthTraceReplayBlockTransactions func(p0 context.Context, p1 string, p2 []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error) `perm:"read"`
@@ -143,14 +169,26 @@ type GatewayMethods struct {
 
 	EthAddressToFilecoinAddress func(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) ``
 
+	EthAddressToRobustFilecoinAddress func(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) ``
+
 	EthBlockNumber func(p0 context.Context) (ethtypes.EthUint64, error) ``
 
-	EthCall func(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) ``
+	EthCall func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) ``
+
+	EthCallAtStateRoot func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) ``
+
+	EthCallDetailed func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) ``
+
+	EthCallMany func(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) ``
 
 	EthChainId func(p0 context.Context) (ethtypes.EthUint64, error) ``
 
 	EthEstimateGas func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthUint64, error) ``
 
+	EthEstimateLogsCount func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) ``
+
+	EthExplainLogs func(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) ``
+
 	EthFeeHistory func(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) ``
 
 	EthGasPrice func(p0 context.Context) (ethtypes.EthBigInt, error) ``
@@ -179,6 +217,8 @@ type GatewayMethods struct {
 
 	EthGetMessageCidByTransactionHash func(p0 context.Context, p1 *ethtypes.EthHash) (*cid.Cid, error) ``
 
+	EthGetProof func(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) ``
+
 	EthGetStorageAt func(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) ``
 
 	EthGetTransactionByBlockHashAndIndex func(p0 context.Context, p1 ethtypes.EthHash, p2 ethtypes.EthUint64) (*ethtypes.EthTx, error) ``
@@ -197,6 +237,10 @@ type GatewayMethods struct {
 
 	EthGetTransactionReceiptLimited func(p0 context.Context, p1 ethtypes.EthHash, p2 abi.ChainEpoch) (*ethtypes.EthTxReceipt, error) ``
 
+	EthGetTransactionReceipts func(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) ``
+
+	EthGetTransactionReceiptsLimited func(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) ``
+
 	EthMaxPriorityFeePerGas func(p0 context.Context) (ethtypes.EthBigInt, error) ``
 
 	EthNewBlockFilter func(p0 context.Context) (ethtypes.EthFilterID, error) ``
@@ -207,6 +251,8 @@ type GatewayMethods struct {
 
 	EthProtocolVersion func(p0 context.Context) (ethtypes.EthUint64, error) ``
 
+	EthReplayTransaction func(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) ``
+
 	EthSendRawTransaction func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) ``
 
 	EthSendRawTransactionUntrusted func(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) ``
@@ -217,6 +263,12 @@ type GatewayMethods struct {
 
 	EthTraceBlock func(p0 context.Context, p1 string) ([]*ethtypes.EthTraceBlock, error) ``
 
+	EthTraceBlockByHash func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) ``
+
+	EthTraceBlockByNumber func(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) ``
+
+	EthTraceCall func(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) ``
+
 	EthTraceFilter func(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) ``
 
 	EthTraceReplayBlockTransactions func(p0 context.Context, p1 string, p2 []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error) ``
@@ -276,6 +328,17 @@ func (s *FullNodeStub) EthAddressToFilecoinAddress(p0 context.Context, p1 ethtyp
 	return *new(address.Address), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthAddressToRobustFilecoinAddress(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) {
+	if s.Internal.EthAddressToRobustFilecoinAddress == nil {
+		return *new(address.Address), ErrNotSupported
+	}
+	return s.Internal.EthAddressToRobustFilecoinAddress(p0, p1)
+}
+
+func (s *FullNodeStub) EthAddressToRobustFilecoinAddress(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) {
+	return *new(address.Address), ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthBlockNumber == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -287,17 +350,50 @@ func (s *FullNodeStub) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, e
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
-func (s *FullNodeStruct) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *FullNodeStruct) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	if s.Internal.EthCall == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
 	}
-	return s.Internal.EthCall(p0, p1, p2)
+	return s.Internal.EthCall(p0, p1)
+}
+
+func (s *FullNodeStub) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	return *new(ethtypes.EthBytes), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthCallAtStateRoot(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	if s.Internal.EthCallAtStateRoot == nil {
+		return *new(ethtypes.EthBytes), ErrNotSupported
+	}
+	return s.Internal.EthCallAtStateRoot(p0, p1)
 }
 
-func (s *FullNodeStub) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *FullNodeStub) EthCallAtStateRoot(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	return *new(ethtypes.EthBytes), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	if s.Internal.EthCallDetailed == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthCallDetailed(p0, p1)
+}
+
+func (s *FullNodeStub) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	if s.Internal.EthCallMany == nil {
+		return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+	}
+	return s.Internal.EthCallMany(p0, p1)
+}
+
+func (s *FullNodeStub) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthChainId(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthChainId == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -320,6 +416,28 @@ func (s *FullNodeStub) EthEstimateGas(p0 context.Context, p1 jsonrpc.RawParams)
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	if s.Internal.EthEstimateLogsCount == nil {
+		return *new(ethtypes.EthUint64), ErrNotSupported
+	}
+	return s.Internal.EthEstimateLogsCount(p0, p1)
+}
+
+func (s *FullNodeStub) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	return *new(ethtypes.EthUint64), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthExplainLogs(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	if s.Internal.EthExplainLogs == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthExplainLogs(p0, p1)
+}
+
+func (s *FullNodeStub) EthExplainLogs(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthFeeHistory(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) {
 	if s.Internal.EthFeeHistory == nil {
 		return *new(ethtypes.EthFeeHistory), ErrNotSupported
@@ -474,6 +592,17 @@ func (s *FullNodeStub) EthGetMessageCidByTransactionHash(p0 context.Context, p1
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	if s.Internal.EthGetProof == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthGetProof(p0, p1, p2, p3)
+}
+
+func (s *FullNodeStub) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthGetStorageAt(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
 	if s.Internal.EthGetStorageAt == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
@@ -573,6 +702,28 @@ func (s *FullNodeStub) EthGetTransactionReceiptLimited(p0 context.Context, p1 et
 	return nil, ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceipts == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceipts(p0, p1)
+}
+
+func (s *FullNodeStub) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthGetTransactionReceiptsLimited(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceiptsLimited == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceiptsLimited(p0, p1, p2)
+}
+
+func (s *FullNodeStub) EthGetTransactionReceiptsLimited(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthMaxPriorityFeePerGas(p0 context.Context) (ethtypes.EthBigInt, error) {
 	if s.Internal.EthMaxPriorityFeePerGas == nil {
 		return *new(ethtypes.EthBigInt), ErrNotSupported
@@ -628,6 +779,17 @@ func (s *FullNodeStub) EthProtocolVersion(p0 context.Context) (ethtypes.EthUint6
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	if s.Internal.EthReplayTransaction == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthReplayTransaction(p0, p1)
+}
+
+func (s *FullNodeStub) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthSendRawTransaction(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) {
 	if s.Internal.EthSendRawTransaction == nil {
 		return *new(ethtypes.EthHash), ErrNotSupported
@@ -683,6 +845,39 @@ func (s *FullNodeStub) EthTraceBlock(p0 context.Context, p1 string) ([]*ethtypes
 	return *new([]*ethtypes.EthTraceBlock), ErrNotSupported
 }
 
+func (s *FullNodeStruct) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByHash == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByHash(p0, p1)
+}
+
+func (s *FullNodeStub) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByNumber == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByNumber(p0, p1)
+}
+
+func (s *FullNodeStub) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *FullNodeStruct) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	if s.Internal.EthTraceCall == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthTraceCall(p0, p1)
+}
+
+func (s *FullNodeStub) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *FullNodeStruct) EthTraceFilter(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	if s.Internal.EthTraceFilter == nil {
 		return *new([]*ethtypes.EthTraceFilterResult), ErrNotSupported
@@ -848,6 +1043,17 @@ func (s *GatewayStub) EthAddressToFilecoinAddress(p0 context.Context, p1 ethtype
 	return *new(address.Address), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthAddressToRobustFilecoinAddress(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) {
+	if s.Internal.EthAddressToRobustFilecoinAddress == nil {
+		return *new(address.Address), ErrNotSupported
+	}
+	return s.Internal.EthAddressToRobustFilecoinAddress(p0, p1)
+}
+
+func (s *GatewayStub) EthAddressToRobustFilecoinAddress(p0 context.Context, p1 ethtypes.EthAddress) (address.Address, error) {
+	return *new(address.Address), ErrNotSupported
+}
+
 func (s *GatewayStruct) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthBlockNumber == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -859,17 +1065,50 @@ func (s *GatewayStub) EthBlockNumber(p0 context.Context) (ethtypes.EthUint64, er
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
-func (s *GatewayStruct) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *GatewayStruct) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	if s.Internal.EthCall == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
 	}
-	return s.Internal.EthCall(p0, p1, p2)
+	return s.Internal.EthCall(p0, p1)
 }
 
-func (s *GatewayStub) EthCall(p0 context.Context, p1 ethtypes.EthCall, p2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (s *GatewayStub) EthCall(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	return *new(ethtypes.EthBytes), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthCallAtStateRoot(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	if s.Internal.EthCallAtStateRoot == nil {
+		return *new(ethtypes.EthBytes), ErrNotSupported
+	}
+	return s.Internal.EthCallAtStateRoot(p0, p1)
+}
+
+func (s *GatewayStub) EthCallAtStateRoot(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	return *new(ethtypes.EthBytes), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	if s.Internal.EthCallDetailed == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthCallDetailed(p0, p1)
+}
+
+func (s *GatewayStub) EthCallDetailed(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *GatewayStruct) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	if s.Internal.EthCallMany == nil {
+		return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+	}
+	return s.Internal.EthCallMany(p0, p1)
+}
+
+func (s *GatewayStub) EthCallMany(p0 context.Context, p1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	return *new([]ethtypes.EthCallManyResult), ErrNotSupported
+}
+
 func (s *GatewayStruct) EthChainId(p0 context.Context) (ethtypes.EthUint64, error) {
 	if s.Internal.EthChainId == nil {
 		return *new(ethtypes.EthUint64), ErrNotSupported
@@ -892,6 +1131,28 @@ func (s *GatewayStub) EthEstimateGas(p0 context.Context, p1 jsonrpc.RawParams) (
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	if s.Internal.EthEstimateLogsCount == nil {
+		return *new(ethtypes.EthUint64), ErrNotSupported
+	}
+	return s.Internal.EthEstimateLogsCount(p0, p1)
+}
+
+func (s *GatewayStub) EthEstimateLogsCount(p0 context.Context, p1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	return *new(ethtypes.EthUint64), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthExplainLogs(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	if s.Internal.EthExplainLogs == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthExplainLogs(p0, p1)
+}
+
+func (s *GatewayStub) EthExplainLogs(p0 context.Context, p1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthFeeHistory(p0 context.Context, p1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) {
 	if s.Internal.EthFeeHistory == nil {
 		return *new(ethtypes.EthFeeHistory), ErrNotSupported
@@ -1046,6 +1307,17 @@ func (s *GatewayStub) EthGetMessageCidByTransactionHash(p0 context.Context, p1 *
 	return nil, ErrNotSupported
 }
 
+func (s *GatewayStruct) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	if s.Internal.EthGetProof == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthGetProof(p0, p1, p2, p3)
+}
+
+func (s *GatewayStub) EthGetProof(p0 context.Context, p1 ethtypes.EthAddress, p2 []ethtypes.EthHash, p3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthGetStorageAt(p0 context.Context, p1 ethtypes.EthAddress, p2 ethtypes.EthBytes, p3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
 	if s.Internal.EthGetStorageAt == nil {
 		return *new(ethtypes.EthBytes), ErrNotSupported
@@ -1145,6 +1417,28 @@ func (s *GatewayStub) EthGetTransactionReceiptLimited(p0 context.Context, p1 eth
 	return nil, ErrNotSupported
 }
 
+func (s *GatewayStruct) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceipts == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceipts(p0, p1)
+}
+
+func (s *GatewayStub) EthGetTransactionReceipts(p0 context.Context, p1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthGetTransactionReceiptsLimited(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	if s.Internal.EthGetTransactionReceiptsLimited == nil {
+		return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+	}
+	return s.Internal.EthGetTransactionReceiptsLimited(p0, p1, p2)
+}
+
+func (s *GatewayStub) EthGetTransactionReceiptsLimited(p0 context.Context, p1 []ethtypes.EthHash, p2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	return *new([]*ethtypes.EthTxReceipt), ErrNotSupported
+}
+
 func (s *GatewayStruct) EthMaxPriorityFeePerGas(p0 context.Context) (ethtypes.EthBigInt, error) {
 	if s.Internal.EthMaxPriorityFeePerGas == nil {
 		return *new(ethtypes.EthBigInt), ErrNotSupported
@@ -1200,6 +1494,17 @@ func (s *GatewayStub) EthProtocolVersion(p0 context.Context) (ethtypes.EthUint64
 	return *new(ethtypes.EthUint64), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	if s.Internal.EthReplayTransaction == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthReplayTransaction(p0, p1)
+}
+
+func (s *GatewayStub) EthReplayTransaction(p0 context.Context, p1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthSendRawTransaction(p0 context.Context, p1 ethtypes.EthBytes) (ethtypes.EthHash, error) {
 	if s.Internal.EthSendRawTransaction == nil {
 		return *new(ethtypes.EthHash), ErrNotSupported
@@ -1255,6 +1560,39 @@ func (s *GatewayStub) EthTraceBlock(p0 context.Context, p1 string) ([]*ethtypes.
 	return *new([]*ethtypes.EthTraceBlock), ErrNotSupported
 }
 
+func (s *GatewayStruct) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByHash == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByHash(p0, p1)
+}
+
+func (s *GatewayStub) EthTraceBlockByHash(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	if s.Internal.EthTraceBlockByNumber == nil {
+		return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+	}
+	return s.Internal.EthTraceBlockByNumber(p0, p1)
+}
+
+func (s *GatewayStub) EthTraceBlockByNumber(p0 context.Context, p1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return *new([]*ethtypes.EthTraceBlockResult), ErrNotSupported
+}
+
+func (s *GatewayStruct) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	if s.Internal.EthTraceCall == nil {
+		return nil, ErrNotSupported
+	}
+	return s.Internal.EthTraceCall(p0, p1)
+}
+
+func (s *GatewayStub) EthTraceCall(p0 context.Context, p1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	return nil, ErrNotSupported
+}
+
 func (s *GatewayStruct) EthTraceFilter(p0 context.Context, p1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	if s.Internal.EthTraceFilter == nil {
 		return *new([]*ethtypes.EthTraceFilterResult), ErrNotSupported
@@ -87,6 +87,21 @@ func (mr *MockFullNodeMockRecorder) EthAddressToFilecoinAddress(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthAddressToFilecoinAddress", reflect.TypeOf((*MockFullNode)(nil).EthAddressToFilecoinAddress), arg0, arg1)
 }
 
+// EthAddressToRobustFilecoinAddress mocks base method.
+func (m *MockFullNode) EthAddressToRobustFilecoinAddress(arg0 context.Context, arg1 ethtypes.EthAddress) (address.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthAddressToRobustFilecoinAddress", arg0, arg1)
+	ret0, _ := ret[0].(address.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthAddressToRobustFilecoinAddress indicates an expected call of EthAddressToRobustFilecoinAddress.
+func (mr *MockFullNodeMockRecorder) EthAddressToRobustFilecoinAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthAddressToRobustFilecoinAddress", reflect.TypeOf((*MockFullNode)(nil).EthAddressToRobustFilecoinAddress), arg0, arg1)
+}
+
 // EthBlockNumber mocks base method.
 func (m *MockFullNode) EthBlockNumber(arg0 context.Context) (ethtypes.EthUint64, error) {
 	m.ctrl.T.Helper()
@@ -103,18 +118,63 @@ func (mr *MockFullNodeMockRecorder) EthBlockNumber(arg0 interface{}) *gomock.Cal
 }
 
 // EthCall mocks base method.
-func (m *MockFullNode) EthCall(arg0 context.Context, arg1 ethtypes.EthCall, arg2 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (m *MockFullNode) EthCall(arg0 context.Context, arg1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EthCall", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "EthCall", arg0, arg1)
 	ret0, _ := ret[0].(ethtypes.EthBytes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EthCall indicates an expected call of EthCall.
-func (mr *MockFullNodeMockRecorder) EthCall(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockFullNodeMockRecorder) EthCall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthCall", reflect.TypeOf((*MockFullNode)(nil).EthCall), arg0, arg1)
+}
+
+// EthCallAtStateRoot mocks base method.
+func (m *MockFullNode) EthCallAtStateRoot(arg0 context.Context, arg1 jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthCallAtStateRoot", arg0, arg1)
+	ret0, _ := ret[0].(ethtypes.EthBytes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthCallAtStateRoot indicates an expected call of EthCallAtStateRoot.
+func (mr *MockFullNodeMockRecorder) EthCallAtStateRoot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthCallAtStateRoot", reflect.TypeOf((*MockFullNode)(nil).EthCallAtStateRoot), arg0, arg1)
+}
+
+// EthCallDetailed mocks base method.
+func (m *MockFullNode) EthCallDetailed(arg0 context.Context, arg1 jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthCallDetailed", arg0, arg1)
+	ret0, _ := ret[0].(*ethtypes.EthCallDetailedResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthCallDetailed indicates an expected call of EthCallDetailed.
+func (mr *MockFullNodeMockRecorder) EthCallDetailed(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthCall", reflect.TypeOf((*MockFullNode)(nil).EthCall), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthCallDetailed", reflect.TypeOf((*MockFullNode)(nil).EthCallDetailed), arg0, arg1)
+}
+
+// EthCallMany mocks base method.
+func (m *MockFullNode) EthCallMany(arg0 context.Context, arg1 jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthCallMany", arg0, arg1)
+	ret0, _ := ret[0].([]ethtypes.EthCallManyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthCallMany indicates an expected call of EthCallMany.
+func (mr *MockFullNodeMockRecorder) EthCallMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthCallMany", reflect.TypeOf((*MockFullNode)(nil).EthCallMany), arg0, arg1)
 }
 
 // EthChainId mocks base method.
@@ -147,6 +207,36 @@ func (mr *MockFullNodeMockRecorder) EthEstimateGas(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthEstimateGas", reflect.TypeOf((*MockFullNode)(nil).EthEstimateGas), arg0, arg1)
 }
 
+// EthEstimateLogsCount mocks base method.
+func (m *MockFullNode) EthEstimateLogsCount(arg0 context.Context, arg1 *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthEstimateLogsCount", arg0, arg1)
+	ret0, _ := ret[0].(ethtypes.EthUint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthEstimateLogsCount indicates an expected call of EthEstimateLogsCount.
+func (mr *MockFullNodeMockRecorder) EthEstimateLogsCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthEstimateLogsCount", reflect.TypeOf((*MockFullNode)(nil).EthEstimateLogsCount), arg0, arg1)
+}
+
+// EthExplainLogs mocks base method.
+func (m *MockFullNode) EthExplainLogs(arg0 context.Context, arg1 *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthExplainLogs", arg0, arg1)
+	ret0, _ := ret[0].(*ethtypes.EthLogsQueryPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthExplainLogs indicates an expected call of EthExplainLogs.
+func (mr *MockFullNodeMockRecorder) EthExplainLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthExplainLogs", reflect.TypeOf((*MockFullNode)(nil).EthExplainLogs), arg0, arg1)
+}
+
 // EthFeeHistory mocks base method.
 func (m *MockFullNode) EthFeeHistory(arg0 context.Context, arg1 jsonrpc.RawParams) (ethtypes.EthFeeHistory, error) {
 	m.ctrl.T.Helper()
@@ -357,6 +447,21 @@ func (mr *MockFullNodeMockRecorder) EthGetMessageCidByTransactionHash(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetMessageCidByTransactionHash", reflect.TypeOf((*MockFullNode)(nil).EthGetMessageCidByTransactionHash), arg0, arg1)
 }
 
+// EthGetProof mocks base method.
+func (m *MockFullNode) EthGetProof(arg0 context.Context, arg1 ethtypes.EthAddress, arg2 []ethtypes.EthHash, arg3 ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetProof", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*ethtypes.EthProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetProof indicates an expected call of EthGetProof.
+func (mr *MockFullNodeMockRecorder) EthGetProof(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetProof", reflect.TypeOf((*MockFullNode)(nil).EthGetProof), arg0, arg1, arg2, arg3)
+}
+
 // EthGetStorageAt mocks base method.
 func (m *MockFullNode) EthGetStorageAt(arg0 context.Context, arg1 ethtypes.EthAddress, arg2 ethtypes.EthBytes, arg3 ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
 	m.ctrl.T.Helper()
@@ -492,6 +597,36 @@ func (mr *MockFullNodeMockRecorder) EthGetTransactionReceiptLimited(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTransactionReceiptLimited", reflect.TypeOf((*MockFullNode)(nil).EthGetTransactionReceiptLimited), arg0, arg1, arg2)
 }
 
+// EthGetTransactionReceipts mocks base method.
+func (m *MockFullNode) EthGetTransactionReceipts(arg0 context.Context, arg1 []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetTransactionReceipts", arg0, arg1)
+	ret0, _ := ret[0].([]*ethtypes.EthTxReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetTransactionReceipts indicates an expected call of EthGetTransactionReceipts.
+func (mr *MockFullNodeMockRecorder) EthGetTransactionReceipts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTransactionReceipts", reflect.TypeOf((*MockFullNode)(nil).EthGetTransactionReceipts), arg0, arg1)
+}
+
+// EthGetTransactionReceiptsLimited mocks base method.
+func (m *MockFullNode) EthGetTransactionReceiptsLimited(arg0 context.Context, arg1 []ethtypes.EthHash, arg2 abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthGetTransactionReceiptsLimited", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*ethtypes.EthTxReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthGetTransactionReceiptsLimited indicates an expected call of EthGetTransactionReceiptsLimited.
+func (mr *MockFullNodeMockRecorder) EthGetTransactionReceiptsLimited(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthGetTransactionReceiptsLimited", reflect.TypeOf((*MockFullNode)(nil).EthGetTransactionReceiptsLimited), arg0, arg1, arg2)
+}
+
 // EthMaxPriorityFeePerGas mocks base method.
 func (m *MockFullNode) EthMaxPriorityFeePerGas(arg0 context.Context) (ethtypes.EthBigInt, error) {
 	m.ctrl.T.Helper()
@@ -567,6 +702,21 @@ func (mr *MockFullNodeMockRecorder) EthProtocolVersion(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthProtocolVersion", reflect.TypeOf((*MockFullNode)(nil).EthProtocolVersion), arg0)
 }
 
+// EthReplayTransaction mocks base method.
+func (m *MockFullNode) EthReplayTransaction(arg0 context.Context, arg1 ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthReplayTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*ethtypes.EthReplayTransactionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthReplayTransaction indicates an expected call of EthReplayTransaction.
+func (mr *MockFullNodeMockRecorder) EthReplayTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthReplayTransaction", reflect.TypeOf((*MockFullNode)(nil).EthReplayTransaction), arg0, arg1)
+}
+
 // EthSendRawTransaction mocks base method.
 func (m *MockFullNode) EthSendRawTransaction(arg0 context.Context, arg1 ethtypes.EthBytes) (ethtypes.EthHash, error) {
 	m.ctrl.T.Helper()
@@ -642,6 +792,51 @@ func (mr *MockFullNodeMockRecorder) EthTraceBlock(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceBlock", reflect.TypeOf((*MockFullNode)(nil).EthTraceBlock), arg0, arg1)
 }
 
+// EthTraceBlockByHash mocks base method.
+func (m *MockFullNode) EthTraceBlockByHash(arg0 context.Context, arg1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTraceBlockByHash", arg0, arg1)
+	ret0, _ := ret[0].([]*ethtypes.EthTraceBlockResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTraceBlockByHash indicates an expected call of EthTraceBlockByHash.
+func (mr *MockFullNodeMockRecorder) EthTraceBlockByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceBlockByHash", reflect.TypeOf((*MockFullNode)(nil).EthTraceBlockByHash), arg0, arg1)
+}
+
+// EthTraceBlockByNumber mocks base method.
+func (m *MockFullNode) EthTraceBlockByNumber(arg0 context.Context, arg1 jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTraceBlockByNumber", arg0, arg1)
+	ret0, _ := ret[0].([]*ethtypes.EthTraceBlockResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTraceBlockByNumber indicates an expected call of EthTraceBlockByNumber.
+func (mr *MockFullNodeMockRecorder) EthTraceBlockByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceBlockByNumber", reflect.TypeOf((*MockFullNode)(nil).EthTraceBlockByNumber), arg0, arg1)
+}
+
+// EthTraceCall mocks base method.
+func (m *MockFullNode) EthTraceCall(arg0 context.Context, arg1 jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EthTraceCall", arg0, arg1)
+	ret0, _ := ret[0].(*ethtypes.EthTraceCallResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EthTraceCall indicates an expected call of EthTraceCall.
+func (mr *MockFullNodeMockRecorder) EthTraceCall(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EthTraceCall", reflect.TypeOf((*MockFullNode)(nil).EthTraceCall), arg0, arg1)
+}
+
 // EthTraceFilter mocks base method.
 func (m *MockFullNode) EthTraceFilter(arg0 context.Context, arg1 ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	m.ctrl.T.Helper()
@@ -115,4 +115,11 @@ type State interface {
 	GetBytecode() ([]byte, error)
 	GetBytecodeCID() (cid.Cid, error)
 	GetBytecodeHash() ([32]byte, error)
+
+	// SetBytecode replaces the contract's bytecode. This should only be used to simulate calls
+	// against modified state.
+	SetBytecode(bytecode cid.Cid, hash [32]byte) error
+	// ClearStorage empties the contract's storage. This should only be used to simulate calls
+	// against modified state.
+	ClearStorage() error
 }
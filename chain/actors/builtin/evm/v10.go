@@ -57,6 +57,21 @@ func (s *state10) GetBytecodeHash() ([32]byte, error) {
 	return s.State.BytecodeHash, nil
 }
 
+func (s *state10) SetBytecode(bytecode cid.Cid, hash [32]byte) error {
+	s.State.Bytecode = bytecode
+	s.State.BytecodeHash = hash
+	return nil
+}
+
+func (s *state10) ClearStorage() error {
+	empty, err := evm10.ConstructState(s.store, s.State.Bytecode)
+	if err != nil {
+		return err
+	}
+	s.State.ContractState = empty.ContractState
+	return nil
+}
+
 func (s *state10) GetBytecode() ([]byte, error) {
 	bc, err := s.GetBytecodeCID()
 	if err != nil {
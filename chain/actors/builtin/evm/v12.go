@@ -57,6 +57,21 @@ func (s *state12) GetBytecodeHash() ([32]byte, error) {
 	return s.State.BytecodeHash, nil
 }
 
+func (s *state12) SetBytecode(bytecode cid.Cid, hash [32]byte) error {
+	s.State.Bytecode = bytecode
+	s.State.BytecodeHash = hash
+	return nil
+}
+
+func (s *state12) ClearStorage() error {
+	empty, err := evm12.ConstructState(s.store, s.State.Bytecode)
+	if err != nil {
+		return err
+	}
+	s.State.ContractState = empty.ContractState
+	return nil
+}
+
 func (s *state12) GetBytecode() ([]byte, error) {
 	bc, err := s.GetBytecodeCID()
 	if err != nil {
@@ -97,6 +97,11 @@ func (f *eventFilter) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 				continue
 			}
 
+			// number the events like the chain index does, counting the ones the filter doesn't
+			// match, so the log indexes of subscriptions agree with eth_getLogs
+			eventIdx := eventCount
+			eventCount++
+
 			if !f.matchAddress(addr) {
 				continue
 			}
@@ -108,7 +113,7 @@ func (f *eventFilter) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 			cev := &index.CollectedEvent{
 				Entries:     ev.Entries,
 				EmitterAddr: addr,
-				EventIdx:    eventCount,
+				EventIdx:    eventIdx,
 				Reverted:    revert,
 				Height:      te.msgTs.Height(),
 				TipSetKey:   te.msgTs.Key(),
@@ -130,7 +135,6 @@ func (f *eventFilter) CollectEvents(ctx context.Context, te *TipSetEvents, rever
 			}
 			f.collected = append(f.collected, cev)
 			f.mu.Unlock()
-			eventCount++
 		}
 	}
 
@@ -286,6 +286,52 @@ func TestEventFilterCollectEvents(t *testing.T) {
 	}
 }
 
+func TestEventFilterCollectEventsIndexesAllEvents(t *testing.T) {
+	rng := pseudo.New(pseudo.NewSource(299792458))
+	a1 := randomF4Addr(t, rng)
+	a2 := randomF4Addr(t, rng)
+
+	a1ID := abi.ActorID(1)
+	a2ID := abi.ActorID(2)
+
+	addrMap := addressMap{}
+	addrMap.add(a1ID, a1)
+	addrMap.add(a2ID, a2)
+
+	ev1 := fakeEvent(a1ID, []kv{{k: "type", v: []byte("approval")}}, nil)
+	ev2 := fakeEvent(a2ID, []kv{{k: "type", v: []byte("transfer")}}, nil)
+
+	st := newStore()
+	events := []*types.Event{ev1, ev2}
+	em := executedMessage{
+		msg: fakeMessage(randomF4Addr(t, rng), randomF4Addr(t, rng)),
+		rct: fakeReceipt(t, rng, st, events),
+		evs: events,
+	}
+	events14000 := buildTipSetEvents(t, rng, 14000, em)
+
+	// Events are numbered within the tipset like the chain index does, whether the filter matches
+	// the events before them or not, both when collecting and when pushing to a subscription.
+	f := &eventFilter{
+		minHeight: -1,
+		maxHeight: -1,
+		addresses: []address.Address{a2},
+	}
+	require.NoError(t, f.CollectEvents(context.Background(), events14000, false, addrMap.ResolveAddress))
+	coll := f.TakeCollectedEvents(context.Background())
+	require.Len(t, coll, 1)
+	require.Equal(t, a2, coll[0].EmitterAddr)
+	require.Equal(t, 1, coll[0].EventIdx)
+
+	ch := make(chan interface{}, 2)
+	f.SetSubChannel(ch)
+	require.NoError(t, f.CollectEvents(context.Background(), events14000, true, addrMap.ResolveAddress))
+	require.Len(t, ch, 1)
+	cev := (<-ch).(*index.CollectedEvent)
+	require.Equal(t, 1, cev.EventIdx)
+	require.True(t, cev.Reverted)
+}
+
 type kv struct {
 	k string
 	v []byte
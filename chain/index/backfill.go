@@ -0,0 +1,100 @@
+package index
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+// ChainBackfillIndex starts backfilling the index in the background over the epochs from `from` to
+// `to` inclusive, validating the epochs that are already indexed along the way. Only one backfill
+// can run at a time; its progress is reported by ChainBackfillIndexStatus.
+func (si *SqliteIndexer) ChainBackfillIndex(ctx context.Context, from, to abi.ChainEpoch) (*types.IndexBackfill, error) {
+	if !si.started {
+		return nil, errors.New("ChainBackfillIndex called before indexer start")
+	}
+	if si.isClosed() {
+		return nil, errors.New("ChainBackfillIndex called on closed indexer")
+	}
+
+	if from < 0 || from > to {
+		return nil, xerrors.Errorf("invalid backfill range: from epoch %d to epoch %d", from, to)
+	}
+	// epochs are backfilled through ChainValidateIndex, which only works for epochs < head
+	head := si.cs.GetHeaviestTipSet()
+	if to >= head.Height() {
+		return nil, xerrors.Errorf("cannot backfill index up to epoch %d, can only backfill up to an epoch less than chain head epoch %d", to, head.Height())
+	}
+
+	si.backfillLk.Lock()
+	defer si.backfillLk.Unlock()
+
+	// checked again under the lock, as Close waits on the backfill once it has cancelled the context
+	if si.isClosed() {
+		return nil, errors.New("ChainBackfillIndex called on closed indexer")
+	}
+	if si.backfill != nil && !si.backfill.Done {
+		return nil, xerrors.Errorf("backfill from epoch %d to epoch %d already in progress", si.backfill.From, si.backfill.To)
+	}
+	si.backfill = &types.IndexBackfill{From: from, To: to, Epoch: from}
+	progress := *si.backfill
+
+	si.wg.Add(1)
+	go si.backfillRange(from, to)
+
+	return &progress, nil
+}
+
+// ChainBackfillIndexStatus returns the progress of the running backfill, or of the last one if none
+// is running.
+func (si *SqliteIndexer) ChainBackfillIndexStatus(ctx context.Context) (*types.IndexBackfill, error) {
+	si.backfillLk.Lock()
+	defer si.backfillLk.Unlock()
+
+	if si.backfill == nil {
+		return nil, errors.New("no index backfill has been started")
+	}
+	progress := *si.backfill
+	return &progress, nil
+}
+
+func (si *SqliteIndexer) backfillRange(from, to abi.ChainEpoch) {
+	defer si.wg.Done()
+
+	log.Infof("backfilling index from epoch %d to epoch %d", from, to)
+
+	for epoch := from; epoch <= to; epoch++ {
+		res, err := si.ChainValidateIndex(si.ctx, epoch, true)
+		if err != nil {
+			log.Errorw("index backfill failed", "epoch", epoch, "error", err)
+			si.updateBackfill(func(p *types.IndexBackfill) {
+				p.Done = true
+				p.Error = err.Error()
+			})
+			return
+		}
+
+		si.updateBackfill(func(p *types.IndexBackfill) {
+			p.Epoch = epoch + 1
+			if res.Backfilled {
+				p.BackfilledTipSets++
+			}
+		})
+	}
+
+	si.updateBackfill(func(p *types.IndexBackfill) {
+		p.Done = true
+		log.Infof("backfilled %d tipsets in index from epoch %d to epoch %d", p.BackfilledTipSets, from, to)
+	})
+}
+
+func (si *SqliteIndexer) updateBackfill(update func(p *types.IndexBackfill)) {
+	si.backfillLk.Lock()
+	defer si.backfillLk.Unlock()
+	update(si.backfill)
+}
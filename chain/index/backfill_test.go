@@ -0,0 +1,105 @@
+package index
+
+import (
+	"context"
+	pseudo "math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+
+	"github.com/filecoin-project/lotus/chain/types"
+)
+
+func TestChainBackfillIndex(t *testing.T) {
+	ctx := context.Background()
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %d", seed)
+	rng := pseudo.New(pseudo.NewSource(seed))
+	headHeight := abi.ChainEpoch(60)
+
+	si, _, cs := setupWithHeadIndexed(t, headHeight, rng)
+	t.Cleanup(func() { _ = si.Close() })
+	si.Start()
+
+	si.SetActorToDelegatedAddresFunc(func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool) {
+		idAddr, err := address.NewIDAddress(uint64(emitter))
+		if err != nil {
+			return address.Undef, false
+		}
+		return idAddr, true
+	})
+
+	// Simulate a node that was started from a snapshot: the tipsets at heights 5 to 7 exist in the
+	// chain but were never indexed.
+	ts5 := fakeTipSet(t, rng, 5, nil)
+	ts6 := fakeTipSet(t, rng, 6, ts5.Cids())
+	ts7 := fakeTipSet(t, rng, 7, ts6.Cids())
+	for _, ts := range []*types.TipSet{ts5, ts6, ts7} {
+		cs.SetTipsetByHeightAndKey(ts.Height(), ts.Key(), ts)
+		cs.SetTipSetByCid(t, ts)
+	}
+
+	fm := fakeMessage(randomIDAddr(t, rng), randomIDAddr(t, rng))
+	ev := fakeEvent(1, []kv{{k: "type", v: []byte("approval")}}, nil)
+	cs.SetMessagesForTipset(ts5, []types.ChainMsg{fm})
+	si.setExecutedMessagesLoaderFunc(func(ctx context.Context, cs ChainStore, msgTs, rctTs *types.TipSet) ([]executedMessage, error) {
+		if msgTs.Height() == ts5.Height() {
+			return []executedMessage{{msg: fm, evs: []types.Event{*ev}}}, nil
+		}
+		return nil, nil
+	})
+
+	f := &EventFilter{MinHeight: 5, MaxHeight: 6}
+	_, err := si.GetEventsForFilter(ctx, f)
+	require.ErrorIs(t, err, &ErrRangeNotIndexed{})
+
+	_, err = si.ChainBackfillIndexStatus(ctx)
+	require.ErrorContains(t, err, "no index backfill has been started")
+
+	// invalid ranges are rejected upfront
+	_, err = si.ChainBackfillIndex(ctx, 6, 5)
+	require.ErrorContains(t, err, "invalid backfill range")
+	_, err = si.ChainBackfillIndex(ctx, 5, headHeight)
+	require.ErrorContains(t, err, "can only backfill up to an epoch less than chain head epoch")
+
+	progress, err := si.ChainBackfillIndex(ctx, 5, 6)
+	require.NoError(t, err)
+	require.Equal(t, &types.IndexBackfill{From: 5, To: 6, Epoch: 5}, progress)
+
+	waitForBackfill := func() *types.IndexBackfill {
+		var progress *types.IndexBackfill
+		require.Eventually(t, func() bool {
+			p, err := si.ChainBackfillIndexStatus(ctx)
+			if err != nil {
+				return false
+			}
+			progress = p
+			return p.Done
+		}, 10*time.Second, 10*time.Millisecond)
+		return progress
+	}
+
+	// backfilling the tipset at height 5 also indexes the tipset at height 6 it was executed by, which
+	// then only needs validating
+	progress = waitForBackfill()
+	require.Equal(t, &types.IndexBackfill{From: 5, To: 6, Epoch: 7, BackfilledTipSets: 1, Done: true}, progress)
+
+	// the backfilled range is now queryable
+	ces, err := si.GetEventsForFilter(ctx, f)
+	require.NoError(t, err)
+	require.Len(t, ces, 1)
+	require.Equal(t, abi.ChainEpoch(5), ces[0].Height)
+	require.Equal(t, fm.Cid(), ces[0].MsgCid)
+
+	// the backfill stops at the first epoch that fails
+	_, err = si.ChainBackfillIndex(ctx, 8, 9)
+	require.NoError(t, err)
+	progress = waitForBackfill()
+	require.Equal(t, abi.ChainEpoch(8), progress.Epoch)
+	require.Zero(t, progress.BackfilledTipSets)
+	require.Contains(t, progress.Error, "failed to get tipset at height 8")
+}
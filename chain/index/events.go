@@ -41,6 +41,27 @@ func (e *ErrRangeInFuture) Is(target error) bool {
 	return ok
 }
 
+// ErrRangeNotIndexed is returned when a height range starts before the earliest tipset present in
+// the index, e.g. on a node that was started from a snapshot and never backfilled. Events for the
+// missing part of the range are unavailable, so returning a partial or empty result would be
+// misleading.
+type ErrRangeNotIndexed struct {
+	EarliestEpoch abi.ChainEpoch
+}
+
+func (e *ErrRangeNotIndexed) Error() string {
+	return fmt.Sprintf("events not available before epoch %d (no event index)", e.EarliestEpoch)
+}
+
+// Is reports a match for any ErrRangeNotIndexed as well as ErrNotFound, as the range is not indexed.
+func (e *ErrRangeNotIndexed) Is(target error) bool {
+	if target == ErrNotFound {
+		return true
+	}
+	_, ok := target.(*ErrRangeNotIndexed)
+	return ok
+}
+
 type executedMessage struct {
 	msg types.ChainMsg
 	rct types.MessageReceipt
@@ -334,6 +355,47 @@ func (si *SqliteIndexer) checkRangeIndexedStatus(ctx context.Context, minHeight
 	return nil
 }
 
+// checkRangeStartIndexed returns ErrRangeNotIndexed if the height range of the filter starts before
+// the earliest non-reverted tipset in the index and at least one non-null round falls in that gap.
+// The genesis tipset carries no events and is not required to be indexed.
+func (si *SqliteIndexer) checkRangeStartIndexed(ctx context.Context, f *EventFilter) error {
+	if f.TipsetCid != cid.Undef || f.MinHeight < 0 {
+		return nil
+	}
+
+	var earliest sql.NullInt64
+	if err := si.stmts.getMinNonRevertedHeightStmt.QueryRowContext(ctx).Scan(&earliest); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return xerrors.Errorf("failed to get minimum non-reverted height: %w", err)
+	}
+	if !earliest.Valid {
+		return nil
+	}
+
+	earliestEpoch := abi.ChainEpoch(earliest.Int64)
+	gapStart := max(f.MinHeight, 1)
+	gapEnd := earliestEpoch - 1
+	if f.MaxHeight >= 0 {
+		gapEnd = min(gapEnd, f.MaxHeight)
+	}
+	if gapStart > gapEnd {
+		return nil
+	}
+
+	// Tipsets that can't be loaded are as good as unindexed, so only a gap consisting solely of
+	// null rounds is acceptable.
+	tsKeyCid, _, err := si.findFirstNonNullRound(ctx, gapStart, gapEnd)
+	if err == nil && tsKeyCid == nil {
+		return nil
+	}
+	if err != nil {
+		log.Debugw("failed to look for non-null rounds before the earliest indexed epoch", "error", err)
+	}
+	return &ErrRangeNotIndexed{EarliestEpoch: earliestEpoch}
+}
+
 func (si *SqliteIndexer) checkTipsetIndexedStatus(ctx context.Context, tipsetKeyCid []byte, height abi.ChainEpoch) error {
 	exists, err := si.isTipsetIndexed(ctx, tipsetKeyCid)
 	if err != nil {
@@ -398,8 +460,13 @@ func (si *SqliteIndexer) getTipsetKeyCidByHeight(ctx context.Context, height abi
 // GetEventsForFilter returns matching events for the given filter
 // Returns nil, nil if the filter has no matching events
 // Returns nil, ErrNotFound if the filter has no matching events and the tipset is not indexed
+// Returns nil, ErrRangeNotIndexed if the height range starts before the earliest indexed tipset
 // Returns nil, err for all other errors
 func (si *SqliteIndexer) GetEventsForFilter(ctx context.Context, f *EventFilter) ([]*CollectedEvent, error) {
+	if err := si.checkRangeStartIndexed(ctx, f); err != nil {
+		return nil, err
+	}
+
 	getEventsFnc := func(stmt *sql.Stmt, values []any) ([]*CollectedEvent, error) {
 		q, err := stmt.QueryContext(ctx, values...)
 		if err != nil {
@@ -578,7 +645,79 @@ func (si *SqliteIndexer) GetEventsForFilter(ctx context.Context, f *EventFilter)
 	return ces, nil
 }
 
+// CountEventsForFilter returns the number of events in the index matching the given filter, without
+// loading them. Unlike GetEventsForFilter it doesn't wait for the index to catch up with the head or
+// apply MaxResults, so the result is a cheap approximation of the number of events
+// GetEventsForFilter would return.
+func (si *SqliteIndexer) CountEventsForFilter(ctx context.Context, f *EventFilter) (int, error) {
+	if err := si.checkRangeStartIndexed(ctx, f); err != nil {
+		return 0, err
+	}
+
+	values, joins, clauses, err := makeFilterConditions(f)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to make filter conditions: %w", err)
+	}
+
+	s := `SELECT COUNT(DISTINCT e.id)
+		FROM event e
+		JOIN tipset_message tm ON e.message_id = tm.id
+		JOIN event_entry ee ON e.id = ee.event_id`
+	s += makeFilterQueryTail(joins, clauses)
+
+	var count int
+	if err := si.db.QueryRowContext(ctx, s, values...).Scan(&count); err != nil {
+		return 0, xerrors.Errorf("failed to count events: %w", err)
+	}
+	return count, nil
+}
+
 func makePrefillFilterQuery(f *EventFilter) ([]any, string, error) {
+	values, joins, clauses, err := makeFilterConditions(f)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s := `SELECT
+			e.id,
+			tm.height,
+			tm.tipset_key_cid,
+			e.emitter_id,
+			e.emitter_addr,
+			e.event_index,
+			tm.message_cid,
+			tm.message_index,
+			e.reverted,
+			ee.flags,
+			ee.key,
+			ee.codec,
+			ee.value
+		FROM event e
+		JOIN tipset_message tm ON e.message_id = tm.id
+		JOIN event_entry ee ON e.id = ee.event_id`
+	s += makeFilterQueryTail(joins, clauses)
+
+	// retain insertion order of event_entry rows
+	s += " ORDER BY tm.height ASC, tm.message_index ASC, e.event_index ASC, ee._rowid_ ASC"
+	return values, s, nil
+}
+
+// makeFilterQueryTail returns the additional joins and WHERE clause to append to a query over the
+// event (e), tipset_message (tm) and event_entry (ee) tables.
+func makeFilterQueryTail(joins []string, clauses []string) string {
+	var s string
+	if len(joins) > 0 {
+		s = s + ", " + strings.Join(joins, ", ")
+	}
+	if len(clauses) > 0 {
+		s = s + " WHERE " + strings.Join(clauses, " AND ")
+	}
+	return s
+}
+
+// makeFilterConditions translates an EventFilter into the query values, additional joins and WHERE
+// clauses used to select matching events.
+func makeFilterConditions(f *EventFilter) ([]any, []string, []string, error) {
 	clauses := []string{}
 	values := []any{}
 	joins := []string{}
@@ -601,7 +740,7 @@ func makePrefillFilterQuery(f *EventFilter) ([]any, string, error) {
 				clauses = append(clauses, "tm.height <= ?")
 				values = append(values, f.MaxHeight)
 			} else {
-				return nil, "", xerrors.Errorf("filter must specify either a tipset or a height range")
+				return nil, nil, nil, xerrors.Errorf("filter must specify either a tipset or a height range")
 			}
 		}
 		// unless asking for a specific tipset, we never want to see reverted historical events
@@ -618,13 +757,13 @@ func makePrefillFilterQuery(f *EventFilter) ([]any, string, error) {
 			case address.ID:
 				id, err := address.IDFromAddress(addr)
 				if err != nil {
-					return nil, "", xerrors.Errorf("failed to get ID from address: %w", err)
+					return nil, nil, nil, xerrors.Errorf("failed to get ID from address: %w", err)
 				}
 				idAddresses = append(idAddresses, id)
 			case address.Delegated:
 				delegatedAddresses = append(delegatedAddresses, addr.Bytes())
 			default:
-				return nil, "", xerrors.Errorf("can only query events by ID or Delegated addresses; but request has address: %s", addr)
+				return nil, nil, nil, xerrors.Errorf("can only query events by ID or Delegated addresses; but request has address: %s", addr)
 			}
 		}
 
@@ -667,33 +806,5 @@ func makePrefillFilterQuery(f *EventFilter) ([]any, string, error) {
 		values = append(values, f.Codec)
 	}
 
-	s := `SELECT
-			e.id,
-			tm.height,
-			tm.tipset_key_cid,
-			e.emitter_id,
-			e.emitter_addr,
-			e.event_index,
-			tm.message_cid,
-			tm.message_index,
-			e.reverted,
-			ee.flags,
-			ee.key,
-			ee.codec,
-			ee.value
-		FROM event e
-		JOIN tipset_message tm ON e.message_id = tm.id
-		JOIN event_entry ee ON e.id = ee.event_id`
-
-	if len(joins) > 0 {
-		s = s + ", " + strings.Join(joins, ", ")
-	}
-
-	if len(clauses) > 0 {
-		s = s + " WHERE " + strings.Join(clauses, " AND ")
-	}
-
-	// retain insertion order of event_entry rows
-	s += " ORDER BY tm.height ASC, tm.message_index ASC, e.event_index ASC, ee._rowid_ ASC"
-	return values, s, nil
+	return values, joins, clauses, nil
 }
@@ -178,6 +178,11 @@ func TestGetEventsForFilterWithEvents(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, len(ces))
 
+	// counting matches the number of events fetched
+	count, err := si.CountEventsForFilter(ctx, f)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
 	// fetch it based on cid -> works
 	tsCid1, err := fakeTipSet1.Key().Cid()
 	require.NoError(t, err)
@@ -236,6 +241,10 @@ func TestGetEventsForFilterWithEvents(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(ces))
 
+	count, err = si.CountEventsForFilter(ctx, f)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+
 	// works if excludeReverted is false i.e. we request events by hash
 	f = &EventFilter{
 		TipsetCid: tsCid1,
@@ -243,6 +252,75 @@ func TestGetEventsForFilterWithEvents(t *testing.T) {
 	ces, err = si.GetEventsForFilter(ctx, f)
 	require.NoError(t, err)
 	require.Equal(t, 2, len(ces))
+
+	count, err = si.CountEventsForFilter(ctx, f)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestGetEventsForFilterRangeNotIndexed(t *testing.T) {
+	ctx := context.Background()
+	seed := time.Now().UnixNano()
+	t.Logf("seed: %d", seed)
+	rng := pseudo.New(pseudo.NewSource(seed))
+	headHeight := abi.ChainEpoch(60)
+	si, _, cs := setupWithHeadIndexed(t, headHeight, rng)
+	t.Cleanup(func() { _ = si.Close() })
+
+	ev := fakeEvent(
+		abi.ActorID(1),
+		[]kv{
+			{k: "type", v: []byte("approval")},
+		},
+		nil,
+	)
+	fm := fakeMessage(address.TestAddress, address.TestAddress)
+
+	si.SetActorToDelegatedAddresFunc(func(ctx context.Context, emitter abi.ActorID, ts *types.TipSet) (address.Address, bool) {
+		idAddr, err := address.NewIDAddress(uint64(emitter))
+		if err != nil {
+			return address.Undef, false
+		}
+		return idAddr, true
+	})
+	si.setExecutedMessagesLoaderFunc(func(ctx context.Context, cs ChainStore, msgTs, rctTs *types.TipSet) ([]executedMessage, error) {
+		return []executedMessage{{msg: fm, evs: []types.Event{*ev}}}, nil
+	})
+
+	// Simulate a node that was started from a snapshot at height 10: the tipset at height 5 exists
+	// in the chain but was never indexed.
+	fakeTipSets := make(map[abi.ChainEpoch]*types.TipSet)
+	for _, h := range []abi.ChainEpoch{5, 10, 11} {
+		fakeTipSets[h] = fakeTipSet(t, rng, h, nil)
+		cs.SetTipsetByHeightAndKey(h, fakeTipSets[h].Key(), fakeTipSets[h])
+		cs.SetTipSetByCid(t, fakeTipSets[h])
+	}
+	cs.SetMessagesForTipset(fakeTipSets[10], []types.ChainMsg{fm})
+	require.NoError(t, si.Apply(ctx, fakeTipSets[10], fakeTipSets[11]))
+
+	// the indexed part of the chain is queryable
+	ces, err := si.GetEventsForFilter(ctx, &EventFilter{MinHeight: 10, MaxHeight: 11})
+	require.NoError(t, err)
+	require.Len(t, ces, 1)
+
+	// a range that reaches into the gap must not silently return only the indexed events
+	ces, err = si.GetEventsForFilter(ctx, &EventFilter{MinHeight: 5, MaxHeight: 11})
+	require.ErrorIs(t, err, &ErrRangeNotIndexed{})
+	require.ErrorIs(t, err, ErrNotFound)
+	require.EqualError(t, err, "events not available before epoch 10 (no event index)")
+	require.Empty(t, ces)
+
+	var notIndexed *ErrRangeNotIndexed
+	require.ErrorAs(t, err, &notIndexed)
+	require.Equal(t, abi.ChainEpoch(10), notIndexed.EarliestEpoch)
+
+	// the same applies to a range that lies entirely within the gap
+	_, err = si.GetEventsForFilter(ctx, &EventFilter{MinHeight: 5, MaxHeight: 5})
+	require.ErrorIs(t, err, &ErrRangeNotIndexed{})
+
+	// the genesis tipset is not expected to be indexed
+	_, err = si.GetEventsForFilter(ctx, &EventFilter{MinHeight: 0, MaxHeight: 0})
+	require.NotErrorIs(t, err, &ErrRangeNotIndexed{})
 }
 
 func TestGetEventsFilterByAddress(t *testing.T) {
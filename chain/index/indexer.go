@@ -84,6 +84,10 @@ type SqliteIndexer struct {
 
 	// ensures writes are serialized so backfilling does not race with index updates
 	writerLk sync.Mutex
+
+	backfillLk sync.Mutex
+	// progress of the running or last backfill started through ChainBackfillIndex
+	backfill *types.IndexBackfill
 }
 
 func NewSqliteIndexer(path string, cs ChainStore, gcRetentionEpochs int64, reconcileEmptyIndex bool,
@@ -155,6 +159,10 @@ func (si *SqliteIndexer) buildExecutedMessagesLoader(rf RecomputeTipSetStateFunc
 
 func (si *SqliteIndexer) Close() error {
 	si.cancel()
+	// a backfill starting concurrently adds to the wait group under backfillLk, once it has
+	// checked the indexer isn't closed
+	si.backfillLk.Lock()
+	si.backfillLk.Unlock() //nolint:staticcheck
 	si.wg.Wait()
 
 	// Close is idempotent, it doesn't hurt to call it multiple times.
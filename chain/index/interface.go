@@ -70,8 +70,12 @@ type Indexer interface {
 	GetMsgInfo(ctx context.Context, m cid.Cid) (*MsgInfo, error)
 
 	GetEventsForFilter(ctx context.Context, f *EventFilter) ([]*CollectedEvent, error)
+	// CountEventsForFilter returns the approximate number of events GetEventsForFilter would return
+	CountEventsForFilter(ctx context.Context, f *EventFilter) (int, error)
 
 	ChainValidateIndex(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error)
+	ChainBackfillIndex(ctx context.Context, from, to abi.ChainEpoch) (*types.IndexBackfill, error)
+	ChainBackfillIndexStatus(ctx context.Context) (*types.IndexBackfill, error)
 
 	Close() error
 }
@@ -35,6 +35,55 @@ const (
 
 var ErrExpensiveFork = errors.New("refusing explicit call due to state fork at epoch")
 
+// CallOptions customises the environment in which a message is applied. The zero value applies the
+// message unmodified.
+type CallOptions struct {
+	// PriorMessages are applied before the message, after any messages of the tipset, like they
+	// would be on chain, e.g. to simulate the message behind the pending messages of its sender.
+	// Those that fail don't stop the message from being applied.
+	PriorMessages []types.ChainMsg
+
+	// StateOverride is invoked with the state tree the message is about to be applied on, after any
+	// prior messages, and may modify it. Changes are made in the call's buffered blockstore, which
+	// is also passed in for writing blocks that can't be written through the state tree, and are
+	// never persisted.
+	StateOverride func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error
+
+	// SetupMessages are applied as implicit messages after StateOverride, e.g. to change the state
+	// of actors in ways only their code can. The message is only applied if they all succeed.
+	SetupMessages []*types.Message
+	// AfterSetup is invoked with the state tree resulting from SetupMessages, if any, and may modify
+	// it like StateOverride, e.g. to undo changes made to run them.
+	AfterSetup func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error
+
+	// BaseFee, if set, replaces the base fee of the tipset the message is applied at, even when gas
+	// is free.
+	BaseFee *abi.TokenAmount
+
+	// Epoch and Timestamp, if set, replace the epoch and timestamp the message is applied at, after
+	// any prior messages were applied at the tipset's. The network version is still the tipset's.
+	// Randomness can't be drawn from epochs past the tipset, so the randomness drawn from those is
+	// the tipset's.
+	Epoch     *abi.ChainEpoch
+	Timestamp *uint64
+	// BeaconRandomness, if set, replaces the beacon randomness drawn from the epoch the message is
+	// applied at and later ones, from which e.g. the EVM derives the value of PREVRANDAO.
+	BeaconRandomness *[32]byte
+	// ChainRandomness, if set, replaces the chain randomness drawn from the epoch the message is
+	// applied at and later ones.
+	ChainRandomness *[32]byte
+	// ChainID, if set, replaces the EIP-155 chain id of the network the message is applied on.
+	ChainID *uint64
+
+	// Inspect is invoked with the receipt of the message and the state tree it resulted in, before
+	// that state is discarded. It is only invoked if the message was applied successfully.
+	Inspect func(ctx context.Context, st *state.StateTree, rct *types.MessageReceipt) error
+
+	// Events, if set, is invoked with the events emitted by the message. Like Inspect, it is only
+	// invoked if the message was applied successfully.
+	Events func(ctx context.Context, evs []types.Event) error
+}
+
 // Call applies the given message to the given tipset's parent state, at the epoch following the
 // tipset's parent. In the presence of null blocks, the height at which the message is invoked may
 // be less than the specified tipset.
@@ -60,12 +109,26 @@ func (sm *StateManager) CallOnState(ctx context.Context, stateCid cid.Cid, msg *
 		msg.Value = types.NewInt(0)
 	}
 
-	return sm.callInternal(ctx, msg, nil, ts, stateCid, sm.GetNetworkVersion, false, execSameSenderMessages)
+	return sm.callInternal(ctx, msg, nil, ts, stateCid, sm.GetNetworkVersion, false, execSameSenderMessages, nil)
 }
 
 // ApplyOnStateWithGas applies the given message on top of the given state root with gas tracing enabled
 func (sm *StateManager) ApplyOnStateWithGas(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet) (*api.InvocResult, error) {
-	return sm.callInternal(ctx, msg, nil, ts, stateCid, sm.GetNetworkVersion, true, execNoMessages)
+	return sm.callInternal(ctx, msg, nil, ts, stateCid, sm.GetNetworkVersion, true, execNoMessages, nil)
+}
+
+// ApplyOnStateWithOptions is like ApplyOnStateWithGas, but applies the message in the environment
+// described by opts.
+func (sm *StateManager) ApplyOnStateWithOptions(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet, opts *CallOptions) (*api.InvocResult, error) {
+	return sm.callInternal(ctx, msg, nil, ts, stateCid, sm.GetNetworkVersion, true, execNoMessages, opts)
+}
+
+// ApplyManyOnStateWithOptions is like ApplyOnStateWithOptions, but applies several messages one
+// after the other, each seeing the changes made by the previous ones, as if they were included in
+// the same block. The state override is applied before the first message and the inspect hook is
+// invoked after each. Messages that fail don't stop the following ones from being applied.
+func (sm *StateManager) ApplyManyOnStateWithOptions(ctx context.Context, stateCid cid.Cid, msgs []*types.Message, ts *types.TipSet, opts *CallOptions) ([]*api.InvocResult, error) {
+	return sm.callManyInternal(ctx, msgs, nil, ts, stateCid, sm.GetNetworkVersion, true, execNoMessages, opts)
 }
 
 // CallWithGas calculates the state for a given tipset, and then applies the given message on top of that state.
@@ -77,7 +140,7 @@ func (sm *StateManager) CallWithGas(ctx context.Context, msg *types.Message, pri
 		strategy = execSameSenderMessages
 	}
 
-	return sm.callInternal(ctx, msg, priorMsgs, ts, cid.Undef, sm.GetNetworkVersion, true, strategy)
+	return sm.callInternal(ctx, msg, priorMsgs, ts, cid.Undef, sm.GetNetworkVersion, true, strategy, nil)
 }
 
 // CallAtStateAndVersion allows you to specify a message to execute on the given stateCid and network version.
@@ -88,20 +151,31 @@ func (sm *StateManager) CallAtStateAndVersion(ctx context.Context, msg *types.Me
 	nvGetter := func(context.Context, abi.ChainEpoch) network.Version {
 		return v
 	}
-	return sm.callInternal(ctx, msg, nil, nil, stateCid, nvGetter, true, execSameSenderMessages)
+	return sm.callInternal(ctx, msg, nil, nil, stateCid, nvGetter, true, execSameSenderMessages, nil)
 }
 
 //   - If no tipset is specified, the first tipset without an expensive migration or one in its parent is used.
 //   - If executing a message at a given tipset or its parent would trigger an expensive migration, the call will
 //     fail with ErrExpensiveFork.
 func (sm *StateManager) callInternal(ctx context.Context, msg *types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet, stateCid cid.Cid,
-	nvGetter rand.NetworkVersionGetter, checkGas bool, strategy execMessageStrategy) (*api.InvocResult, error) {
+	nvGetter rand.NetworkVersionGetter, checkGas bool, strategy execMessageStrategy, opts *CallOptions) (*api.InvocResult, error) {
+	res, err := sm.callManyInternal(ctx, []*types.Message{msg}, priorMsgs, ts, stateCid, nvGetter, checkGas, strategy, opts)
+	if len(res) == 0 {
+		return nil, err
+	}
+	return res[0], err
+}
+
+// callManyInternal applies msgs one after the other, each on the state resulting from the previous
+// one. It returns the results of the messages applied so far along with any error.
+func (sm *StateManager) callManyInternal(ctx context.Context, msgs []*types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet, stateCid cid.Cid,
+	nvGetter rand.NetworkVersionGetter, checkGas bool, strategy execMessageStrategy, opts *CallOptions) ([]*api.InvocResult, error) {
 	ctx, span := trace.StartSpan(ctx, "statemanager.callInternal")
 	defer span.End()
 
-	// Copy the message as we'll be modifying the nonce.
-	msgCopy := *msg
-	msg = &msgCopy
+	if len(msgs) == 0 {
+		return nil, xerrors.New("no messages to apply")
+	}
 
 	var err error
 	var pts *types.TipSet
@@ -149,36 +223,32 @@ func (sm *StateManager) callInternal(ctx context.Context, msg *types.Message, pr
 
 	if span.IsRecordingEvents() {
 		span.AddAttributes(
-			trace.Int64Attribute("gas_limit", msg.GasLimit),
-			trace.StringAttribute("gas_feecap", msg.GasFeeCap.String()),
-			trace.StringAttribute("value", msg.Value.String()),
+			trace.Int64Attribute("gas_limit", msgs[0].GasLimit),
+			trace.StringAttribute("gas_feecap", msgs[0].GasFeeCap.String()),
+			trace.StringAttribute("value", msgs[0].Value.String()),
 		)
+		if len(msgs) > 1 {
+			span.AddAttributes(trace.Int64Attribute("messages", int64(len(msgs))))
+		}
 	}
 
+	// Every call writes to a buffer of its own on top of the state blockstore, so that concurrent
+	// calls can't see each other's changes and nothing they write is persisted.
 	buffStore := blockstore.NewTieredBstore(sm.cs.StateBlockstore(), blockstore.NewMemorySync())
-	vmopt := &vm.VMOpts{
-		StateBase:      stateCid,
-		Epoch:          ts.Height(),
-		Timestamp:      ts.MinTimestamp(),
-		Rand:           rand.NewStateRand(sm.cs, ts.Cids(), sm.beacon, nvGetter),
-		Bstore:         buffStore,
-		Actors:         sm.tsExec.NewActorRegistry(),
-		Syscalls:       sm.Syscalls,
-		CircSupplyCalc: sm.GetVMCirculatingSupply,
-		NetworkVersion: nvGetter(ctx, ts.Height()),
-		BaseFee:        ts.Blocks()[0].ParentBaseFee,
-		LookbackState:  LookbackStateGetterForTipset(sm, ts),
-		TipSetGetter:   TipSetGetterForTipset(sm.cs, ts),
-		Tracing:        true,
-	}
+	vmopt := sm.callVMOpts(ctx, ts, stateCid, buffStore, nvGetter)
+	vmopt.ReturnEvents = opts != nil && opts.Events != nil
 	vmi, err := sm.newVM(ctx, vmopt)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to set up vm: %w", err)
 	}
 
+	if opts != nil {
+		priorMsgs = append(priorMsgs, opts.PriorMessages...)
+	}
+
 	switch strategy {
 	case execNoMessages:
-		// Do nothing
+		// Only apply the given prior messages.
 	case execAllMessages, execSameSenderMessages:
 		tsMsgs, err := sm.cs.MessagesForTipset(ctx, ts)
 		if err != nil {
@@ -190,12 +260,15 @@ func (sm *StateManager) callInternal(ctx context.Context, msg *types.Message, pr
 			var filteredTsMsgs []types.ChainMsg
 			for _, tsMsg := range tsMsgs {
 				//TODO we should technically be normalizing the filecoin address of from when we compare here
-				if tsMsg.VMMessage().From == msg.VMMessage().From {
+				if tsMsg.VMMessage().From == msgs[0].From {
 					filteredTsMsgs = append(filteredTsMsgs, tsMsg)
 				}
 			}
 			priorMsgs = append(filteredTsMsgs, priorMsgs...)
 		}
+	}
+
+	if strategy != execNoMessages || len(priorMsgs) > 0 {
 		for i, m := range priorMsgs {
 			_, err = vmi.ApplyMessage(ctx, m)
 			if err != nil {
@@ -216,17 +289,64 @@ func (sm *StateManager) callInternal(ctx context.Context, msg *types.Message, pr
 		return nil, xerrors.Errorf("loading state tree: %w", err)
 	}
 
-	fromActor, err := stTree.GetActor(msg.From)
-	if err != nil {
-		return nil, xerrors.Errorf("call raw get actor: %s", err)
+	// The VM needs to be recreated if the state or environment it was set up with changes.
+	var resetVM bool
+
+	if opts != nil && opts.StateOverride != nil {
+		if err := opts.StateOverride(ctx, buffStore, stTree); err != nil {
+			return nil, xerrors.Errorf("applying state override: %w", err)
+		}
+		stateCid, err = stTree.Flush(ctx)
+		if err != nil {
+			return nil, xerrors.Errorf("flushing overridden state: %w", err)
+		}
+		resetVM = true
 	}
 
-	msg.Nonce = fromActor.Nonce
+	if opts != nil && len(opts.SetupMessages) > 0 {
+		stateCid, err = sm.applySetupMessages(ctx, vmopt, stateCid, opts)
+		if err != nil {
+			return nil, err
+		}
+		stTree, err = state.LoadStateTree(cbor.NewCborStore(buffStore), stateCid)
+		if err != nil {
+			return nil, xerrors.Errorf("loading state tree: %w", err)
+		}
+		resetVM = true
+	}
 
-	// If the fee cap is set to zero, make gas free.
-	if msg.GasFeeCap.NilOrZero() {
+	// If the fee cap of every message is set to zero, make gas free.
+	freeGas := true
+	for _, msg := range msgs {
+		freeGas = freeGas && msg.GasFeeCap.NilOrZero()
+	}
+	if freeGas {
 		// Now estimate with a new VM with no base fee.
 		vmopt.BaseFee = big.Zero()
+		resetVM = true
+	}
+	if opts != nil && opts.BaseFee != nil {
+		vmopt.BaseFee = *opts.BaseFee
+		resetVM = true
+	}
+	if opts != nil && opts.Epoch != nil {
+		vmopt.Epoch = *opts.Epoch
+		resetVM = true
+	}
+	if opts != nil && opts.Timestamp != nil {
+		vmopt.Timestamp = *opts.Timestamp
+		resetVM = true
+	}
+	if opts != nil && opts.ChainID != nil {
+		vmopt.ChainID = opts.ChainID
+		resetVM = true
+	}
+	if opts != nil && (opts.Epoch != nil || opts.BeaconRandomness != nil || opts.ChainRandomness != nil) {
+		vmopt.Rand = &callRand{Rand: vmopt.Rand, height: ts.Height(), beacon: opts.BeaconRandomness, chain: opts.ChainRandomness}
+		resetVM = true
+	}
+
+	if resetVM {
 		vmopt.StateBase = stateCid
 
 		vmi, err = sm.newVM(ctx, vmopt)
@@ -235,63 +355,199 @@ func (sm *StateManager) callInternal(ctx context.Context, msg *types.Message, pr
 		}
 	}
 
-	var ret *vm.ApplyRet
-	var gasInfo api.MsgGasCost
-	if checkGas {
-		fromKey, err := sm.ResolveToDeterministicAddress(ctx, msg.From, ts)
+	results := make([]*api.InvocResult, 0, len(msgs))
+	for i, msg := range msgs {
+		// Copy the message as we'll be modifying the nonce.
+		msgCopy := *msg
+		msg = &msgCopy
+
+		fromActor, err := stTree.GetActor(msg.From)
 		if err != nil {
-			return nil, xerrors.Errorf("could not resolve key: %w", err)
+			return results, xerrors.Errorf("call raw get actor: %s", err)
+		}
+
+		msg.Nonce = fromActor.Nonce
+
+		var ret *vm.ApplyRet
+		var gasInfo api.MsgGasCost
+		if checkGas {
+			fromKey, err := sm.ResolveToDeterministicAddress(ctx, msg.From, ts)
+			if err != nil {
+				return results, xerrors.Errorf("could not resolve key: %w", err)
+			}
+
+			var msgApply types.ChainMsg
+
+			switch fromKey.Protocol() {
+			case address.BLS:
+				msgApply = msg
+			case address.SECP256K1:
+				msgApply = &types.SignedMessage{
+					Message: *msg,
+					Signature: crypto.Signature{
+						Type: crypto.SigTypeSecp256k1,
+						Data: make([]byte, 65),
+					},
+				}
+			case address.Delegated:
+				msgApply = &types.SignedMessage{
+					Message: *msg,
+					Signature: crypto.Signature{
+						Type: crypto.SigTypeDelegated,
+						Data: make([]byte, 65),
+					},
+				}
+			}
+
+			ret, err = vmi.ApplyMessage(ctx, msgApply)
+			if err != nil {
+				return results, xerrors.Errorf("gas estimation failed: %w", err)
+			}
+			gasInfo = MakeMsgGasCost(msg, ret)
+		} else {
+			ret, err = vmi.ApplyImplicitMessage(ctx, msg)
+			if err != nil && ret == nil {
+				return results, xerrors.Errorf("apply message failed: %w", err)
+			}
 		}
 
-		var msgApply types.ChainMsg
-
-		switch fromKey.Protocol() {
-		case address.BLS:
-			msgApply = msg
-		case address.SECP256K1:
-			msgApply = &types.SignedMessage{
-				Message: *msg,
-				Signature: crypto.Signature{
-					Type: crypto.SigTypeSecp256k1,
-					Data: make([]byte, 65),
-				},
+		inspect := err == nil && ret.ExitCode.IsSuccess() && opts != nil && opts.Inspect != nil
+		// The following messages need the resulting state to look up their senders' nonces.
+		if inspect || (err == nil && i < len(msgs)-1) {
+			root, err := vmi.Flush(ctx)
+			if err != nil {
+				return results, xerrors.Errorf("flushing vm: %w", err)
+			}
+			stTree, err = state.LoadStateTree(cbor.NewCborStore(buffStore), root)
+			if err != nil {
+				return results, xerrors.Errorf("loading resulting state tree: %w", err)
+			}
+		}
+		if inspect {
+			if err := opts.Inspect(ctx, stTree, &ret.MessageReceipt); err != nil {
+				return results, xerrors.Errorf("inspecting resulting state: %w", err)
 			}
-		case address.Delegated:
-			msgApply = &types.SignedMessage{
-				Message: *msg,
-				Signature: crypto.Signature{
-					Type: crypto.SigTypeDelegated,
-					Data: make([]byte, 65),
-				},
+		}
+		if err == nil && ret.ExitCode.IsSuccess() && opts != nil && opts.Events != nil {
+			if err := opts.Events(ctx, ret.Events); err != nil {
+				return results, xerrors.Errorf("handling emitted events: %w", err)
 			}
 		}
 
-		ret, err = vmi.ApplyMessage(ctx, msgApply)
+		var errs string
+		if ret.ActorErr != nil {
+			errs = ret.ActorErr.Error()
+		}
+
+		results = append(results, &api.InvocResult{
+			MsgCid:         msg.Cid(),
+			Msg:            msg,
+			MsgRct:         &ret.MessageReceipt,
+			GasCost:        gasInfo,
+			ExecutionTrace: ret.ExecutionTrace,
+			Error:          errs,
+			Duration:       ret.Duration,
+		})
 		if err != nil {
-			return nil, xerrors.Errorf("gas estimation failed: %w", err)
+			return results, err
 		}
-		gasInfo = MakeMsgGasCost(msg, ret)
-	} else {
-		ret, err = vmi.ApplyImplicitMessage(ctx, msg)
-		if err != nil && ret == nil {
-			return nil, xerrors.Errorf("apply message failed: %w", err)
+	}
+
+	return results, nil
+}
+
+// callVMOpts returns the options of a VM applying messages on top of stateCid at the epoch of ts,
+// writing to bs.
+func (sm *StateManager) callVMOpts(ctx context.Context, ts *types.TipSet, stateCid cid.Cid, bs blockstore.Blockstore, nvGetter rand.NetworkVersionGetter) *vm.VMOpts {
+	return &vm.VMOpts{
+		StateBase:      stateCid,
+		Epoch:          ts.Height(),
+		Timestamp:      ts.MinTimestamp(),
+		Rand:           rand.NewStateRand(sm.cs, ts.Cids(), sm.beacon, nvGetter),
+		Bstore:         bs,
+		Actors:         sm.tsExec.NewActorRegistry(),
+		Syscalls:       sm.Syscalls,
+		CircSupplyCalc: sm.GetVMCirculatingSupply,
+		NetworkVersion: nvGetter(ctx, ts.Height()),
+		BaseFee:        ts.Blocks()[0].ParentBaseFee,
+		LookbackState:  LookbackStateGetterForTipset(sm, ts),
+		TipSetGetter:   TipSetGetterForTipset(sm.cs, ts),
+		Tracing:        true,
+	}
+}
+
+// StateAfterMessages applies msgs on top of the given state root at the epoch of ts, like the prior
+// messages of a call, and returns the resulting state tree. Messages that fail don't stop the
+// following ones from being applied. Like for calls, the state is written to a buffer of its own,
+// so nothing is persisted.
+func (sm *StateManager) StateAfterMessages(ctx context.Context, stateCid cid.Cid, msgs []types.ChainMsg, ts *types.TipSet) (*state.StateTree, error) {
+	ctx, span := trace.StartSpan(ctx, "statemanager.StateAfterMessages")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("messages", int64(len(msgs))))
+
+	stateCid, err := sm.HandleStateForks(ctx, stateCid, ts.Height(), nil, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to handle fork: %w", err)
+	}
+
+	buffStore := blockstore.NewTieredBstore(sm.cs.StateBlockstore(), blockstore.NewMemorySync())
+	vmi, err := sm.newVM(ctx, sm.callVMOpts(ctx, ts, stateCid, buffStore, sm.GetNetworkVersion))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to set up vm: %w", err)
+	}
+	for i, m := range msgs {
+		if _, err := vmi.ApplyMessage(ctx, m); err != nil {
+			return nil, xerrors.Errorf("applying message (%d, %s): %w", i, m.Cid(), err)
+		}
+	}
+	root, err := vmi.Flush(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("flushing vm: %w", err)
+	}
+	return state.LoadStateTree(cbor.NewCborStore(buffStore), root)
+}
+
+// applySetupMessages applies the setup messages of opts on the given state, then invokes its
+// AfterSetup, and returns the resulting state.
+func (sm *StateManager) applySetupMessages(ctx context.Context, vmopt *vm.VMOpts, stateCid cid.Cid, opts *CallOptions) (cid.Cid, error) {
+	setupOpts := *vmopt
+	setupOpts.StateBase = stateCid
+	vmi, err := sm.newVM(ctx, &setupOpts)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to set up setup vm: %w", err)
+	}
+
+	for i, msg := range opts.SetupMessages {
+		ret, err := vmi.ApplyImplicitMessage(ctx, msg)
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("applying setup message %d: %w", i, err)
+		}
+		if ret.ExitCode.IsError() {
+			return cid.Undef, xerrors.Errorf("setup message %d to %s failed: exit %s", i, msg.To, ret.ExitCode)
 		}
 	}
 
-	var errs string
-	if ret.ActorErr != nil {
-		errs = ret.ActorErr.Error()
+	stateCid, err = vmi.Flush(ctx)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("flushing setup vm: %w", err)
+	}
+	if opts.AfterSetup == nil {
+		return stateCid, nil
 	}
 
-	return &api.InvocResult{
-		MsgCid:         msg.Cid(),
-		Msg:            msg,
-		MsgRct:         &ret.MessageReceipt,
-		GasCost:        gasInfo,
-		ExecutionTrace: ret.ExecutionTrace,
-		Error:          errs,
-		Duration:       ret.Duration,
-	}, err
+	bs := vmopt.Bstore
+	stTree, err := state.LoadStateTree(cbor.NewCborStore(bs), stateCid)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("loading setup state tree: %w", err)
+	}
+	if err := opts.AfterSetup(ctx, bs, stTree); err != nil {
+		return cid.Undef, xerrors.Errorf("applying state override after setup: %w", err)
+	}
+	stateCid, err = stTree.Flush(ctx)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("flushing state after setup: %w", err)
+	}
+	return stateCid, nil
 }
 
 var errHaltExecution = fmt.Errorf("halt")
@@ -312,3 +568,32 @@ func (sm *StateManager) Replay(ctx context.Context, ts *types.TipSet, mcid cid.C
 
 	return finder.outm, finder.outr, nil
 }
+
+// callRand draws the randomness of calls applied at epochs that may be past the tipset they are
+// applied on, from which no randomness can be drawn: the tipset's randomness is drawn instead.
+type callRand struct {
+	rand.Rand
+	height abi.ChainEpoch
+	// beacon and chain, if set, replace the beacon and chain randomness of the tipset's epoch and
+	// later ones.
+	beacon *[32]byte
+	chain  *[32]byte
+}
+
+func (r *callRand) GetChainRandomness(ctx context.Context, round abi.ChainEpoch) ([32]byte, error) {
+	if r.chain != nil && round >= r.height {
+		return *r.chain, nil
+	}
+	return r.Rand.GetChainRandomness(ctx, min(round, r.height))
+}
+
+func (r *callRand) GetBeaconEntry(ctx context.Context, round abi.ChainEpoch) (*types.BeaconEntry, error) {
+	return r.Rand.GetBeaconEntry(ctx, min(round, r.height))
+}
+
+func (r *callRand) GetBeaconRandomness(ctx context.Context, round abi.ChainEpoch) ([32]byte, error) {
+	if r.beacon != nil && round >= r.height {
+		return *r.beacon, nil
+	}
+	return r.Rand.GetBeaconRandomness(ctx, min(round, r.height))
+}
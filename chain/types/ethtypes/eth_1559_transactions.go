@@ -123,7 +123,7 @@ func (tx *Eth1559TxArgs) ToEthTx(smsg *types.SignedMessage) (EthTx, error) {
 	gasPremium := EthBigInt(tx.MaxPriorityFeePerGas)
 
 	ethTx := EthTx{
-		ChainID:              EthUint64(buildconstants.Eip155ChainId),
+		ChainID:              EthUint64(tx.ChainID),
 		Type:                 EIP1559TxType,
 		Nonce:                EthUint64(tx.Nonce),
 		Hash:                 hash,
@@ -133,6 +133,7 @@ func (tx *Eth1559TxArgs) ToEthTx(smsg *types.SignedMessage) (EthTx, error) {
 		Gas:                  EthUint64(tx.GasLimit),
 		MaxFeePerGas:         &gasFeeCap,
 		MaxPriorityFeePerGas: &gasPremium,
+		AccessList:           []EthAccessTuple{},
 		From:                 from,
 		R:                    EthBigInt(tx.R),
 		S:                    EthBigInt(tx.S),
@@ -0,0 +1,190 @@
+package ethtypes
+
+import (
+	"encoding/binary"
+	mathbig "math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// EthABIParam is a parameter of a Solidity function or event, in the JSON ABI format.
+type EthABIParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Indexed is set for event parameters that are stored in the topics of the log rather than in
+	// its data.
+	Indexed bool `json:"indexed,omitempty"`
+}
+
+// EthABIEvent is a Solidity event, in the JSON ABI format.
+type EthABIEvent struct {
+	Name      string        `json:"name"`
+	Inputs    []EthABIParam `json:"inputs"`
+	Anonymous bool          `json:"anonymous,omitempty"`
+}
+
+// Signature returns the canonical signature of the event, such as
+// "Transfer(address,address,uint256)".
+func (ev EthABIEvent) Signature() string {
+	types := make([]string, len(ev.Inputs))
+	for i, in := range ev.Inputs {
+		types[i] = in.Type
+	}
+	return ev.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// Topic returns the keccak-256 hash of the signature of the event, which is the first topic of
+// the logs of non-anonymous events.
+func (ev EthABIEvent) Topic() EthHash {
+	return EthHashFromTxBytes([]byte(ev.Signature()))
+}
+
+// DecodeLog decodes the fields of a log emitted by the event, keyed by name. It returns false if
+// the log wasn't emitted by the event. Indexed parameters of dynamic types are only stored as the
+// hash of their value, which is returned instead.
+func (ev EthABIEvent) DecodeLog(topics []EthHash, data []byte) (map[string]any, bool, error) {
+	var indexed, unindexed []EthABIParam
+	for _, in := range ev.Inputs {
+		if in.Indexed {
+			indexed = append(indexed, in)
+		} else {
+			unindexed = append(unindexed, in)
+		}
+	}
+
+	if !ev.Anonymous {
+		if len(topics) == 0 || topics[0] != ev.Topic() {
+			return nil, false, nil
+		}
+		topics = topics[1:]
+	}
+	if len(topics) != len(indexed) {
+		return nil, false, nil
+	}
+
+	fields := make(map[string]any, len(ev.Inputs))
+	for i, in := range indexed {
+		if isDynamicABIType(in.Type) {
+			fields[in.Name] = topics[i]
+			continue
+		}
+		v, err := decodeABIWord(in.Type, topics[i][:])
+		if err != nil {
+			return nil, false, xerrors.Errorf("decoding %s: %w", in.Name, err)
+		}
+		fields[in.Name] = v
+	}
+
+	values, err := DecodeABIValues(unindexed, data)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, in := range unindexed {
+		fields[in.Name] = values[i]
+	}
+	return fields, true, nil
+}
+
+// DecodeABIValues decodes ABI encoded values of the given parameters. Only elementary types are
+// supported: addresses, booleans, integers, fixed-size byte arrays, bytes and strings. Addresses
+// are decoded as EthAddress, integers as EthBigInt, byte arrays as EthBytes.
+func DecodeABIValues(params []EthABIParam, data []byte) ([]any, error) {
+	values := make([]any, len(params))
+	for i, p := range params {
+		if len(data) < (i+1)*32 {
+			return nil, xerrors.Errorf("decoding %s: data too short", p.Name)
+		}
+		word := data[i*32 : (i+1)*32]
+		if !isDynamicABIType(p.Type) {
+			v, err := decodeABIWord(p.Type, word)
+			if err != nil {
+				return nil, xerrors.Errorf("decoding %s: %w", p.Name, err)
+			}
+			values[i] = v
+			continue
+		}
+
+		// Dynamic values are stored at the given offset, prefixed with their length.
+		offset, err := abiWordToLength(word, len(data))
+		if err != nil || offset+32 > len(data) {
+			return nil, xerrors.Errorf("decoding %s: invalid offset", p.Name)
+		}
+		length, err := abiWordToLength(data[offset:offset+32], len(data)-offset-32)
+		if err != nil {
+			return nil, xerrors.Errorf("decoding %s: invalid length", p.Name)
+		}
+		content := data[offset+32 : offset+32+length]
+		if p.Type == "string" {
+			values[i] = string(content)
+		} else {
+			values[i] = EthBytes(content)
+		}
+	}
+	return values, nil
+}
+
+func isDynamicABIType(typ string) bool {
+	return typ == "bytes" || typ == "string"
+}
+
+// abiWordToLength decodes a word holding an offset or length, which must not exceed max.
+func abiWordToLength(word []byte, max int) (int, error) {
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, xerrors.New("value too large")
+		}
+	}
+	n := binary.BigEndian.Uint64(word[24:])
+	if n > uint64(max) {
+		return 0, xerrors.Errorf("value %d out of bounds", n)
+	}
+	return int(n), nil
+}
+
+// decodeABIWord decodes a 32 byte word holding a value of the given static type.
+func decodeABIWord(typ string, word []byte) (any, error) {
+	switch {
+	case typ == "address":
+		var addr EthAddress
+		copy(addr[:], word[12:])
+		return addr, nil
+	case typ == "bool":
+		return word[31] != 0, nil
+	case strings.HasPrefix(typ, "uint"):
+		if _, err := abiTypeSize(typ, "uint", 8, 256); err != nil {
+			return nil, err
+		}
+		return EthBigInt{Int: new(mathbig.Int).SetBytes(word)}, nil
+	case strings.HasPrefix(typ, "int"):
+		if _, err := abiTypeSize(typ, "int", 8, 256); err != nil {
+			return nil, err
+		}
+		v := new(mathbig.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			v.Sub(v, new(mathbig.Int).Lsh(mathbig.NewInt(1), 256))
+		}
+		return EthBigInt{Int: v}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := abiTypeSize(typ, "bytes", 1, 32)
+		if err != nil {
+			return nil, err
+		}
+		return EthBytes(word[:size]), nil
+	}
+	return nil, xerrors.Errorf("unsupported ABI type %s", typ)
+}
+
+// abiTypeSize returns the size suffixed to the name of a type, such as 64 for uint64, which
+// defaults to max. The size must be a multiple of step, e.g. of 8 bits for integers.
+func abiTypeSize(typ, prefix string, step, max int) (int, error) {
+	if typ == prefix {
+		return max, nil
+	}
+	size, err := strconv.Atoi(strings.TrimPrefix(typ, prefix))
+	if err != nil || size <= 0 || size > max || size%step != 0 {
+		return 0, xerrors.Errorf("unsupported ABI type %s", typ)
+	}
+	return size, nil
+}
@@ -0,0 +1,89 @@
+package ethtypes
+
+import (
+	mathbig "math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func abiWord(b ...byte) []byte {
+	word := make([]byte, 32)
+	copy(word[32-len(b):], b)
+	return word
+}
+
+func TestEthABIEventDecodeLog(t *testing.T) {
+	transfer := EthABIEvent{
+		Name: "Transfer",
+		Inputs: []EthABIParam{
+			{Name: "from", Type: "address", Indexed: true},
+			{Name: "to", Type: "address", Indexed: true},
+			{Name: "value", Type: "uint256"},
+		},
+	}
+	require.Equal(t, "Transfer(address,address,uint256)", transfer.Signature())
+	require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", transfer.Topic().String())
+
+	var from, to EthAddress
+	from[19], to[19] = 1, 2
+	var fromTopic, toTopic EthHash
+	copy(fromTopic[12:], from[:])
+	copy(toTopic[12:], to[:])
+
+	fields, ok, err := transfer.DecodeLog([]EthHash{transfer.Topic(), fromTopic, toTopic}, abiWord(100))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, map[string]any{
+		"from":  from,
+		"to":    to,
+		"value": EthBigInt{Int: mathbig.NewInt(100)},
+	}, fields)
+
+	// Logs of other events, or with other indexed parameters, aren't decoded.
+	_, ok, err = transfer.DecodeLog([]EthHash{fromTopic, fromTopic, toTopic}, abiWord(100))
+	require.NoError(t, err)
+	require.False(t, ok)
+	_, ok, err = transfer.DecodeLog([]EthHash{transfer.Topic(), fromTopic}, abiWord(100))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = transfer.DecodeLog([]EthHash{transfer.Topic(), fromTopic, toTopic}, nil)
+	require.ErrorContains(t, err, "data too short")
+}
+
+func TestDecodeABIValues(t *testing.T) {
+	params := []EthABIParam{
+		{Name: "flag", Type: "bool"},
+		{Name: "delta", Type: "int8"},
+		{Name: "tag", Type: "bytes2"},
+		{Name: "note", Type: "string"},
+	}
+	minusOne := make([]byte, 32)
+	for i := range minusOne {
+		minusOne[i] = 0xff
+	}
+	var data []byte
+	data = append(data, abiWord(1)...)
+	data = append(data, minusOne...)
+	data = append(data, append([]byte{0xab, 0xcd}, make([]byte, 30)...)...)
+	data = append(data, abiWord(0x80)...) // offset of the string
+	data = append(data, abiWord(2)...)
+	data = append(data, append([]byte("hi"), make([]byte, 30)...)...)
+
+	values, err := DecodeABIValues(params, data)
+	require.NoError(t, err)
+	require.Equal(t, []any{true, EthBigInt{Int: mathbig.NewInt(-1)}, EthBytes{0xab, 0xcd}, "hi"}, values)
+
+	_, err = DecodeABIValues([]EthABIParam{{Name: "list", Type: "uint256[]"}}, abiWord(0))
+	require.ErrorContains(t, err, "unsupported ABI type uint256[]")
+	// Integer sizes are multiples of 8 bits.
+	_, err = DecodeABIValues([]EthABIParam{{Name: "count", Type: "uint7"}}, abiWord(0))
+	require.ErrorContains(t, err, "unsupported ABI type uint7")
+	_, err = DecodeABIValues([]EthABIParam{{Name: "delta", Type: "int12"}}, abiWord(0))
+	require.ErrorContains(t, err, "unsupported ABI type int12")
+
+	// Offsets must point within the data.
+	_, err = DecodeABIValues([]EthABIParam{{Name: "note", Type: "string"}}, abiWord(0x40))
+	require.ErrorContains(t, err, "invalid offset")
+}
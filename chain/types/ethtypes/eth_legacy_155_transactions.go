@@ -41,9 +41,15 @@ func (tx *EthLegacy155TxArgs) ToEthTx(smsg *types.SignedMessage) (EthTx, error)
 		return EthTx{}, fmt.Errorf("failed to get tx hash: %w", err)
 	}
 
+	// The chain id isn't part of the serialised transaction, it's encoded in V.
+	chainId := deriveEIP155ChainId(tx.legacyTx.V)
+	if !chainId.IsUint64() {
+		return EthTx{}, fmt.Errorf("chain id derived from v is out of range: %s", chainId.String())
+	}
+
 	gasPrice := EthBigInt(tx.legacyTx.GasPrice)
 	ethTx := EthTx{
-		ChainID:  buildconstants.Eip155ChainId,
+		ChainID:  EthUint64(chainId.Uint64()),
 		Type:     EthLegacyTxType,
 		Nonce:    EthUint64(tx.legacyTx.Nonce),
 		Hash:     hash,
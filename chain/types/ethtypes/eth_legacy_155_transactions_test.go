@@ -94,6 +94,7 @@ func TestEIP155Tx(t *testing.T) {
 	expectedHash, err := eth155Tx.TxHash()
 	require.NoError(t, err)
 	require.EqualValues(t, ethTxVal.Hash, expectedHash)
+	require.EqualValues(t, 314, ethTxVal.ChainID)
 	require.Nil(t, ethTxVal.MaxFeePerGas)
 	require.Nil(t, ethTxVal.MaxPriorityFeePerGas)
 	require.EqualValues(t, ethTxVal.Gas, eth155Tx.legacyTx.GasLimit)
@@ -23,6 +23,7 @@ import (
 
 const (
 	EthLegacyTxType = 0x00
+	EIP2930TxType   = 0x01
 	EIP1559TxType   = 0x02
 )
 
@@ -81,10 +82,12 @@ type EthTx struct {
 	MaxFeePerGas         *EthBigInt  `json:"maxFeePerGas,omitempty"`
 	MaxPriorityFeePerGas *EthBigInt  `json:"maxPriorityFeePerGas,omitempty"`
 	GasPrice             *EthBigInt  `json:"gasPrice,omitempty"`
-	AccessList           []EthHash   `json:"accessList"`
-	V                    EthBigInt   `json:"v"`
-	R                    EthBigInt   `json:"r"`
-	S                    EthBigInt   `json:"s"`
+	// AccessList is always empty: Filecoin messages can't carry an access list, so transactions
+	// with a non-empty one are rejected.
+	AccessList []EthAccessTuple `json:"accessList"`
+	V          EthBigInt        `json:"v"`
+	R          EthBigInt        `json:"r"`
+	S          EthBigInt        `json:"s"`
 }
 
 func (tx *EthTx) GasFeeCap() (EthBigInt, error) {
@@ -215,7 +218,7 @@ func ParseEthTransaction(data []byte) (EthTransaction, error) {
 	}
 
 	switch data[0] {
-	case 1:
+	case EIP2930TxType:
 		// EIP-2930
 		return nil, fmt.Errorf("EIP-2930 transaction is not supported")
 	case EIP1559TxType:
@@ -1,6 +1,7 @@
 package ethtypes
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -101,7 +102,13 @@ func TestEthTransactionFromSignedFilecoinMessage(t *testing.T) {
 				require.EqualValues(t, big.NewInt(11), *ethTx.MaxFeePerGas)
 				require.EqualValues(t, big.NewInt(12), *ethTx.MaxPriorityFeePerGas)
 				require.Nil(t, ethTx.GasPrice)
+				require.NotNil(t, ethTx.AccessList)
 				require.Empty(t, ethTx.AccessList)
+
+				// EIP-1559 transactions report an empty access list rather than none.
+				txJSON, err := json.Marshal(ethTx)
+				require.NoError(t, err)
+				require.Contains(t, string(txJSON), `"accessList":[]`)
 			},
 		},
 		"valid-legacy": {
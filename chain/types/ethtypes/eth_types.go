@@ -25,7 +25,6 @@ import (
 
 	"github.com/filecoin-project/lotus/build"
 	"github.com/filecoin-project/lotus/build/buildconstants"
-	"github.com/filecoin-project/lotus/chain/actors"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/lib/must"
 )
@@ -190,6 +189,9 @@ func (e *EthBytes) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// EthBlock is an Ethereum block built from a tipset. Its difficulty and total difficulty are always
+// zero, as Filecoin has no proof-of-work difficulty; they are still reported rather than omitted,
+// like Ethereum nodes do since the merge, for libraries that require them.
 type EthBlock struct {
 	Hash             EthHash    `json:"hash"`
 	ParentHash       EthHash    `json:"parentHash"`
@@ -245,6 +247,7 @@ func NewEthBlock(hasTransactions bool, tipsetLen int) EthBlock {
 		TransactionsRoot: EmptyRootHash, // TransactionsRoot set to a hardcoded value which is used by some clients to determine if has no transactions.
 		ReceiptsRoot:     EmptyEthHash,
 		Difficulty:       EmptyEthInt,
+		TotalDifficulty:  EmptyEthInt,
 		LogsBloom:        NewFullEthBloom(),
 		Extradata:        []byte{},
 		MixHash:          EmptyEthHash,
@@ -260,6 +263,7 @@ func NewEthBlock(hasTransactions bool, tipsetLen int) EthBlock {
 	return b
 }
 
+// EthCall describes a call to simulate, e.g. with eth_call or eth_estimateGas.
 type EthCall struct {
 	From     *EthAddress `json:"from"`
 	To       *EthAddress `json:"to"`
@@ -267,6 +271,110 @@ type EthCall struct {
 	GasPrice EthBigInt   `json:"gasPrice"`
 	Value    EthBigInt   `json:"value"`
 	Data     EthBytes    `json:"data"`
+
+	// MaxFeePerGas and MaxPriorityFeePerGas are the EIP-1559 fee fields. Gas estimation executes
+	// the call with them against the real base fee, and prices the funds a contract creation needs
+	// with the fee cap. eth_call prices the gas of Affordable calls at the base fee of the block,
+	// or its override, plus the priority fee, up to the fee cap; they don't affect other calls.
+	MaxFeePerGas         *EthBigInt `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *EthBigInt `json:"maxPriorityFeePerGas,omitempty"`
+
+	// Type is the transaction type the call was declared as, if any. See CheckType and FeeParams.
+	Type *EthUint64 `json:"type,omitempty"`
+	// AccessList is accepted for EIP-2930 and EIP-1559 calls but has no effect, as the FEVM doesn't
+	// price storage and account accesses by warmth.
+	AccessList []EthAccessTuple `json:"accessList,omitempty"`
+
+	// Affordable is a Lotus extension capping the gas available to the call to what the sender's
+	// balance can pay for at the call's gas price once its value is transferred, as it would be for
+	// a real transaction, instead of the block gas limit. It isn't supported by gas estimation.
+	Affordable bool `json:"affordable,omitempty"`
+	// Nonce is a Lotus extension setting the nonce of the sender for the call, which determines the
+	// address of contracts it creates. It defaults to the sender's current nonce, and is a shorthand
+	// for a nonce override of the sender. It is only supported by single calls, and not by gas
+	// estimation.
+	Nonce *EthUint64 `json:"nonce,omitempty"`
+	// Origin is a Lotus extension setting tx.origin for the call independently of From, which
+	// remains msg.sender of the called contract. The call is then sent by Origin to a stub standing
+	// in for the code of From, which forwards it to To along with its value, so Origin pays for the
+	// value. It defaults to From, and is only supported by single calls to contracts, and not by
+	// gas estimation.
+	// Because of the stub, From has code during the call: the called contract sees it as a
+	// contract rather than an account, so checks that msg.sender is an EOA, such as
+	// extcodesize(msg.sender) == 0, fail, and calls back into From reach the stub rather than the
+	// code From may have on chain.
+	Origin *EthAddress `json:"origin,omitempty"`
+	// ReportTouched is a Lotus extension asking eth_callDetailed to report the addresses the call
+	// touched. It has no effect on other methods.
+	ReportTouched bool `json:"reportTouched,omitempty"`
+	// ReportBalance is a Lotus extension asking eth_callDetailed to report the balance of the sender
+	// before and after the call. It has no effect on other methods.
+	ReportBalance bool `json:"reportBalance,omitempty"`
+	// EventsABI is a Lotus extension giving eth_callDetailed the ABI of the events the call may
+	// emit, to decode the logs it reports. It has no effect on other methods.
+	EventsABI []EthABIEvent `json:"eventsAbi,omitempty"`
+	// OutputTypes is a Lotus extension giving eth_callDetailed the ABI types of the values a call to
+	// a contract returns, such as "uint256", to decode its return data. It has no effect on other
+	// methods.
+	OutputTypes []string `json:"outputTypes,omitempty"`
+}
+
+// EthAccessTuple is an entry of an EIP-2930 access list.
+type EthAccessTuple struct {
+	Address     EthAddress `json:"address"`
+	StorageKeys []EthHash  `json:"storageKeys"`
+}
+
+// HasFeeParams returns true if the call specifies EIP-1559 fee parameters.
+func (c *EthCall) HasFeeParams() bool {
+	return c.MaxFeePerGas != nil || c.MaxPriorityFeePerGas != nil
+}
+
+// CheckType checks that the fields of the call are consistent with its declared type: legacy calls
+// can't carry EIP-1559 fees or an access list, EIP-2930 calls can't carry EIP-1559 fees, and
+// EIP-1559 calls can't carry a gas price. Calls without a declared type aren't checked.
+func (c *EthCall) CheckType() error {
+	if c.Type == nil {
+		return nil
+	}
+
+	switch *c.Type {
+	case EthLegacyTxType:
+		if c.HasFeeParams() {
+			return xerrors.New("maxFeePerGas and maxPriorityFeePerGas are not allowed in legacy transactions")
+		}
+		if c.AccessList != nil {
+			return xerrors.New("accessList is not allowed in legacy transactions")
+		}
+	case EIP2930TxType:
+		if c.HasFeeParams() {
+			return xerrors.New("maxFeePerGas and maxPriorityFeePerGas are not allowed in EIP-2930 transactions")
+		}
+	case EIP1559TxType:
+		if c.hasGasPrice() {
+			return xerrors.New("gasPrice is not allowed in EIP-1559 transactions, use maxFeePerGas and maxPriorityFeePerGas")
+		}
+	default:
+		return xerrors.Errorf("unsupported transaction type %s", c.Type.Hex())
+	}
+	return nil
+}
+
+// FeeParams returns the fee cap and premium specified by the call, either of which may be nil.
+// Calls declared as legacy or EIP-2930 transactions pay their gas price as both, like the
+// corresponding signed transactions do; otherwise the EIP-1559 fee fields are used.
+func (c *EthCall) FeeParams() (feeCap, premium *EthBigInt) {
+	if c.Type != nil && (*c.Type == EthLegacyTxType || *c.Type == EIP2930TxType) {
+		if !c.hasGasPrice() {
+			return nil, nil
+		}
+		return &c.GasPrice, &c.GasPrice
+	}
+	return c.MaxFeePerGas, c.MaxPriorityFeePerGas
+}
+
+func (c *EthCall) hasGasPrice() bool {
+	return c.GasPrice.Int != nil && c.GasPrice.Sign() != 0
 }
 
 func (c *EthCall) ToFilecoinMessage() (*types.Message, error) {
@@ -292,12 +400,15 @@ func (c *EthCall) ToFilecoinMessage() (*types.Message, error) {
 
 	var params []byte
 	if len(c.Data) > 0 {
+		// Size the buffer for the CBOR header (at most 9 bytes) and the data upfront, so that large
+		// calldata is only copied once.
+		var buf bytes.Buffer
+		buf.Grow(len(c.Data) + 9)
 		initcode := abi.CborBytes(c.Data)
-		params2, err := actors.SerializeParams(&initcode)
-		if err != nil {
+		if err := initcode.MarshalCBOR(&buf); err != nil {
 			return nil, xerrors.Errorf("failed to serialize params: %w", err)
 		}
-		params = params2
+		params = buf.Bytes()
 	}
 
 	var to address.Address
@@ -333,6 +444,10 @@ func (c *EthCall) UnmarshalJSON(b []byte) error {
 		// The field should be "input" by spec, but many clients use "data" so we support
 		// both, but prefer "input".
 		Input *EthBytes `json:"input"`
+		// The fee fields are decoded separately to report which one is invalid.
+		GasPrice             json.RawMessage `json:"gasPrice"`
+		MaxFeePerGas         json.RawMessage `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas json.RawMessage `json:"maxPriorityFeePerGas"`
 		EthCallRaw
 	}
 
@@ -346,10 +461,56 @@ func (c *EthCall) UnmarshalJSON(b []byte) error {
 		params.Data = *params.Input
 	}
 
+	gasPrice, err := decodeEthCallFee("gasPrice", params.GasPrice)
+	if err != nil {
+		return err
+	}
+	if gasPrice != nil {
+		params.EthCallRaw.GasPrice = *gasPrice
+	}
+	if params.EthCallRaw.MaxFeePerGas, err = decodeEthCallFee("maxFeePerGas", params.MaxFeePerGas); err != nil {
+		return err
+	}
+	if params.EthCallRaw.MaxPriorityFeePerGas, err = decodeEthCallFee("maxPriorityFeePerGas", params.MaxPriorityFeePerGas); err != nil {
+		return err
+	}
+
 	*c = EthCall(params.EthCallRaw)
 	return nil
 }
 
+// decodeEthCallFee decodes the fee field of a call with the given name, which is nil if it is
+// absent. Unlike other EthBigInt values, which decode malformed quantities leniently, fees must be
+// valid, non-negative hex quantities.
+func decodeEthCallFee(name string, raw json.RawMessage) (*EthBigInt, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, xerrors.Errorf("invalid %s: %w", name, err)
+	}
+
+	if strings.HasPrefix(s, "-") {
+		return nil, xerrors.Errorf("invalid %s: must not be negative", name)
+	}
+	// Exactly one 0x prefix followed by hex digits, without a sign.
+	digits, ok := strings.CutPrefix(s, "0x")
+	if !ok || digits == "" || strings.IndexFunc(digits, func(r rune) bool { return !isHexDigit(r) }) >= 0 {
+		return nil, xerrors.Errorf("invalid %s: invalid hex quantity %q", name, s)
+	}
+	i, ok := new(mathbig.Int).SetString(digits, 16)
+	if !ok {
+		return nil, xerrors.Errorf("invalid %s: invalid hex quantity %q", name, s)
+	}
+	fee := EthBigInt(big.NewFromGo(i))
+	return &fee, nil
+}
+
+func isHexDigit(r rune) bool {
+	return ('0' <= r && r <= '9') || ('a' <= r && r <= 'f') || ('A' <= r && r <= 'F')
+}
+
 type EthSyncingResult struct {
 	DoneSync      bool
 	StartingBlock EthUint64
@@ -485,7 +646,9 @@ func EthAddressFromFilecoinAddress(addr address.Address) (EthAddress, error) {
 	return EthAddress{}, ErrInvalidAddress
 }
 
-// ParseEthAddress parses an Ethereum address from a hex string.
+// ParseEthAddress parses an Ethereum address from a hex string. Hex digits are case-insensitive, so
+// a checksummed (mixed-case) address parses to the same value as its lowercase form; the checksum
+// itself isn't verified.
 func ParseEthAddress(s string) (EthAddress, error) {
 	b, err := decodeHexString(s, EthAddressLength)
 	if err != nil {
@@ -527,6 +690,20 @@ func (ea *EthAddress) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText and UnmarshalText allow EthAddress to be used as a JSON object key.
+func (ea EthAddress) MarshalText() ([]byte, error) {
+	return []byte(ea.String()), nil
+}
+
+func (ea *EthAddress) UnmarshalText(b []byte) error {
+	addr, err := ParseEthAddress(string(b))
+	if err != nil {
+		return err
+	}
+	copy(ea[:], addr[:])
+	return nil
+}
+
 func (ea EthAddress) IsMaskedID() bool {
 	return bytes.HasPrefix(ea[:], maskedIDPrefix[:])
 }
@@ -567,6 +744,20 @@ func (h *EthHash) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalText and UnmarshalText allow EthHash to be used as a JSON object key.
+func (h EthHash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+func (h *EthHash) UnmarshalText(b []byte) error {
+	hash, err := ParseEthHash(string(b))
+	if err != nil {
+		return err
+	}
+	copy(h[:], hash[:])
+	return nil
+}
+
 func (h EthHash) String() string {
 	return "0x" + hex.EncodeToString(h[:])
 }
@@ -670,6 +861,28 @@ type EthFeeHistory struct {
 	Reward        *[][]EthBigInt `json:"reward,omitempty"`
 }
 
+// EthProof is the result of eth_getProof. The Filecoin state isn't a Merkle Patricia trie, so the
+// proofs aren't Ethereum proofs: AccountProof holds the raw IPLD blocks read from the state root
+// of the block to the actor, including those resolving its address, each of which can be checked
+// against the CID linking to it. StorageHash is the hash of the CID of the actor's state.
+type EthProof struct {
+	Address      EthAddress        `json:"address"`
+	Balance      EthBigInt         `json:"balance"`
+	Nonce        EthUint64         `json:"nonce"`
+	CodeHash     EthHash           `json:"codeHash"`
+	StorageHash  EthHash           `json:"storageHash"`
+	AccountProof []EthBytes        `json:"accountProof"`
+	StorageProof []EthStorageProof `json:"storageProof"`
+}
+
+// EthStorageProof is the value of a storage slot of an EthProof. Contract storage is kept in a
+// KAMT which can only be read through the EVM actor, so Proof is always empty.
+type EthStorageProof struct {
+	Key   EthHash    `json:"key"`
+	Value EthBigInt  `json:"value"`
+	Proof []EthBytes `json:"proof"`
+}
+
 type EthFilterID EthHash
 
 func (h EthFilterID) MarshalJSON() ([]byte, error) {
@@ -724,6 +937,29 @@ type EthFilterSpec struct {
 	// If BlockHash is present in the filter criteria, then neither FromBlock nor ToBlock are allowed.
 	// Added in EIP-234
 	BlockHash *EthHash `json:"blockHash,omitempty"`
+
+	// Event signature, such as "Transfer(address,address,uint256)", whose keccak-256 hash the first
+	// topic must match. This is a Lotus extension sparing clients from hashing the signature
+	// themselves; it must not be combined with a first topic in Topics.
+	// Optional, default: empty.
+	EventSignature string `json:"eventSignature,omitempty"`
+
+	// Minimum number of topics, from 0 to 4, event logs must have to be returned. This is a Lotus
+	// extension applied after the other criteria, so logs dropped for having fewer topics still
+	// count towards the result limits of the node and towards eth_estimateLogsCount.
+	// Optional, default: 0.
+	MinTopics EthUint64 `json:"minTopics,omitempty"`
+
+	// Topics event logs must not have, in the same format as Topics: logs whose topic at a position
+	// is one of the hashes listed for that position are dropped. This is a Lotus extension applied
+	// after the other criteria, like MinTopics.
+	// Optional, default: empty list.
+	ExcludeTopics EthTopicSpec `json:"excludeTopics,omitempty"`
+
+	// Return the event logs grouped by the hash of the transaction that emitted them, as an object
+	// rather than an array. This is a Lotus extension only honoured by eth_getLogs.
+	// Optional, default: false.
+	GroupByTransaction bool `json:"groupByTransaction,omitempty"`
 }
 
 // EthAddressList represents a list of addresses.
@@ -799,9 +1035,16 @@ func (e *EthHashList) UnmarshalJSON(b []byte) error {
 // The JSON encoding must produce an array of the populated field.
 type EthFilterResult struct {
 	Results []interface{}
+	// ByTransaction holds the event logs grouped by the hash of the transaction that emitted them,
+	// when requested through EthFilterSpec.GroupByTransaction. If set, it is encoded as an object in
+	// place of Results.
+	ByTransaction map[EthHash][]EthLog
 }
 
 func (h EthFilterResult) MarshalJSON() ([]byte, error) {
+	if h.ByTransaction != nil {
+		return json.Marshal(h.ByTransaction)
+	}
 	if h.Results != nil {
 		return json.Marshal(h.Results)
 	}
@@ -812,10 +1055,33 @@ func (h *EthFilterResult) UnmarshalJSON(b []byte) error {
 	if bytes.Equal(b, []byte{'n', 'u', 'l', 'l'}) {
 		return nil
 	}
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '{' {
+		return json.Unmarshal(b, &h.ByTransaction)
+	}
 	err := json.Unmarshal(b, &h.Results)
 	return err
 }
 
+// EthLogsQueryPlan describes how an eth_getLogs query would be executed, without executing it.
+type EthLogsQueryPlan struct {
+	// FromBlock and ToBlock are the range of blocks the query covers, once block tags are resolved
+	// against the head. BlockHash is set instead for queries of a single block.
+	FromBlock *EthUint64 `json:"fromBlock,omitempty"`
+	ToBlock   *EthUint64 `json:"toBlock,omitempty"`
+	BlockHash *EthHash   `json:"blockHash,omitempty"`
+	// Tipsets is the number of tipsets the query scans at most, counting null rounds.
+	Tipsets EthUint64 `json:"tipsets"`
+	// IndexFilters lists the criteria the chain index narrows the scanned events with: "blockHash"
+	// or "blockRange", and "address" and "topics" if the query filters on them. Filecoin blocks carry
+	// a full logs bloom, so blooms are never used to skip blocks: the index takes their place.
+	IndexFilters []string `json:"indexFilters"`
+	// PostFilters lists the criteria applied to the events returned by the index, which it can't
+	// match itself: "minTopics" and "excludeTopics".
+	PostFilters []string `json:"postFilters,omitempty"`
+	// MaxResults is the number of logs past which the query fails, if it is limited.
+	MaxResults EthUint64 `json:"maxResults,omitempty"`
+}
+
 // EthLog represents the results of an event filter execution.
 type EthLog struct {
 	// Address is the address of the actor that produced the event log.
@@ -897,6 +1163,33 @@ type EthSubscriptionParams struct {
 	// The JSON decoding must treat a string as equivalent to an array with one value, for example
 	// "0x8888f1f195afa192cfee86069858" must be decoded as [ "0x8888f1f195afa192cfee86069858" ]
 	Address EthAddressList `json:"address"`
+
+	// Event signature whose keccak-256 hash the first topic must match, as in EthFilterSpec.
+	// Optional, default: empty.
+	EventSignature string `json:"eventSignature,omitempty"`
+
+	// Minimum number of topics event logs must have, as in EthFilterSpec.
+	// Optional, default: 0.
+	MinTopics EthUint64 `json:"minTopics,omitempty"`
+
+	// Topics event logs must not have, as in EthFilterSpec.
+	// Optional, default: empty list.
+	ExcludeTopics EthTopicSpec `json:"excludeTopics,omitempty"`
+}
+
+// FilterSpec returns the filter spec holding the criteria of the subscription, which has no block
+// range as it follows the chain head.
+func (p *EthSubscriptionParams) FilterSpec() *EthFilterSpec {
+	if p == nil {
+		return &EthFilterSpec{}
+	}
+	return &EthFilterSpec{
+		Address:        p.Address,
+		Topics:         p.Topics,
+		EventSignature: p.EventSignature,
+		MinTopics:      p.MinTopics,
+		ExcludeTopics:  p.ExcludeTopics,
+	}
 }
 
 type EthSubscriptionResponse struct {
@@ -1003,6 +1296,495 @@ func (e EthEstimateGasParams) MarshalJSON() ([]byte, error) {
 	return json.Marshal([]interface{}{e.Tx})
 }
 
+// EthCallParams handles raw jsonrpc params for eth_call
+type EthCallParams struct {
+	Tx EthCall
+	// BlkParam defaults to "latest" when not specified.
+	BlkParam       *EthBlockNumberOrHash
+	StateOverrides EthStateOverrides
+	BlockOverrides *EthBlockOverrides
+}
+
+func (e *EthCallParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 4:
+		err = json.Unmarshal(params[3], &e.BlockOverrides)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 3:
+		err = json.Unmarshal(params[2], &e.StateOverrides)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 2:
+		err = json.Unmarshal(params[1], &e.BlkParam)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 1:
+		err = json.Unmarshal(params[0], &e.Tx)
+		if err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("expected 1 to 4 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthCallParams) MarshalJSON() ([]byte, error) {
+	blkParam := e.BlkParam
+	if blkParam == nil && (e.StateOverrides != nil || e.BlockOverrides != nil) {
+		latest := NewEthBlockNumberOrHashFromPredefined(BlockTagLatest)
+		blkParam = &latest
+	}
+
+	params := []interface{}{e.Tx}
+	if blkParam != nil {
+		params = append(params, blkParam)
+	}
+	if e.StateOverrides != nil || e.BlockOverrides != nil {
+		params = append(params, e.StateOverrides)
+	}
+	if e.BlockOverrides != nil {
+		params = append(params, e.BlockOverrides)
+	}
+	return json.Marshal(params)
+}
+
+// EthBlockOverrides describes changes to the block a call is simulated in. This follows the block
+// overrides accepted by Geth's eth_call. They can be combined with state overrides.
+type EthBlockOverrides struct {
+	// BaseFeePerGas replaces the base fee reported by the BASEFEE opcode and paid by calls
+	// setting a maximum fee per gas.
+	BaseFeePerGas *EthBigInt `json:"baseFeePerGas,omitempty"`
+	// Number and Time replace the number and timestamp of the block, as reported by the NUMBER and
+	// TIMESTAMP opcodes. The number can't precede the block the call is simulated in. Blocks past
+	// the head have no randomness yet, so PREVRANDAO reports that of the block the call is simulated
+	// in, unless PrevRandao is set.
+	Number *EthUint64 `json:"number,omitempty"`
+	Time   *EthUint64 `json:"time,omitempty"`
+	// PrevRandao replaces the beacon randomness of the block. The PREVRANDAO opcode reports a value
+	// derived from it rather than the value itself, as Filecoin draws the randomness of each use
+	// from the beacon's.
+	PrevRandao *EthHash `json:"prevRandao,omitempty"`
+	// ChainRandomness replaces the chain randomness of the block, which the EVM doesn't expose but
+	// built-in actors called through the call actor precompile draw from, e.g. for proof challenges.
+	ChainRandomness *EthHash `json:"chainRandomness,omitempty"`
+	// GasLimit lowers the gas available to the call, which is otherwise the block gas limit. The
+	// GASLIMIT opcode still reports the block gas limit, which is fixed by the EVM.
+	GasLimit *EthUint64 `json:"gasLimit,omitempty"`
+	// Coinbase can only be the zero address, which the COINBASE opcode always reports on Filecoin,
+	// as tipsets are produced by several miners.
+	Coinbase *EthAddress `json:"coinbase,omitempty"`
+	// ChainID replaces the chain id reported by the CHAINID opcode, e.g. to simulate a call as it
+	// would run on another chain. It doesn't change the chain id transactions are signed for.
+	ChainID *EthUint64 `json:"chainId,omitempty"`
+}
+
+// EthStateOverrides describes changes to the state of accounts, keyed by address, that are applied
+// before a call is simulated. This follows the state override set accepted by Geth's eth_call.
+type EthStateOverrides map[EthAddress]EthAccountOverride
+
+// EthAccountOverride describes the changes to apply to a single account. Fields that are not set
+// are left untouched.
+type EthAccountOverride struct {
+	Nonce   *EthUint64 `json:"nonce,omitempty"`
+	Balance *EthBigInt `json:"balance,omitempty"`
+	// Code replaces the account's EVM bytecode, turning it into a contract if it isn't one. Empty
+	// code clears the code of a contract, so calls to it succeed without returning anything.
+	Code *EthBytes `json:"code,omitempty"`
+	// ReturnData is a Lotus extension replacing the account's code with a stub that returns the
+	// given data to every call, e.g. to mock an oracle. It can't be combined with Code.
+	ReturnData *EthBytes `json:"returnData,omitempty"`
+	// State replaces the contract's entire storage with the given slots: the others read as zero.
+	State map[EthHash]EthHash `json:"state,omitempty"`
+	// StateDiff replaces the given storage slots of the contract, leaving the others untouched. It
+	// can't be combined with State.
+	StateDiff map[EthHash]EthHash `json:"stateDiff,omitempty"`
+}
+
+// EthCallDetailedResult is the result of eth_callDetailed.
+//
+// Limitations: some details of a call can't be reported, as the FVM runs the EVM actor as a whole
+// and only traces the messages between actors and the IPLD blocks they read and write.
+//   - Gas refunds: calls are charged FVM gas, which refunds nothing for clearing storage under any
+//     network version, so there is no EIP-3529 refund to report, nor refund rules to choose from.
+//   - Precompile calls: the EVM actor runs precompiles itself, without invoking another actor, so
+//     they leave no trace of their invocation or of its input.
+//   - Opcode counts: the EVM actor interprets the bytecode inside the FVM, which runs no hook for
+//     each executed instruction.
+//   - Storage accesses: the SLOAD and SSTORE operations of the EVM aren't traced, so the storage
+//     slots a call read or wrote can't be told apart; StateChanged only says whether it wrote any.
+type EthCallDetailedResult struct {
+	// ReturnData is the data returned by the call or, for contract creations, the runtime code of
+	// the created contract.
+	ReturnData EthBytes `json:"returnData"`
+	// Output is the return data decoded with EthCall.OutputTypes, if given, as DecodeABIValues
+	// decodes it.
+	Output []any `json:"output,omitempty"`
+	// CreatedAddress is the address the contract was deployed at, for contract creations.
+	CreatedAddress *EthAddress `json:"createdAddress,omitempty"`
+	// CodeSize and CodeHash are the size and keccak256 hash of the runtime code of the created
+	// contract, for contract creations.
+	CodeSize *EthUint64 `json:"codeSize,omitempty"`
+	CodeHash *EthHash   `json:"codeHash,omitempty"`
+	// Sender is EthCallSenderExisting if the sender of the call has an actor on chain, or
+	// EthCallSenderSynthetic if one was created for the call. A synthetic sender starts without
+	// funds, so it can only transfer value given to it by a balance override.
+	Sender string `json:"sender"`
+	// GasLimit is the gas limit the call was applied with: the block gas limit, lowered by a gas
+	// limit block override and to what the sender can pay for if requested through
+	// EthCall.Affordable.
+	GasLimit EthUint64 `json:"gasLimit"`
+	// StateChanged is whether the call changed any state, such as the storage of a contract or the
+	// balance of an account, telling calls to view functions apart from others without knowing the
+	// ABI of the contract. The nonce of the sender, which every message increments, isn't taken into
+	// account.
+	StateChanged bool `json:"stateChanged"`
+	// SenderBalanceBefore and SenderBalanceAfter are the balance of the sender before and after the
+	// call if requested through EthCall.ReportBalance. The balance before the call takes balance
+	// overrides into account. As calls are applied without charging for gas, the difference is the
+	// value the call transferred away from the sender, less what was sent back to it.
+	SenderBalanceBefore *EthBigInt `json:"senderBalanceBefore,omitempty"`
+	SenderBalanceAfter  *EthBigInt `json:"senderBalanceAfter,omitempty"`
+	// Logs are the logs the call would emit, in order.
+	Logs []EthCallLog `json:"logs,omitempty"`
+	// Touched lists the addresses touched by the call if requested through EthCall.ReportTouched, in
+	// the order they were first touched: the sender, the recipient and every actor invoked during
+	// the call, including the contracts whose code was read with EXTCODESIZE, EXTCODEHASH or
+	// EXTCODECOPY. Accounts whose balance was read with BALANCE aren't included, as the FVM doesn't
+	// trace balance lookups, and neither are precompiles.
+	Touched []EthAddress `json:"touched,omitempty"`
+}
+
+// EthCallLog is a log emitted by a call simulated by eth_callDetailed.
+type EthCallLog struct {
+	Address EthAddress `json:"address"`
+	Data    EthBytes   `json:"data"`
+	Topics  []EthHash  `json:"topics"`
+	// Event and Fields are the name of the event of EthCall.EventsABI the log was emitted by, if
+	// any, and the fields of the log decoded with its ABI, keyed by name.
+	Event  string         `json:"event,omitempty"`
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+const (
+	EthCallSenderExisting  = "existing"
+	EthCallSenderSynthetic = "synthetic"
+)
+
+// EthCallManyParams handles raw jsonrpc params for eth_callMany. The calls are simulated one after
+// the other, each seeing the changes made by the previous ones.
+type EthCallManyParams struct {
+	Calls []EthCall
+	// BlkParam defaults to "latest" when not specified.
+	BlkParam *EthBlockNumberOrHash
+	// StateOverrides are applied once, before the first call.
+	StateOverrides EthStateOverrides
+	Options        EthCallManyOptions
+}
+
+// EthCallManyOptions tune what eth_callMany returns for the calls of a bundle.
+type EthCallManyOptions struct {
+	// TraceReverts attaches the call frame of the calls that revert to their result, as reported
+	// by the callTracer of debug_traceCall. Successful calls aren't traced.
+	TraceReverts bool `json:"traceReverts,omitempty"`
+}
+
+func (e *EthCallManyParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 4:
+		err = json.Unmarshal(params[3], &e.Options)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 3:
+		err = json.Unmarshal(params[2], &e.StateOverrides)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 2:
+		err = json.Unmarshal(params[1], &e.BlkParam)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 1:
+		err = json.Unmarshal(params[0], &e.Calls)
+		if err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("expected 1 to 4 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthCallManyParams) MarshalJSON() ([]byte, error) {
+	hasOptions := e.Options != (EthCallManyOptions{})
+	blkParam := e.BlkParam
+	if blkParam == nil && (e.StateOverrides != nil || hasOptions) {
+		latest := NewEthBlockNumberOrHashFromPredefined(BlockTagLatest)
+		blkParam = &latest
+	}
+
+	params := []interface{}{e.Calls}
+	if blkParam != nil {
+		params = append(params, blkParam)
+	}
+	if e.StateOverrides != nil || hasOptions {
+		params = append(params, e.StateOverrides)
+	}
+	if hasOptions {
+		params = append(params, e.Options)
+	}
+	return json.Marshal(params)
+}
+
+// EthCallAtStateRootParams handles raw jsonrpc params for EthCallAtStateRoot.
+type EthCallAtStateRootParams struct {
+	Tx EthCall
+	// StateRoot is the root of the state tree the call is applied on.
+	StateRoot cid.Cid
+}
+
+func (e *EthCallAtStateRootParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+	if len(params) != 2 {
+		return xerrors.Errorf("expected 2 params, got %d", len(params))
+	}
+
+	err = json.Unmarshal(params[0], &e.Tx)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(params[1], &e.StateRoot)
+}
+
+func (e EthCallAtStateRootParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.Tx, e.StateRoot})
+}
+
+// EthCallManyResult is the outcome of one of the calls of an eth_callMany bundle.
+type EthCallManyResult struct {
+	// ReturnData is the data returned by the call or, if it reverted, the revert data. It is empty
+	// for contract creations.
+	ReturnData EthBytes `json:"returnData"`
+	// CreatedAddress is the address the contract was deployed at, for contract creations.
+	CreatedAddress *EthAddress `json:"createdAddress,omitempty"`
+	// Error describes why the call failed, if it did.
+	Error string `json:"error,omitempty"`
+	// Trace is the call frame of the call, if it reverted and the calls that revert were asked to
+	// be traced.
+	Trace *EthCallFrame `json:"trace,omitempty"`
+}
+
+const (
+	EthCallTracer     = "callTracer"
+	EthPrestateTracer = "prestateTracer"
+)
+
+// EthTraceCallParams handles raw jsonrpc params for debug_traceCall.
+type EthTraceCallParams struct {
+	Tx EthCall
+	// BlkParam defaults to "latest" when not specified.
+	BlkParam *EthBlockNumberOrHash
+	Config   EthTraceCallConfig
+}
+
+func (e *EthTraceCallParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 3:
+		err = json.Unmarshal(params[2], &e.Config)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 2:
+		err = json.Unmarshal(params[1], &e.BlkParam)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 1:
+		err = json.Unmarshal(params[0], &e.Tx)
+		if err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("expected 1 to 3 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthTraceCallParams) MarshalJSON() ([]byte, error) {
+	blkParam := NewEthBlockNumberOrHashFromPredefined(BlockTagLatest)
+	if e.BlkParam != nil {
+		blkParam = *e.BlkParam
+	}
+	return json.Marshal([]interface{}{e.Tx, blkParam, e.Config})
+}
+
+// EthTraceCallConfig configures debug_traceCall, as in Geth.
+type EthTraceCallConfig struct {
+	// Tracer is either EthCallTracer or EthPrestateTracer. Geth's default opcode logger isn't
+	// supported, as the FVM doesn't trace the instructions executed by the EVM.
+	Tracer       string           `json:"tracer,omitempty"`
+	TracerConfig *EthTracerConfig `json:"tracerConfig,omitempty"`
+	// StateOverrides and BlockOverrides are applied as by eth_call.
+	StateOverrides EthStateOverrides  `json:"stateOverrides,omitempty"`
+	BlockOverrides *EthBlockOverrides `json:"blockOverrides,omitempty"`
+}
+
+// EthTracerConfig configures the tracer of debug_traceCall.
+type EthTracerConfig struct {
+	// OnlyTopCall leaves the subcalls out of the trace of the EthCallTracer.
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"`
+}
+
+// EthTraceCallResult is the result of debug_traceCall, in the format of the tracer it was made
+// with: CallFrame for the EthCallTracer, Prestate for the EthPrestateTracer.
+type EthTraceCallResult struct {
+	CallFrame *EthCallFrame
+	Prestate  EthPrestate
+}
+
+func (r EthTraceCallResult) MarshalJSON() ([]byte, error) {
+	if r.CallFrame != nil {
+		return json.Marshal(r.CallFrame)
+	}
+	return json.Marshal(r.Prestate)
+}
+
+func (r *EthTraceCallResult) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	// Call frames always have a type, which is never an address.
+	if _, ok := fields["type"]; ok {
+		return json.Unmarshal(b, &r.CallFrame)
+	}
+	return json.Unmarshal(b, &r.Prestate)
+}
+
+// EthCallFrame is a call traced by the EthCallTracer of debug_traceCall, as reported by Geth. Gas
+// and GasUsed are in units of Filecoin gas.
+type EthCallFrame struct {
+	// Type is CALL, STATICCALL, DELEGATECALL or CREATE.
+	Type string     `json:"type"`
+	From EthAddress `json:"from"`
+	// To is the address of the created contract for contract creations, if it was created.
+	To      *EthAddress     `json:"to,omitempty"`
+	Value   EthBigInt       `json:"value"`
+	Gas     EthUint64       `json:"gas"`
+	GasUsed EthUint64       `json:"gasUsed"`
+	Input   EthBytes        `json:"input"`
+	Output  EthBytes        `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*EthCallFrame `json:"calls,omitempty"`
+}
+
+// EthPrestate is the trace made by the EthPrestateTracer of debug_traceCall: the accounts a call
+// touched, as they were before it. Their storage isn't reported, as the FVM doesn't trace the
+// storage slots read and written by the EVM.
+type EthPrestate map[EthAddress]EthPrestateAccount
+
+type EthPrestateAccount struct {
+	Balance EthBigInt `json:"balance"`
+	Nonce   EthUint64 `json:"nonce,omitempty"`
+	Code    EthBytes  `json:"code,omitempty"`
+}
+
+// EthTraceBlockParams handles raw jsonrpc params for debug_traceBlockByNumber and
+// debug_traceBlockByHash.
+type EthTraceBlockParams struct {
+	// Block is the number or tag of the block, or its hash.
+	Block  string
+	Config EthTraceBlockConfig
+}
+
+func (e *EthTraceBlockParams) UnmarshalJSON(b []byte) error {
+	var params []json.RawMessage
+	err := json.Unmarshal(b, &params)
+	if err != nil {
+		return err
+	}
+
+	switch len(params) {
+	case 2:
+		err = json.Unmarshal(params[1], &e.Config)
+		if err != nil {
+			return err
+		}
+		fallthrough
+	case 1:
+		err = json.Unmarshal(params[0], &e.Block)
+		if err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("expected 1 or 2 params, got %d", len(params))
+	}
+
+	return nil
+}
+
+func (e EthTraceBlockParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.Block, e.Config})
+}
+
+// EthTraceBlockConfig configures debug_traceBlockByNumber and debug_traceBlockByHash. It takes the
+// fields of EthTraceCallConfig that apply to the transactions of a block, so only the
+// EthCallTracer is supported: the state between the transactions of a block isn't kept for the
+// EthPrestateTracer.
+type EthTraceBlockConfig struct {
+	Tracer       string           `json:"tracer,omitempty"`
+	TracerConfig *EthTracerConfig `json:"tracerConfig,omitempty"`
+	// EVMOnly is a Lotus extension leaving out the messages that don't invoke an EVM actor or the
+	// Ethereum Address Manager, such as native transfers and miner messages.
+	EVMOnly bool `json:"evmOnly,omitempty"`
+}
+
+// EthTraceBlockResult is the trace of a transaction of a block, as reported by Geth's
+// debug_traceBlockByNumber and debug_traceBlockByHash.
+type EthTraceBlockResult struct {
+	TxHash EthHash       `json:"txHash"`
+	Result *EthCallFrame `json:"result"`
+}
+
 // EthFeeHistoryParams handles raw jsonrpc params for eth_feeHistory
 type EthFeeHistoryParams struct {
 	BlkCount          EthUint64
@@ -1185,6 +1967,22 @@ type EthTraceReplayBlockTransaction struct {
 	VmTrace         *string     `json:"vmTrace"`
 }
 
+// EthReplayTransactionResult is the outcome of re-executing a mined transaction on top of the
+// state it was originally executed on.
+type EthReplayTransactionResult struct {
+	TransactionHash EthHash   `json:"transactionHash"`
+	BlockHash       EthHash   `json:"blockHash"`
+	BlockNumber     EthUint64 `json:"blockNumber"`
+	// Status is 1 if the transaction succeeded and 0 if it failed, as in its receipt.
+	Status  EthUint64 `json:"status"`
+	GasUsed EthUint64 `json:"gasUsed"`
+	// Output is the output of the top level trace of the transaction, as in
+	// trace_replayBlockTransactions.
+	Output EthBytes    `json:"output"`
+	Error  string      `json:"error,omitempty"`
+	Trace  []*EthTrace `json:"trace"`
+}
+
 type EthTraceTransaction struct {
 	*EthTrace
 	BlockHash           EthHash `json:"blockHash"`
@@ -1274,9 +2072,13 @@ type EthTxReceipt struct {
 	CumulativeGasUsed EthUint64   `json:"cumulativeGasUsed"`
 	GasUsed           EthUint64   `json:"gasUsed"`
 	EffectiveGasPrice EthBigInt   `json:"effectiveGasPrice"`
-	LogsBloom         EthBytes    `json:"logsBloom"`
-	Logs              []EthLog    `json:"logs"`
-	Type              EthUint64   `json:"type"`
+	// EffectivePriorityFeePerGas is a Lotus extension reporting the priority fee per unit of gas paid
+	// to the block producer: the gas premium of the transaction, capped by what its fee cap leaves
+	// above the base fee. Unlike on Ethereum, it is paid on the gas limit rather than the gas used.
+	EffectivePriorityFeePerGas EthBigInt `json:"effectivePriorityFeePerGas"`
+	LogsBloom                  EthBytes  `json:"logsBloom"`
+	Logs                       []EthLog  `json:"logs"`
+	Type                       EthUint64 `json:"type"`
 }
 
 const errorFunctionSelector = "\x08\xc3\x79\xa0" // Error(string)
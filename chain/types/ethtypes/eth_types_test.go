@@ -1,13 +1,16 @@
 package ethtypes
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/big"
@@ -87,6 +90,11 @@ func TestEthBigIntUnmarshalJSON(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, i, tc.Output)
 	}
+
+	// Malformed quantities decode leniently; only call fees are validated, see TestEthCallInvalidFees.
+	var i EthBigInt
+	require.NoError(t, i.UnmarshalJSON([]byte(`"0x"`)))
+	require.EqualValues(t, 0, i.Int64())
 }
 
 func TestEthHash(t *testing.T) {
@@ -162,6 +170,29 @@ func TestEthSubscriptionID(t *testing.T) {
 	}
 }
 
+func TestEthSubscribeParamsFilterSpec(t *testing.T) {
+	var params EthSubscribeParams
+	require.NoError(t, json.Unmarshal([]byte(`["logs",{"address":"0xd4c5fb16488aa48081296299d54b0c648c9333da","topics":[null,["0x013dbb9442ca9667baccc6230fcd5c1c4b2d4d2870f4bd20681d4d47cfd15184"]],"eventSignature":"Transfer(address,address,uint256)","minTopics":"0x3","excludeTopics":[["0xab8653edf9f51785664a643b47605a7ba3d917b5339a0724e7642c114d0e4738"]]}]`), &params))
+	require.Equal(t, "logs", params.EventType)
+
+	spec := params.Params.FilterSpec()
+	require.Nil(t, spec.FromBlock)
+	require.Nil(t, spec.ToBlock)
+	require.Nil(t, spec.BlockHash)
+	require.Equal(t, params.Params.Address, spec.Address)
+	require.Len(t, spec.Address, 1)
+	require.Equal(t, params.Params.Topics, spec.Topics)
+	require.Len(t, spec.Topics, 2)
+	require.Nil(t, spec.Topics[0])
+	require.Equal(t, "Transfer(address,address,uint256)", spec.EventSignature)
+	require.Equal(t, EthUint64(3), spec.MinTopics)
+	require.Equal(t, params.Params.ExcludeTopics, spec.ExcludeTopics)
+
+	// subscribing to logs without params matches all of them
+	var none *EthSubscriptionParams
+	require.Equal(t, &EthFilterSpec{}, none.FilterSpec())
+}
+
 func TestEthAddr(t *testing.T) {
 	testcases := []string{
 		strings.ToLower(`"0xd4c5fb16488Aa48081296299d54b0c648C9333dA"`),
@@ -176,6 +207,14 @@ func TestEthAddr(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, a.String(), strings.Replace(addr, `"`, "", -1))
 	}
+
+	// Checksummed addresses decode to the same value as their lowercase form, which is always
+	// what's returned.
+	var checksummed, lower EthAddress
+	require.NoError(t, checksummed.UnmarshalJSON([]byte(`"0xd4c5fb16488Aa48081296299d54b0c648C9333dA"`)))
+	require.NoError(t, lower.UnmarshalJSON([]byte(`"0xd4c5fb16488aa48081296299d54b0c648c9333da"`)))
+	require.Equal(t, lower, checksummed)
+	require.Equal(t, "0xd4c5fb16488aa48081296299d54b0c648c9333da", checksummed.String())
 }
 
 func TestParseEthAddr(t *testing.T) {
@@ -247,6 +286,138 @@ func TestUnmarshalEthCallInputAndDataEmpty(t *testing.T) {
 	require.EqualValues(t, []byte{}, c.Data)
 }
 
+func TestUnmarshalEthCallFeeParams(t *testing.T) {
+	var c EthCall
+	err := c.UnmarshalJSON([]byte(`{"to":"0x0000000000000000000000000000000000000001","maxFeePerGas":"0x3b9aca00","maxPriorityFeePerGas":"0x64"}`))
+	require.NoError(t, err)
+	require.True(t, c.HasFeeParams())
+	require.EqualValues(t, 1_000_000_000, c.MaxFeePerGas.Int64())
+	require.EqualValues(t, 100, c.MaxPriorityFeePerGas.Int64())
+
+	c = EthCall{}
+	err = c.UnmarshalJSON([]byte(`{"to":"0x0000000000000000000000000000000000000001","gasPrice":"0x64"}`))
+	require.NoError(t, err)
+	require.False(t, c.HasFeeParams())
+}
+
+func TestEthCallCheckType(t *testing.T) {
+	const to = `"to":"0x0000000000000000000000000000000000000001"`
+
+	for _, tc := range []struct {
+		name   string
+		call   string
+		err    string
+		feeCap int64 // -1 for none
+	}{
+		{name: "untyped", call: `{` + to + `,"gasPrice":"0x64","maxFeePerGas":"0xc8"}`, feeCap: 200},
+		{name: "legacy", call: `{` + to + `,"type":"0x0","gasPrice":"0x64"}`, feeCap: 100},
+		{name: "legacy without gas price", call: `{` + to + `,"type":"0x0"}`, feeCap: -1},
+		{name: "legacy with 1559 fees", call: `{` + to + `,"type":"0x0","maxFeePerGas":"0xc8"}`, err: "not allowed in legacy transactions"},
+		{name: "legacy with access list", call: `{` + to + `,"type":"0x0","accessList":[]}`, err: "accessList is not allowed"},
+		{name: "2930", call: `{` + to + `,"type":"0x1","gasPrice":"0x64","accessList":[{"address":"0x0000000000000000000000000000000000000002","storageKeys":[]}]}`, feeCap: 100},
+		{name: "2930 with 1559 fees", call: `{` + to + `,"type":"0x1","maxPriorityFeePerGas":"0x1"}`, err: "not allowed in EIP-2930 transactions"},
+		{name: "1559", call: `{` + to + `,"type":"0x2","maxFeePerGas":"0xc8","accessList":[]}`, feeCap: 200},
+		{name: "1559 with zero gas price", call: `{` + to + `,"type":"0x2","gasPrice":"0x0","maxFeePerGas":"0xc8"}`, feeCap: 200},
+		{name: "1559 with gas price", call: `{` + to + `,"type":"0x2","gasPrice":"0x64"}`, err: "gasPrice is not allowed in EIP-1559 transactions"},
+		{name: "unsupported", call: `{` + to + `,"type":"0x4"}`, err: "unsupported transaction type 0x4"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c EthCall
+			require.NoError(t, json.Unmarshal([]byte(tc.call), &c))
+
+			err := c.CheckType()
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			feeCap, _ := c.FeeParams()
+			if tc.feeCap < 0 {
+				require.Nil(t, feeCap)
+			} else {
+				require.EqualValues(t, tc.feeCap, feeCap.Int64())
+			}
+		})
+	}
+}
+
+func TestEthCallInvalidFees(t *testing.T) {
+	const to = `"to":"0x0000000000000000000000000000000000000001"`
+
+	for _, tc := range []struct {
+		name string
+		call string
+		err  string
+	}{
+		{name: "negative gas price", call: `{` + to + `,"gasPrice":"-0x64"}`, err: "invalid gasPrice: must not be negative"},
+		{name: "non-hex gas price", call: `{` + to + `,"gasPrice":"0x6g"}`, err: `invalid gasPrice: invalid hex quantity "0x6g"`},
+		{name: "non-string gas price", call: `{` + to + `,"gasPrice":100}`, err: "invalid gasPrice"},
+		{name: "negative max fee", call: `{` + to + `,"maxFeePerGas":"-0x1"}`, err: "invalid maxFeePerGas: must not be negative"},
+		{name: "non-hex priority fee", call: `{` + to + `,"maxPriorityFeePerGas":"nope"}`, err: "invalid maxPriorityFeePerGas"},
+		{name: "double prefix", call: `{` + to + `,"gasPrice":"0x0x1"}`, err: `invalid gasPrice: invalid hex quantity "0x0x1"`},
+		{name: "missing prefix", call: `{` + to + `,"gasPrice":"1"}`, err: `invalid gasPrice: invalid hex quantity "1"`},
+		{name: "signed", call: `{` + to + `,"gasPrice":"+1"}`, err: `invalid gasPrice: invalid hex quantity "+1"`},
+		{name: "signed after prefix", call: `{` + to + `,"gasPrice":"0x+1"}`, err: `invalid gasPrice: invalid hex quantity "0x+1"`},
+		{name: "no digits", call: `{` + to + `,"maxFeePerGas":"0x"}`, err: `invalid maxFeePerGas: invalid hex quantity "0x"`},
+		{name: "empty", call: `{` + to + `,"maxFeePerGas":""}`, err: `invalid maxFeePerGas: invalid hex quantity ""`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c EthCall
+			require.ErrorContains(t, json.Unmarshal([]byte(tc.call), &c), tc.err)
+		})
+	}
+
+	var c EthCall
+	require.NoError(t, json.Unmarshal([]byte(`{`+to+`,"gasPrice":"0x64","maxFeePerGas":null}`), &c))
+	require.EqualValues(t, 100, c.GasPrice.Int64())
+	require.Nil(t, c.MaxFeePerGas)
+}
+
+func TestEthCallParams(t *testing.T) {
+	to, err := ParseEthAddress("0xFe01CC39f5Ae8553D6914DBb9dC27D219fa22D7f")
+	require.NoError(t, err)
+	sender, err := ParseEthAddress("0x4D6D86b31a112a05A473c4aE84afaF873f632325")
+	require.NoError(t, err)
+
+	var p EthCallParams
+	require.NoError(t, json.Unmarshal([]byte(`[{"to":"0xFe01CC39f5Ae8553D6914DBb9dC27D219fa22D7f"}]`), &p))
+	require.Equal(t, &to, p.Tx.To)
+	require.Nil(t, p.BlkParam)
+	require.Nil(t, p.StateOverrides)
+
+	p = EthCallParams{}
+	require.NoError(t, json.Unmarshal([]byte(`[{"to":"0xFe01CC39f5Ae8553D6914DBb9dC27D219fa22D7f"},"latest",{"0x4D6D86b31a112a05A473c4aE84afaF873f632325":{"nonce":"0x5","balance":"0x64"}}]`), &p))
+	require.Equal(t, "latest", p.BlkParam.String())
+	require.Len(t, p.StateOverrides, 1)
+	require.EqualValues(t, 5, *p.StateOverrides[sender].Nonce)
+	require.EqualValues(t, 100, p.StateOverrides[sender].Balance.Int64())
+
+	require.Error(t, json.Unmarshal([]byte(`[]`), &p))
+	require.Error(t, json.Unmarshal([]byte(`[{},"latest",{},{},{}]`), &p))
+
+	// round trip, filling in the block param when state overrides are set
+	nonce := EthUint64(7)
+	b, err := json.Marshal(EthCallParams{Tx: EthCall{To: &to}, StateOverrides: EthStateOverrides{sender: {Nonce: &nonce}}})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"from":null,"to":"0xfe01cc39f5ae8553d6914dbb9dc27d219fa22d7f","gas":"0x0","gasPrice":"0x0","value":"0x0","data":"0x"},"latest",{"0x4d6d86b31a112a05a473c4ae84afaf873f632325":{"nonce":"0x7"}}]`, string(b))
+
+	var decoded EthCallParams
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.EqualValues(t, 7, *decoded.StateOverrides[sender].Nonce)
+
+	// block overrides come after the state overrides, which are filled in
+	baseFee := EthBigInt(big.NewInt(100))
+	b, err = json.Marshal(EthCallParams{Tx: EthCall{To: &to}, BlockOverrides: &EthBlockOverrides{BaseFeePerGas: &baseFee}})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"from":null,"to":"0xfe01cc39f5ae8553d6914dbb9dc27d219fa22d7f","gas":"0x0","gasPrice":"0x0","value":"0x0","data":"0x"},"latest",null,{"baseFeePerGas":"0x64"}]`, string(b))
+
+	decoded = EthCallParams{}
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Nil(t, decoded.StateOverrides)
+	require.EqualValues(t, 100, decoded.BlockOverrides.BaseFeePerGas.Int64())
+}
+
 func TestUnmarshalEthBytes(t *testing.T) {
 	testcases := []string{
 		`"0x00"`,
@@ -319,6 +490,20 @@ func TestEthFilterResultMarshalJSON(t *testing.T) {
 			},
 			want: `[` + string(logjson) + `]`,
 		},
+
+		{
+			res: EthFilterResult{
+				ByTransaction: map[EthHash][]EthLog{},
+			},
+			want: "{}",
+		},
+
+		{
+			res: EthFilterResult{
+				ByTransaction: map[EthHash][]EthLog{hash1: {log}},
+			},
+			want: `{"0x013dbb9442ca9667baccc6230fcd5c1c4b2d4d2870f4bd20681d4d47cfd15184":[` + string(logjson) + `]}`,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -330,6 +515,23 @@ func TestEthFilterResultMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestEthFilterResultUnmarshalJSONByTransaction(t *testing.T) {
+	hash, err := ParseEthHash("013dbb9442ca9667baccc6230fcd5c1c4b2d4d2870f4bd20681d4d47cfd15184")
+	require.NoError(t, err, "eth hash")
+
+	want := EthFilterResult{
+		ByTransaction: map[EthHash][]EthLog{
+			hash: {{TransactionHash: hash, LogIndex: 1, Topics: []EthHash{hash}, Data: EthBytes{1}}},
+		},
+	}
+	data, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	var got EthFilterResult
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, want, got)
+}
+
 func TestEthFilterSpecUnmarshalJSON(t *testing.T) {
 	hash1, err := ParseEthHash("013dbb9442ca9667baccc6230fcd5c1c4b2d4d2870f4bd20681d4d47cfd15184")
 	require.NoError(t, err, "eth hash")
@@ -402,6 +604,23 @@ func TestEthFilterSpecUnmarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `{"eventSignature":"Transfer(address,address,uint256)"}`,
+			want:  EthFilterSpec{EventSignature: "Transfer(address,address,uint256)"},
+		},
+		{
+			input: `{"minTopics":"0x2"}`,
+			want:  EthFilterSpec{MinTopics: 2},
+		},
+		{
+			input: `{"excludeTopics":[null,"0xab8653edf9f51785664a643b47605a7ba3d917b5339a0724e7642c114d0e4738"]}`,
+			want: EthFilterSpec{
+				ExcludeTopics: EthTopicSpec{
+					nil,
+					{hash2},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -648,3 +867,145 @@ func BenchmarkEthHashFromCid(b *testing.B) {
 		}
 	}
 }
+
+// largeCalldataSize is the calldata size used to check that eth_call doesn't copy calldata more
+// than needed.
+const largeCalldataSize = 1 << 20
+
+func TestEthBlockDifficulty(t *testing.T) {
+	for _, number := range []EthUint64{0, 1, 1000} {
+		blk := NewEthBlock(number > 0, 2)
+		blk.Number = number
+
+		b, err := json.Marshal(blk)
+		require.NoError(t, err)
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &fields))
+
+		// The fields are always present, and don't depend on the block.
+		require.Equal(t, "0x0", fields["difficulty"])
+		require.Equal(t, "0x0", fields["totalDifficulty"])
+	}
+}
+
+func TestEthCallToFilecoinMessageLargeCalldata(t *testing.T) {
+	to := EthAddress{0xff, 0x00}
+	call := EthCall{To: &to, Data: make([]byte, largeCalldataSize)}
+	for i := range call.Data {
+		call.Data[i] = byte(i)
+	}
+
+	msg, err := call.ToFilecoinMessage()
+	require.NoError(t, err)
+	data, err := cbg.ReadByteArray(bytes.NewReader(msg.Params), uint64(len(msg.Params)))
+	require.NoError(t, err)
+	require.Equal(t, []byte(call.Data), data)
+
+	// The params are encoded into a single buffer sized upfront, rather than into a buffer doubling
+	// in size as it grows. The bytes allocated per conversion are measured the way
+	// testing.AllocsPerRun counts allocations.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	_, _ = call.ToFilecoinMessage()
+	const runs = 10
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		_, _ = call.ToFilecoinMessage()
+	}
+	runtime.ReadMemStats(&after)
+	require.Less(t, (after.TotalAlloc-before.TotalAlloc)/runs, uint64(2*largeCalldataSize))
+}
+
+func TestEthTraceCallJSON(t *testing.T) {
+	to := EthAddress{0xff, 0x01}
+	var params EthTraceCallParams
+	require.NoError(t, json.Unmarshal([]byte(`[{"to":"0xff01000000000000000000000000000000000000"},"latest",{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true}}]`), &params))
+	require.Equal(t, &to, params.Tx.To)
+	require.Equal(t, EthCallTracer, params.Config.Tracer)
+	require.True(t, params.Config.TracerConfig.OnlyTopCall)
+
+	// Call frames and prestates are told apart when decoding results.
+	frame := EthTraceCallResult{CallFrame: &EthCallFrame{
+		Type:  "CALL",
+		To:    &to,
+		Value: EthBigIntZero,
+		Input: EthBytes{1},
+		Calls: []*EthCallFrame{{Type: "STATICCALL", Value: EthBigIntZero, Input: EthBytes{}}},
+	}}
+	b, err := json.Marshal(frame)
+	require.NoError(t, err)
+	var decoded EthTraceCallResult
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Nil(t, decoded.Prestate)
+	require.Equal(t, "CALL", decoded.CallFrame.Type)
+	require.Equal(t, &to, decoded.CallFrame.To)
+	require.Len(t, decoded.CallFrame.Calls, 1)
+	require.Equal(t, "STATICCALL", decoded.CallFrame.Calls[0].Type)
+
+	prestate := EthTraceCallResult{Prestate: EthPrestate{to: {Balance: EthBigIntZero, Nonce: 1, Code: EthBytes{0xfe}}}}
+	b, err = json.Marshal(prestate)
+	require.NoError(t, err)
+	decoded = EthTraceCallResult{}
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Nil(t, decoded.CallFrame)
+	require.Len(t, decoded.Prestate, 1)
+	require.Equal(t, EthUint64(1), decoded.Prestate[to].Nonce)
+	require.Equal(t, EthBytes{0xfe}, decoded.Prestate[to].Code)
+}
+
+func TestEthTraceBlockParamsJSON(t *testing.T) {
+	var params EthTraceBlockParams
+	require.NoError(t, json.Unmarshal([]byte(`["0x10",{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true},"evmOnly":true}]`), &params))
+	require.Equal(t, "0x10", params.Block)
+	require.Equal(t, EthCallTracer, params.Config.Tracer)
+	require.True(t, params.Config.TracerConfig.OnlyTopCall)
+	require.True(t, params.Config.EVMOnly)
+
+	// The config is optional.
+	params = EthTraceBlockParams{}
+	require.NoError(t, json.Unmarshal([]byte(`["latest"]`), &params))
+	require.Equal(t, "latest", params.Block)
+	require.Empty(t, params.Config.Tracer)
+
+	require.ErrorContains(t, json.Unmarshal([]byte(`[]`), &params), "expected 1 or 2 params")
+}
+
+func TestEthCallManyParamsOptionsJSON(t *testing.T) {
+	to := EthAddress{0x01}
+	params := EthCallManyParams{
+		Calls:   []EthCall{{To: &to}},
+		Options: EthCallManyOptions{TraceReverts: true},
+	}
+
+	// The block and the state overrides come before the options, so they're filled in.
+	b, err := json.Marshal(params)
+	require.NoError(t, err)
+	var positional []json.RawMessage
+	require.NoError(t, json.Unmarshal(b, &positional))
+	require.Len(t, positional, 4)
+	require.JSONEq(t, `"latest"`, string(positional[1]))
+	require.JSONEq(t, `null`, string(positional[2]))
+	require.JSONEq(t, `{"traceReverts":true}`, string(positional[3]))
+
+	var decoded EthCallManyParams
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.True(t, decoded.Options.TraceReverts)
+	require.Nil(t, decoded.StateOverrides)
+
+	// Without options, they're left out.
+	b, err = json.Marshal(EthCallManyParams{Calls: params.Calls})
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "traceReverts")
+}
+
+func BenchmarkEthCallToFilecoinMessageLargeCalldata(b *testing.B) {
+	to := EthAddress{0xff, 0x00}
+	call := EthCall{To: &to, Data: make([]byte, largeCalldataSize)}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := call.ToFilecoinMessage(); err != nil {
+			b.Fatalf("Error in ToFilecoinMessage: %v", err)
+		}
+	}
+}
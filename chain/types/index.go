@@ -21,3 +21,19 @@ type IndexValidation struct {
 	// IsNullRound indicates if the epoch corresponds to a null round and therefore does not have any indexed messages or events.
 	IsNullRound bool
 }
+
+// IndexBackfill reports the progress of a backfill of the chain index over a range of epochs.
+type IndexBackfill struct {
+	// From is the first epoch of the range being backfilled.
+	From abi.ChainEpoch
+	// To is the last epoch of the range being backfilled.
+	To abi.ChainEpoch
+	// Epoch is the next epoch to be processed, or To+1 once the whole range has been processed.
+	Epoch abi.ChainEpoch
+	// BackfilledTipSets is the number of tipsets that were missing from the index and have been backfilled so far.
+	BackfilledTipSets uint64
+	// Done denotes whether the backfill has stopped, either because it processed the whole range or because it failed.
+	Done bool
+	// Error is the error the backfill stopped on, if it failed.
+	Error string
+}
@@ -263,6 +263,11 @@ func defaultFVMOpts(ctx context.Context, opts *VMOpts) (*ffi.FVMOpts, error) {
 		return nil, xerrors.Errorf("calculating circ supply: %w", err)
 	}
 
+	chainID := uint64(buildconstants.Eip155ChainId)
+	if opts.ChainID != nil {
+		chainID = *opts.ChainID
+	}
+
 	return &ffi.FVMOpts{
 		FVMVersion: 0,
 		Externs: &FvmExtern{
@@ -275,7 +280,7 @@ func defaultFVMOpts(ctx context.Context, opts *VMOpts) (*ffi.FVMOpts, error) {
 		},
 		Epoch:          opts.Epoch,
 		Timestamp:      opts.Timestamp,
-		ChainID:        buildconstants.Eip155ChainId,
+		ChainID:        chainID,
 		BaseFee:        opts.BaseFee,
 		BaseCircSupply: circToReport,
 		NetworkVersion: opts.NetworkVersion,
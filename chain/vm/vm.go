@@ -249,6 +249,9 @@ type VMOpts struct {
 	LookbackState  LookbackStateGetter
 	TipSetGetter   TipSetGetter
 	Tracing        bool
+	// ChainID, if set, replaces the EIP-155 chain id of the network, as reported by the EVM's
+	// CHAINID opcode.
+	ChainID *uint64
 	// ReturnEvents decodes and returns emitted events.
 	ReturnEvents bool
 	// ExecutionLane specifies the execution priority of the created vm
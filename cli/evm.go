@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -126,11 +127,20 @@ var EvmCallSimulateCmd = &cli.Command{
 		defer closer()
 		ctx := ReqContext(cctx)
 
-		res, err := api.EthCall(ctx, ethtypes.EthCall{
-			From: &fromEthAddr,
-			To:   &toEthAddr,
-			Data: params,
-		}, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+		blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined("latest")
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				From: &fromEthAddr,
+				To:   &toEthAddr,
+				Data: params,
+			},
+			BlkParam: &blkParam,
+		})
+		if err != nil {
+			return err
+		}
+
+		res, err := api.EthCall(ctx, callParams)
 		if err != nil {
 			fmt.Println("Eth call fails, return val: ", res)
 			return err
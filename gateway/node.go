@@ -16,6 +16,7 @@ import (
 	"github.com/filecoin-project/lotus/api/v2api"
 	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 	_ "github.com/filecoin-project/lotus/lib/sigs/bls"
 	_ "github.com/filecoin-project/lotus/lib/sigs/delegated"
 	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
@@ -35,6 +36,10 @@ const (
 	chainRateLimitTokens  = 2
 	stateRateLimitTokens  = 3
 
+	// Building the state of the pending block applies messages of the message pool, up to a tenth
+	// of a block's gas limit, for every request at it, which is charged as this many state lookups.
+	pendingStateRateLimitCalls = 10
+
 	MaxRateLimitTokens = stateRateLimitTokens // Number of tokens consumed for the most expensive types of operations
 )
 
@@ -245,3 +250,17 @@ func (gw *Node) limit(ctx context.Context, tokens int) error {
 	}
 	return nil
 }
+
+// limitPending charges for building the state of the pending block when blkParam selects it, on
+// top of what the request is charged otherwise.
+func (gw *Node) limitPending(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash) error {
+	if blkParam.PredefinedBlock == nil || *blkParam.PredefinedBlock != ethtypes.BlockTagPending {
+		return nil
+	}
+	for i := 0; i < pendingStateRateLimitCalls; i++ {
+		if err := gw.limit(ctx, stateRateLimitTokens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -266,6 +266,16 @@ func (pv1 *reverseProxyV1) EthGetTransactionReceipt(ctx context.Context, txHash
 	return pv1.server.EthGetTransactionReceiptLimited(ctx, txHash, pv1.gateway.maxMessageLookbackEpochs)
 }
 
+func (pv1 *reverseProxyV1) EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	// Each receipt costs as much as a single lookup.
+	for range txHashes {
+		if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+	return pv1.server.EthGetTransactionReceiptsLimited(ctx, txHashes, pv1.gateway.maxMessageLookbackEpochs)
+}
+
 func (pv1 *reverseProxyV1) EthGetCode(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
 	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
@@ -298,10 +308,34 @@ func (pv1 *reverseProxyV1) EthGetBalance(ctx context.Context, address ethtypes.E
 	if err := pv1.checkEthBlockParam(ctx, blkParam, 0); err != nil {
 		return ethtypes.EthBigInt(big.Zero()), err
 	}
+	if err := pv1.gateway.limitPending(ctx, blkParam); err != nil {
+		return ethtypes.EthBigInt(big.Zero()), err
+	}
 
 	return pv1.server.EthGetBalance(ctx, address, blkParam)
 }
 
+var EthGetProofMaxStorageKeys = 1000 // same as the default cap on the addresses of a filter
+
+func (pv1 *reverseProxyV1) EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	if len(storageKeys) > EthGetProofMaxStorageKeys {
+		return nil, xerrors.Errorf("too many storage keys: %d > %d", len(storageKeys), EthGetProofMaxStorageKeys)
+	}
+
+	// The account proof and the proof of each storage key cost as much as a single lookup.
+	for i := 0; i <= len(storageKeys); i++ {
+		if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pv1.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthGetProof(ctx, address, storageKeys, blkParam)
+}
+
 func (pv1 *reverseProxyV1) EthChainId(ctx context.Context) (ethtypes.EthUint64, error) {
 	if err := pv1.gateway.limit(ctx, basicRateLimitTokens); err != nil {
 		return 0, err
@@ -396,17 +430,101 @@ func (pv1 *reverseProxyV1) EthEstimateGas(ctx context.Context, jparams jsonrpc.R
 	return pv1.server.EthEstimateGas(ctx, jparams)
 }
 
-func (pv1 *reverseProxyV1) EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (pv1 *reverseProxyV1) EthCall(ctx context.Context, jparams jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthCallParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
 	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
 	}
 
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
 	if err := pv1.checkEthBlockParam(ctx, blkParam, 0); err != nil {
 		return nil, err
 	}
+	if err := pv1.gateway.limitPending(ctx, blkParam); err != nil {
+		return nil, err
+	}
 
 	// todo limit gas? to what?
-	return pv1.server.EthCall(ctx, tx, blkParam)
+	return pv1.server.EthCall(ctx, jparams)
+}
+
+func (pv1 *reverseProxyV1) EthCallDetailed(ctx context.Context, jparams jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthCallParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	if err := pv1.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+	if err := pv1.gateway.limitPending(ctx, blkParam); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthCallDetailed(ctx, jparams)
+}
+
+func (pv1 *reverseProxyV1) EthTraceCall(ctx context.Context, jparams jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthTraceCallParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	if err := pv1.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+	if err := pv1.gateway.limitPending(ctx, blkParam); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthTraceCall(ctx, jparams)
+}
+
+func (pv1 *reverseProxyV1) EthCallMany(ctx context.Context, jparams jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthCallManyParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	// Each call of the sequence is executed, so charge for every one of them.
+	for range params.Calls {
+		if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	if err := pv1.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthCallMany(ctx, jparams)
 }
 
 func (pv1 *reverseProxyV1) EthSendRawTransaction(ctx context.Context, rawTx ethtypes.EthBytes) (ethtypes.EthHash, error) {
@@ -442,6 +560,30 @@ func (pv1 *reverseProxyV1) EthGetLogs(ctx context.Context, filter *ethtypes.EthF
 	return pv1.server.EthGetLogs(ctx, filter)
 }
 
+func (pv1 *reverseProxyV1) EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return 0, err
+	}
+
+	if filter.FromBlock != nil {
+		if err := pv1.checkBlkParam(ctx, *filter.FromBlock, 0); err != nil {
+			return 0, err
+		}
+	}
+	if filter.ToBlock != nil {
+		if err := pv1.checkBlkParam(ctx, *filter.ToBlock, 0); err != nil {
+			return 0, err
+		}
+	}
+	if filter.BlockHash != nil {
+		if err := pv1.checkBlkHash(ctx, *filter.BlockHash); err != nil {
+			return 0, err
+		}
+	}
+
+	return pv1.server.EthEstimateLogsCount(ctx, filter)
+}
+
 func (pv1 *reverseProxyV1) EthGetFilterChanges(ctx context.Context, id ethtypes.EthFilterID) (*ethtypes.EthFilterResult, error) {
 	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
@@ -665,6 +807,52 @@ func (pv1 *reverseProxyV1) EthTraceTransaction(ctx context.Context, txHash strin
 	return pv1.server.EthTraceTransaction(ctx, txHash)
 }
 
+func (pv1 *reverseProxyV1) EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthReplayTransaction(ctx, txHash)
+}
+
+func (pv1 *reverseProxyV1) EthTraceBlockByNumber(ctx context.Context, jparams jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthTraceBlockParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	if err := pv1.checkBlkParam(ctx, params.Block, 0); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthTraceBlockByNumber(ctx, jparams)
+}
+
+func (pv1 *reverseProxyV1) EthTraceBlockByHash(ctx context.Context, jparams jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthTraceBlockParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	blkHash, err := ethtypes.ParseEthHash(params.Block)
+	if err != nil {
+		return nil, xerrors.Errorf("parsing block hash: %w", err)
+	}
+	if err := pv1.checkBlkHash(ctx, blkHash); err != nil {
+		return nil, err
+	}
+
+	return pv1.server.EthTraceBlockByHash(ctx, jparams)
+}
+
 func (pv1 *reverseProxyV1) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	if err := pv1.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
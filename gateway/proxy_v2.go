@@ -56,6 +56,12 @@ func (pv2 *reverseProxyV2) EthAddressToFilecoinAddress(ctx context.Context, ethA
 	return pv2.server.EthAddressToFilecoinAddress(ctx, ethAddress)
 }
 
+// EthAddressToRobustFilecoinAddress isn't served: resolving actors other than accounts searches the
+// whole address map of the init actor.
+func (pv2 *reverseProxyV2) EthAddressToRobustFilecoinAddress(context.Context, ethtypes.EthAddress) (address.Address, error) {
+	return address.Undef, xerrors.New("EthAddressToRobustFilecoinAddress not supported by the gateway")
+}
+
 func (pv2 *reverseProxyV2) FilecoinAddressToEthAddress(ctx context.Context, params jsonrpc.RawParams) (ethtypes.EthAddress, error) {
 	_, err := jsonrpc.DecodeParams[ethtypes.FilecoinAddressToEthAddressParams](params)
 	if err != nil {
@@ -269,6 +275,26 @@ func (pv2 *reverseProxyV2) EthGetTransactionReceiptLimited(ctx context.Context,
 	return pv2.server.EthGetTransactionReceiptLimited(ctx, txHash, limit)
 }
 
+func (pv2 *reverseProxyV2) EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	// Each receipt costs as much as a single lookup.
+	for range txHashes {
+		if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+	return pv2.server.EthGetTransactionReceiptsLimited(ctx, txHashes, pv2.gateway.maxMessageLookbackEpochs)
+}
+
+func (pv2 *reverseProxyV2) EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	// Each receipt costs as much as a single lookup.
+	for range txHashes {
+		if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+	return pv2.server.EthGetTransactionReceiptsLimited(ctx, txHashes, limit)
+}
+
 func (pv2 *reverseProxyV2) EthGetBlockReceipts(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash) ([]*ethtypes.EthTxReceipt, error) {
 	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
@@ -315,10 +341,32 @@ func (pv2 *reverseProxyV2) EthGetBalance(ctx context.Context, address ethtypes.E
 	if err := pv2.checkEthBlockParam(ctx, blkParam, 0); err != nil {
 		return ethtypes.EthBigInt(big.Zero()), err
 	}
+	if err := pv2.gateway.limitPending(ctx, blkParam); err != nil {
+		return ethtypes.EthBigInt(big.Zero()), err
+	}
 
 	return pv2.server.EthGetBalance(ctx, address, blkParam)
 }
 
+func (pv2 *reverseProxyV2) EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	if len(storageKeys) > EthGetProofMaxStorageKeys {
+		return nil, xerrors.Errorf("too many storage keys: %d > %d", len(storageKeys), EthGetProofMaxStorageKeys)
+	}
+
+	// The account proof and the proof of each storage key cost as much as a single lookup.
+	for i := 0; i <= len(storageKeys); i++ {
+		if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pv2.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthGetProof(ctx, address, storageKeys, blkParam)
+}
+
 func (pv2 *reverseProxyV2) EthTraceBlock(ctx context.Context, blkNum string) ([]*ethtypes.EthTraceBlock, error) {
 	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
@@ -351,6 +399,52 @@ func (pv2 *reverseProxyV2) EthTraceTransaction(ctx context.Context, txHash strin
 	return pv2.server.EthTraceTransaction(ctx, txHash)
 }
 
+func (pv2 *reverseProxyV2) EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthReplayTransaction(ctx, txHash)
+}
+
+func (pv2 *reverseProxyV2) EthTraceBlockByNumber(ctx context.Context, jparams jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthTraceBlockParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	if err := pv2.checkBlkParam(ctx, params.Block, 0); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthTraceBlockByNumber(ctx, jparams)
+}
+
+func (pv2 *reverseProxyV2) EthTraceBlockByHash(ctx context.Context, jparams jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthTraceBlockParams](jparams)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	blkHash, err := ethtypes.ParseEthHash(params.Block)
+	if err != nil {
+		return nil, xerrors.Errorf("parsing block hash: %w", err)
+	}
+	if err := pv2.checkBlkHash(ctx, blkHash); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthTraceBlockByHash(ctx, jparams)
+}
+
 func (pv2 *reverseProxyV2) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
@@ -423,17 +517,106 @@ func (pv2 *reverseProxyV2) EthEstimateGas(ctx context.Context, p jsonrpc.RawPara
 	return pv2.server.EthEstimateGas(ctx, p)
 }
 
-func (pv2 *reverseProxyV2) EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (pv2 *reverseProxyV2) EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthCallParams](p)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
 	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return nil, err
 	}
 
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
 	if err := pv2.checkEthBlockParam(ctx, blkParam, 0); err != nil {
 		return nil, err
 	}
+	if err := pv2.gateway.limitPending(ctx, blkParam); err != nil {
+		return nil, err
+	}
 
 	// todo limit gas? to what?
-	return pv2.server.EthCall(ctx, tx, blkParam)
+	return pv2.server.EthCall(ctx, p)
+}
+
+func (pv2 *reverseProxyV2) EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthCallParams](p)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	if err := pv2.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+	if err := pv2.gateway.limitPending(ctx, blkParam); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthCallDetailed(ctx, p)
+}
+
+func (pv2 *reverseProxyV2) EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthTraceCallParams](p)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return nil, err
+	}
+
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	if err := pv2.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+	if err := pv2.gateway.limitPending(ctx, blkParam); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthTraceCall(ctx, p)
+}
+
+func (pv2 *reverseProxyV2) EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	params, err := jsonrpc.DecodeParams[ethtypes.EthCallManyParams](p)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding params: %w", err)
+	}
+
+	// Each call of the sequence is executed, so charge for every one of them.
+	for range params.Calls {
+		if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if params.BlkParam != nil {
+		blkParam = *params.BlkParam
+	}
+	if err := pv2.checkEthBlockParam(ctx, blkParam, 0); err != nil {
+		return nil, err
+	}
+
+	return pv2.server.EthCallMany(ctx, p)
+}
+
+// EthCallAtStateRoot isn't served: a state root can't be checked against the lookback limit.
+func (pv2 *reverseProxyV2) EthCallAtStateRoot(context.Context, jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	return nil, xerrors.New("EthCallAtStateRoot not supported by the gateway")
 }
 
 func (pv2 *reverseProxyV2) EthGetLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error) {
@@ -460,6 +643,35 @@ func (pv2 *reverseProxyV2) EthGetLogs(ctx context.Context, filter *ethtypes.EthF
 	return pv2.server.EthGetLogs(ctx, filter)
 }
 
+func (pv2 *reverseProxyV2) EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
+		return 0, err
+	}
+
+	if filter.FromBlock != nil {
+		if err := pv2.checkBlkParam(ctx, *filter.FromBlock, 0); err != nil {
+			return 0, err
+		}
+	}
+	if filter.ToBlock != nil {
+		if err := pv2.checkBlkParam(ctx, *filter.ToBlock, 0); err != nil {
+			return 0, err
+		}
+	}
+	if filter.BlockHash != nil {
+		if err := pv2.checkBlkHash(ctx, *filter.BlockHash); err != nil {
+			return 0, err
+		}
+	}
+
+	return pv2.server.EthEstimateLogsCount(ctx, filter)
+}
+
+// EthExplainLogs isn't served: it is an admin method for node operators.
+func (pv2 *reverseProxyV2) EthExplainLogs(context.Context, *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	return nil, xerrors.New("EthExplainLogs not supported by the gateway")
+}
+
 func (pv2 *reverseProxyV2) EthNewBlockFilter(ctx context.Context) (ethtypes.EthFilterID, error) {
 	if err := pv2.gateway.limit(ctx, stateRateLimitTokens); err != nil {
 		return ethtypes.EthFilterID{}, err
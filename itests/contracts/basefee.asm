@@ -0,0 +1,20 @@
+# Reverts unless executed with a non-zero base fee. Used to check that gas
+# estimation executes calls against the real base fee when fees are specified.
+#
+# init code: copy the 10 byte runtime below into memory and return it
+push1 0x0a
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x0a
+push1 0x00
+return
+# runtime
+basefee
+push1 0x08
+jumpi
+push1 0x00
+dup1
+revert
+jumpdest
+stop
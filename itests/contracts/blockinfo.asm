@@ -0,0 +1,23 @@
+# Returns the block number, timestamp and PREVRANDAO, as three 32 byte words.
+#
+# init code: copy the 17 byte runtime below into memory and return it
+push1 0x11
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x11
+push1 0x00
+return
+# runtime
+number
+push1 0x00
+mstore
+timestamp
+push1 0x20
+mstore
+prevrandao
+push1 0x40
+mstore
+push1 0x60
+push1 0x00
+return
@@ -0,0 +1,34 @@
+# Calls the contract whose address is passed as the only argument and, if that
+# call fails, reverts with the same revert data, as Solidity does for failed
+# external calls.
+#
+# init code: copy the 30 byte runtime below into memory and return it
+push1 0x1e
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x1e
+push1 0x00
+return
+# runtime: call(gas, calldata[0:32], 0, 0, 0, 0, 0)
+push1 0x00
+push1 0x00
+push1 0x00
+push1 0x00
+push1 0x00
+push1 0x00
+calldataload
+gas
+call
+push1 0x1c
+jumpi
+# bubble up the revert data
+returndatasize
+push1 0x00
+push1 0x00
+returndatacopy
+returndatasize
+push1 0x00
+revert
+jumpdest
+stop
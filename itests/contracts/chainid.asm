@@ -0,0 +1,17 @@
+# Returns the chain id, as a 32 byte word.
+#
+# init code: copy the 8 byte runtime below into memory and return it
+push1 0x08
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x08
+push1 0x00
+return
+# runtime
+chainid
+push1 0x00
+mstore
+push1 0x20
+push1 0x00
+return
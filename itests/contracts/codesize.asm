@@ -0,0 +1,20 @@
+# Returns the size of the code of the account whose address is passed as the first word of the
+# calldata, as read with EXTCODESIZE.
+#
+# init code: copy the 12 byte runtime below into memory and return it
+push1 0x0c
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x0c
+push1 0x00
+return
+# runtime
+push1 0x00
+calldataload
+extcodesize
+push1 0x00
+mstore
+push1 0x20
+push1 0x00
+return
@@ -0,0 +1,34 @@
+# Delegates calls to the implementation whose address is passed as the only
+# argument, returning or reverting with whatever the implementation returned,
+# as proxy contracts do.
+#
+# init code: copy the 31 byte runtime below into memory and return it
+push1 0x1f
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x1f
+push1 0x00
+return
+# runtime: delegatecall(gas, calldata[0:32], 0, 0, 0, 0)
+push1 0x00
+push1 0x00
+push1 0x00
+push1 0x00
+push1 0x00
+calldataload
+gas
+delegatecall
+returndatasize
+push1 0x00
+push1 0x00
+returndatacopy
+push1 0x1a
+jumpi
+returndatasize
+push1 0x00
+revert
+jumpdest
+returndatasize
+push1 0x00
+return
@@ -0,0 +1,14 @@
+# Loops forever, running out of gas whatever the gas limit.
+#
+# init code: copy the 4 byte runtime below into memory and return it
+push1 0x04
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x04
+push1 0x00
+return
+# runtime
+jumpdest
+push1 0x00
+jump
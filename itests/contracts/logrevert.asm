@@ -0,0 +1,17 @@
+# Emits an event and then reverts every call, so the event is never persisted.
+#
+# init code: copy the 10 byte runtime below into memory and return it
+push1 0x0a
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x0a
+push1 0x00
+return
+# runtime: log a zero topic event with no data, then revert without data
+push1 0x00
+push1 0x00
+log0
+push1 0x00
+push1 0x00
+revert
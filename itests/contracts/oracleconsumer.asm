@@ -0,0 +1,37 @@
+# Reads a price from the oracle whose address is passed as the only argument,
+# and returns twice that price. Used to check that state overrides can stub
+# out the contracts a call depends on.
+#
+# init code: copy the 35 byte runtime below into memory and return it
+push1 0x23
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x23
+push1 0x00
+return
+# runtime: staticcall(gas, calldata[0:32], 0, 0, 0, 32)
+push1 0x20
+push1 0x00
+push1 0x00
+push1 0x00
+push1 0x00
+calldataload
+gas
+staticcall
+push1 0x14
+jumpi
+push1 0x00
+dup1
+revert
+jumpdest
+# return mem[0:32] * 2
+push1 0x00
+mload
+push1 0x02
+mul
+push1 0x00
+mstore
+push1 0x20
+push1 0x00
+return
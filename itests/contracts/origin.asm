@@ -0,0 +1,20 @@
+# Returns tx.origin followed by msg.sender, as two 32 byte words.
+#
+# init code: copy the 13 byte runtime below into memory and return it
+push1 0x0d
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x0d
+push1 0x00
+return
+# runtime
+origin
+push1 0x00
+mstore
+caller
+push1 0x20
+mstore
+push1 0x40
+push1 0x00
+return
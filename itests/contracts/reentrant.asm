@@ -0,0 +1,45 @@
+# Calls back into itself once. The outer call sets slot 0 to 1 before reentering, the reentrant
+# call (made with one byte of calldata) copies slot 0 into slot 1, and the outer call then returns
+# slot 1, which is 1 if the reentrant call saw the outer call's write.
+#
+# init code: copy the 42 byte runtime below into memory and return it
+push1 0x2a
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x2a
+push1 0x00
+return
+# runtime
+calldatasize
+push1 0x22 ## reenter
+jumpi
+# outer call: slot 0 = 1, then call self with one byte of calldata
+push1 0x01
+push1 0x00
+sstore
+push1 0x00 ## retSize
+push1 0x00 ## retOffset
+push1 0x01 ## argsSize
+push1 0x00 ## argsOffset
+push1 0x00 ## value
+address
+gas
+call
+pop
+# return slot 1
+push1 0x01
+sload
+push1 0x00
+mstore
+push1 0x20
+push1 0x00
+return
+# reentrant call: slot 1 = slot 0
+reenter:
+jumpdest
+push1 0x00
+sload
+push1 0x01
+sstore
+stop
@@ -0,0 +1,9 @@
+# Init code that reverts with its own address (left-padded to 32 bytes) as the
+# revert data. Used to observe the address a CREATE would deploy to without
+# having to actually deploy anything.
+address
+push1 0x00
+mstore
+push1 0x20
+push1 0x00
+revert
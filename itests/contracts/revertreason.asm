@@ -0,0 +1,23 @@
+# Reverts every call with Error("inner failure").
+#
+# init code: copy the 112 byte runtime below into memory and return it
+push1 0x70
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x70
+push1 0x00
+return
+# runtime: copy the 100 byte revert data that follows into memory and revert with it
+push1 0x64
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x64
+push1 0x00
+revert
+# abi.encodeWithSignature("Error(string)", "inner failure")
+0x08c379a0
+0x0000000000000000000000000000000000000000000000000000000000000020
+0x000000000000000000000000000000000000000000000000000000000000000d
+0x696e6e6572206661696c75726500000000000000000000000000000000000000
@@ -0,0 +1,26 @@
+# Logs an ERC-20 Transfer event, an ERC-20 Approval event and an event without topics, all without
+# data, whatever the calldata.
+#
+# init code: copy the 82 byte runtime below into memory and return it
+push1 0x52
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x52
+push1 0x00
+return
+# runtime
+# Transfer(address,address,uint256)
+push32 0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef
+push1 0x00
+push1 0x00
+log1
+# Approval(address,address,uint256)
+push32 0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925
+push1 0x00
+push1 0x00
+log1
+push1 0x00
+push1 0x00
+log0
+stop
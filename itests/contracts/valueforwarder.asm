@@ -0,0 +1,39 @@
+# Calls the contract whose address is passed as the first argument with the rest
+# of the calldata as input, forwarding the value it received, and returns the
+# first 32 bytes the call returned. Reverts if that call fails.
+#
+# init code: copy the 36 byte runtime below into memory and return it
+push1 0x24
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x24
+push1 0x00
+return
+# runtime: copy calldata[32:] to memory
+push1 0x20
+calldatasize
+sub
+dup1
+push1 0x20
+push1 0x00
+calldatacopy
+# call(gas, calldata[0:32], callvalue, 0, len(calldata) - 32, 0, 32)
+push1 0x20
+push1 0x00
+dup3
+push1 0x00
+callvalue
+push1 0x00
+calldataload
+gas
+call
+push1 0x1e
+jumpi
+push1 0x00
+dup1
+revert
+jumpdest
+push1 0x20
+push1 0x00
+return
@@ -0,0 +1,21 @@
+# Stores the value it received in slot 0 and returns it.
+#
+# init code: copy the 13 byte runtime below into memory and return it
+push1 0x0d
+push1 0x0c
+push1 0x00
+codecopy
+push1 0x0d
+push1 0x00
+return
+# runtime: sstore(0, callvalue)
+callvalue
+dup1
+push1 0x00
+sstore
+# return callvalue
+push1 0x00
+mstore
+push1 0x20
+push1 0x00
+return
@@ -35,7 +35,7 @@ type ethApi interface {
 	EthTraceReplayBlockTransactions(ctx context.Context, blkNum string, traceTypes []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error)
 	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthFeeHistory, error)
 	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error)
-	EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
+	EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error)
 	EthGetBlockTransactionCountByNumber(ctx context.Context, blkNum string) (ethtypes.EthUint64, error)
 	EthGetBlockByNumber(ctx context.Context, blkNum string, fullTxInfo bool) (ethtypes.EthBlock, error)
 	EthGetTransactionByBlockNumberAndIndex(ctx context.Context, blkNum string, txIndex ethtypes.EthUint64) (*ethtypes.EthTx, error)
@@ -674,10 +674,12 @@ func TestEthAPIWithF3(t *testing.T) {
 					Data: kit.CalcFuncSignature("getCounter()"),
 				}
 
+				callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: call, BlkParam: &param})
+				req.NoError(err)
+
 				var ret ethtypes.EthBytes
-				var err error
 				expect := stableExecute(func() {
-					ret, err = subject.EthCall(ctx, call, param)
+					ret, err = subject.EthCall(ctx, callParams)
 				})
 
 				if expectErr != "" {
@@ -313,6 +313,51 @@ func TestFilecoinAddressToEthAddress(t *testing.T) {
 	require.Equal(ethAddr, apiEthAddr)
 }
 
+func TestEthAddressToRobustFilecoinAddress(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// A contract is known by the f2 address the init actor assigned it on creation, both through
+	// its f410 address and its ID address.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractActor, err := client.StateGetActor(ctx, contractAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	require.NotNil(t, contractActor.DelegatedAddress)
+	contractEthAddr, err := ethtypes.EthAddressFromFilecoinAddress(*contractActor.DelegatedAddress)
+	require.NoError(t, err)
+	contractIdEthAddr, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	for _, ethAddr := range []ethtypes.EthAddress{contractEthAddr, contractIdEthAddr} {
+		robustAddr, err := client.EthAddressToRobustFilecoinAddress(ctx, ethAddr)
+		require.NoError(t, err)
+		require.Equal(t, address.Actor, robustAddr.Protocol())
+
+		idAddr, err := client.StateLookupID(ctx, robustAddr, types.EmptyTSK)
+		require.NoError(t, err)
+		require.Equal(t, contractAddr, idAddr)
+	}
+
+	// An account is known by its public key address.
+	secpAddr, err := client.WalletNew(ctx, types.KTSecp256k1)
+	require.NoError(t, err)
+	kit.SendFunds(ctx, t, client, secpAddr, abi.NewTokenAmount(1))
+	secpIdAddr, err := client.StateLookupID(ctx, secpAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	secpEthAddr, err := ethtypes.EthAddressFromFilecoinAddress(secpIdAddr)
+	require.NoError(t, err)
+
+	robustAddr, err := client.EthAddressToRobustFilecoinAddress(ctx, secpEthAddr)
+	require.NoError(t, err)
+	require.Equal(t, secpAddr, robustAddr)
+
+	// Singletons only have an ID address.
+	marketEthAddr, err := ethtypes.EthAddressFromFilecoinAddress(builtin.StorageMarketActorAddr)
+	require.NoError(t, err)
+	_, err = client.EthAddressToRobustFilecoinAddress(ctx, marketEthAddr)
+	require.ErrorContains(t, err, "only has an ID address")
+}
+
 func TestFilecoinAddressToEthAddressFinalised(t *testing.T) {
 	require := require.New(t)
 	blockTime := 5 * time.Millisecond
@@ -435,3 +480,26 @@ func TestEthBlockNumberAliases(t *testing.T) {
 		})
 	}
 }
+
+func TestEthGetBlockBaseFee(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client.WaitTillChain(ctx, kit.HeightAtLeast(10))
+
+	blk, err := client.EVM().EthGetBlockByNumber(ctx, "latest", false)
+	require.NoError(t, err)
+	ts, err := client.ChainGetTipSetByHeight(ctx, abi.ChainEpoch(blk.Number), types.EmptyTSK)
+	require.NoError(t, err)
+	require.EqualValues(t, blk.Number, ts.Height())
+
+	// The messages of a tipset pay the base fee computed from its parent, in attoFIL per unit of gas,
+	// which maps one to one to wei per unit of gas.
+	baseFee := ts.Blocks()[0].ParentBaseFee
+	require.True(t, baseFee.GreaterThan(big.Zero()))
+	require.Equal(t, ethtypes.EthBigInt(baseFee), blk.BaseFeePerGas)
+}
@@ -27,6 +27,14 @@ func TestEthFilterAPIDisabledViaConfig(t *testing.T) {
 	require.NotNil(t, err)
 	require.Equal(t, err.Error(), eth.ErrModuleDisabled.Error())
 
+	_, err = client.EthEstimateLogsCount(ctx, &ethtypes.EthFilterSpec{})
+	require.NotNil(t, err)
+	require.Equal(t, err.Error(), eth.ErrModuleDisabled.Error())
+
+	_, err = client.EthExplainLogs(ctx, &ethtypes.EthFilterSpec{})
+	require.NotNil(t, err)
+	require.Equal(t, err.Error(), eth.ErrModuleDisabled.Error())
+
 	_, err = client.EthGetFilterChanges(ctx, ethtypes.EthFilterID{})
 	require.NotNil(t, err)
 	require.Equal(t, err.Error(), eth.ErrModuleDisabled.Error())
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
@@ -282,3 +283,63 @@ func TestDeployment(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, byteCode.RawData(), byteCodeSt)
 }
+
+// TestEthGetCodePendingCreate checks that the code of a contract created by a transaction in the
+// head tipset is only visible at "pending", as the head tipset is only executed by its children.
+func TestEthGetCodePendingCreate(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, miner, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	miners := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contractHex, err := os.ReadFile("./contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+
+	tx, err := deployContractTx(ctx, client, ethAddr, contract)
+	require.NoError(t, err)
+	client.EVM().SignTransaction(tx, key.PrivateKey)
+	contractAddr := client.EVM().ComputeContractAddress(ethAddr, 0)
+
+	// Stop mining, so the transaction is included by the single block mined below.
+	for _, m := range miners {
+		m.Pause()
+	}
+	hash := client.EVM().SubmitTransaction(ctx, tx)
+
+	code, err := client.EthGetCode(ctx, contractAddr, ethtypes.NewEthBlockNumberOrHashFromPredefined("pending"))
+	require.NoError(t, err)
+	require.Empty(t, code, "code isn't live while the transaction is in the mpool")
+
+	kit.NewBlockMiner(t, miner).MineUntilBlock(ctx, client, nil)
+
+	mCid, err := client.EthGetMessageCidByTransactionHash(ctx, &hash)
+	require.NoError(t, err)
+	require.NotNil(t, mCid)
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	msgs, err := client.ChainGetMessagesInTipset(ctx, head.Key())
+	require.NoError(t, err)
+	require.True(t, slices.ContainsFunc(msgs, func(m api.Message) bool { return m.Cid == *mCid }), "transaction not included in the head tipset")
+
+	code, err = client.EthGetCode(ctx, contractAddr, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+	require.NoError(t, err)
+	require.Empty(t, code, "code isn't live until the transaction is executed")
+
+	// The pending block is the head tipset, whose state includes its own messages. The runtime code
+	// is returned rather than the init code.
+	code, err = client.EthGetCode(ctx, contractAddr, ethtypes.NewEthBlockNumberOrHashFromPredefined("pending"))
+	require.NoError(t, err)
+	require.NotEmpty(t, code)
+	require.NotEqual(t, ethtypes.EthBytes(contract), code)
+
+	for _, m := range miners {
+		m.Restart()
+	}
+}
@@ -11,7 +11,11 @@ import (
 
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
 
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/consensus/filcns"
+	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 	"github.com/filecoin-project/lotus/itests/kit"
@@ -124,6 +128,13 @@ func TestEthFeeHistory(t *testing.T) {
 	assertHistory(&history, 5, int(latestBlk))
 	require.Nil(history.Reward)
 
+	// The last base fee is the one projected for the block after the newest, which is the
+	// parent base fee of the head.
+	head, err := client.ChainHead(ctx)
+	require.NoError(err)
+	nextBaseFee := history.BaseFeePerGas[len(history.BaseFeePerGas)-1]
+	require.Zero(head.Blocks()[0].ParentBaseFee.Int.Cmp(nextBaseFee.Int), "next base fee %s", nextBaseFee)
+
 	history, err = client.EthFeeHistory(ctx, result.Wrap[jsonrpc.RawParams](
 		json.Marshal([]interface{}{"0x10", "0x12"}),
 	).Assert(require.NoError))
@@ -190,3 +201,99 @@ func TestEthFeeHistory(t *testing.T) {
 	).Assert(require.NoError))
 	require.NoError(err)
 }
+
+func TestEthFeeHistoryGasUsedRatio(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// Fill a block with a message of known gas usage.
+	_, _, recipient := client.EVM().NewAccount()
+	from, err := client.WalletDefaultAddress(ctx)
+	require.NoError(t, err)
+	sm, err := client.MpoolPushMessage(ctx, &types.Message{From: from, To: recipient, Value: types.FromFil(1)}, nil)
+	require.NoError(t, err)
+	lookup, err := client.StateWaitMsg(ctx, sm.Cid(), 3, api.LookbackNoLimit, true)
+	require.NoError(t, err)
+	require.True(t, lookup.Receipt.ExitCode.IsSuccess())
+
+	txHash, err := client.EthGetTransactionHashByCid(ctx, sm.Cid())
+	require.NoError(t, err)
+	require.NotNil(t, txHash)
+	receipt, err := client.EthGetTransactionReceipt(ctx, *txHash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	history, err := client.EthFeeHistory(ctx, result.Wrap[jsonrpc.RawParams](
+		json.Marshal([]interface{}{10, receipt.BlockNumber.Hex()}),
+	).Assert(require.NoError))
+	require.NoError(t, err)
+	require.NotEmpty(t, history.GasUsedRatio)
+
+	// The ratios run from the oldest block to the block of the message, each being the gas used by
+	// the block over its gas limit, as reported by eth_getBlockByHash.
+	blkHash := receipt.BlockHash
+	for i := len(history.GasUsedRatio) - 1; i >= 0; i-- {
+		blk, err := client.EthGetBlockByHash(ctx, blkHash, false)
+		require.NoError(t, err)
+		require.Equal(t, float64(blk.GasUsed)/float64(blk.GasLimit), history.GasUsedRatio[i], "block %d", blk.Number)
+		blkHash = blk.ParentHash
+	}
+
+	blk, err := client.EthGetBlockByHash(ctx, receipt.BlockHash, false)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, history.GasUsedRatio[len(history.GasUsedRatio)-1], float64(receipt.GasUsed)/float64(blk.GasLimit))
+	require.Greater(t, history.GasUsedRatio[len(history.GasUsedRatio)-1], float64(0))
+}
+
+func TestEthFeeHistoryBeforeEVM(t *testing.T) {
+	kit.QuietMiningLogs()
+
+	// The EVM is introduced by the upgrade to network version 18.
+	nv18epoch := abi.ChainEpoch(20)
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC(),
+		kit.UpgradeSchedule(stmgr.Upgrade{
+			Network: network.Version17,
+			Height:  -1,
+		}, stmgr.Upgrade{
+			Network:   network.Version18,
+			Height:    nv18epoch,
+			Migration: filcns.UpgradeActorsV10,
+		}))
+	ens.InterconnectAll().BeginMining(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client.WaitTillChain(ctx, kit.HeightAtLeast(nv18epoch+20))
+
+	// Find the first tipset the EVM was active in, and the tipset ten epochs later.
+	newest, err := client.ChainGetTipSetByHeight(ctx, nv18epoch+10, types.EmptyTSK)
+	require.NoError(t, err)
+	activation := newest
+	for {
+		parent, err := client.ChainGetTipSet(ctx, activation.Parents())
+		require.NoError(t, err)
+		nv, err := client.StateNetworkVersion(ctx, parent.Key())
+		require.NoError(t, err)
+		if nv < network.Version18 {
+			break
+		}
+		activation = parent
+	}
+	require.Less(t, activation.Height(), newest.Height())
+
+	// Ask for a range starting well before the EVM was active.
+	history, err := client.EthFeeHistory(ctx, result.Wrap[jsonrpc.RawParams](
+		json.Marshal([]interface{}{30, ethtypes.EthUint64(newest.Height()).Hex()}),
+	).Assert(require.NoError))
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.EthUint64(activation.Height()), history.OldestBlock)
+	require.NotEmpty(t, history.GasUsedRatio)
+	require.Len(t, history.BaseFeePerGas, len(history.GasUsedRatio)+1)
+
+	// A range ending before the EVM was active has no history at all.
+	_, err = client.EthFeeHistory(ctx, result.Wrap[jsonrpc.RawParams](
+		json.Marshal([]interface{}{5, ethtypes.EthUint64(nv18epoch - 5).Hex()}),
+	).Assert(require.NoError))
+	require.ErrorContains(t, err, "no fee history before network version 18")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ipfs/go-cid"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
@@ -857,6 +858,532 @@ func TestEthGetLogs(t *testing.T) {
 	}
 }
 
+func TestEthEstimateLogsCount(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contract1, contract2, invocations := prepareEventMatrixInvocations(ctx, t, client)
+	testCases := getCombinationFilterTestCases(contract1, contract2, "0x0")
+
+	invokeAndWaitUntilAllOnChain(t, client, invocations)
+
+	for _, tc := range testCases {
+		tc := tc // appease the lint despot
+		t.Run(tc.name, func(t *testing.T) {
+			// fetch the logs first, this waits for the index to catch up with the events
+			res, err := client.EthGetLogs(ctx, tc.spec)
+			require.NoError(err)
+			actual := len(res.Results)
+
+			estimate, err := client.EthEstimateLogsCount(ctx, tc.spec)
+			require.NoError(err)
+
+			// the estimate is approximate, but should be in the right ballpark
+			require.GreaterOrEqual(int(estimate), actual)
+			require.LessOrEqual(int(estimate), 2*actual)
+		})
+	}
+}
+
+func TestEthGetLogsMaxResults(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	const maxResults = 3
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC(), kit.MaxGetLogsResults(maxResults))
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// two contracts, emitting two and three logs respectively
+	contract1, _ := invokeLogFourData(t, client, 2)
+	contract2, _ := invokeLogFourData(t, client, maxResults)
+
+	// fewer than the maximum
+	res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).AddressOneOf(contract1).Filter())
+	require.NoError(err)
+	require.Len(res.Results, 2)
+
+	// exactly the maximum
+	res, err = client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).AddressOneOf(contract2).Filter())
+	require.NoError(err)
+	require.Len(res.Results, maxResults)
+
+	// more than the maximum
+	_, err = client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).AddressOneOf(contract1, contract2).Filter())
+	require.ErrorContains(err, fmt.Sprintf("query returned more than %d results, narrow your filter", maxResults))
+}
+
+func TestEthGetLogsEventSignature(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, _, invocations := prepareEventMatrixInvocations(ctx, t, client)
+	invokeAndWaitUntilAllOnChain(t, client, invocations)
+
+	for _, sig := range []string{
+		"EventOneData(uint256)",
+		"EventTwoIndexed(uint256,uint256)",
+		"EventThreeIndexedWithData(uint256,uint256,uint256,uint256)",
+	} {
+		sig := sig
+		t.Run(sig, func(t *testing.T) {
+			byTopic, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Topic1OneOf(kit.EthTopicHash(sig)).Filter())
+			require.NoError(err)
+			require.NotEmpty(byTopic.Results)
+
+			spec := kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter()
+			spec.EventSignature = sig
+			bySignature, err := client.EthGetLogs(ctx, spec)
+			require.NoError(err)
+			require.Equal(byTopic.Results, bySignature.Results)
+		})
+	}
+
+	// the signature can be combined with the other topics
+	byTopic, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).
+		Topic1OneOf(kit.EventMatrixContract.Ev["EventTwoIndexed"]).
+		Topic2OneOf(uint64EthHash(44)).
+		Filter())
+	require.NoError(err)
+	require.NotEmpty(byTopic.Results)
+
+	spec := kit.NewEthFilterBuilder().FromBlockEpoch(0).Topic2OneOf(uint64EthHash(44)).Filter()
+	spec.EventSignature = "EventTwoIndexed(uint256,uint256)"
+	bySignature, err := client.EthGetLogs(ctx, spec)
+	require.NoError(err)
+	require.Equal(byTopic.Results, bySignature.Results)
+
+	// but not with the first topic
+	spec = kit.NewEthFilterBuilder().FromBlockEpoch(0).Topic1OneOf(kit.EventMatrixContract.Ev["EventOneData"]).Filter()
+	spec.EventSignature = "EventOneData(uint256)"
+	_, err = client.EthGetLogs(ctx, spec)
+	require.ErrorContains(err, "must not specify both event signature and first topic")
+}
+
+func TestEthGetLogsMinTopics(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, _, invocations := prepareEventMatrixInvocations(ctx, t, client)
+	invokeAndWaitUntilAllOnChain(t, client, invocations)
+
+	res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter())
+	require.NoError(err)
+	all, err := parseEthLogsFromFilterResult(res)
+	require.NoError(err)
+
+	// the event matrix emits logs with one to four topics
+	topicCounts := map[int]int{}
+	for _, elog := range all {
+		topicCounts[len(elog.Topics)]++
+	}
+	for k := 1; k <= 4; k++ {
+		require.NotZero(topicCounts[k], "no logs with %d topics", k)
+	}
+
+	for k := 0; k <= 4; k++ {
+		want := []*ethtypes.EthLog{}
+		for _, elog := range all {
+			if len(elog.Topics) >= k {
+				want = append(want, elog)
+			}
+		}
+
+		spec := kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter()
+		spec.MinTopics = ethtypes.EthUint64(k)
+		res, err := client.EthGetLogs(ctx, spec)
+		require.NoError(err)
+		got, err := parseEthLogsFromFilterResult(res)
+		require.NoError(err)
+		require.Equal(want, got, "min topics %d", k)
+	}
+
+	spec := kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter()
+	spec.MinTopics = 5
+	_, err = client.EthGetLogs(ctx, spec)
+	require.ErrorContains(err, "events have at most 4 topics")
+}
+
+func TestEthGetLogsGroupByTransaction(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, _, invocations := prepareEventMatrixInvocations(ctx, t, client)
+	messages := invokeAndWaitUntilAllOnChain(t, client, invocations)
+
+	res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter())
+	require.NoError(err)
+	all, err := parseEthLogsFromFilterResult(res)
+	require.NoError(err)
+
+	want := map[ethtypes.EthHash][]ethtypes.EthLog{}
+	for _, elog := range all {
+		want[elog.TransactionHash] = append(want[elog.TransactionHash], *elog)
+	}
+	require.Greater(len(want), 1)
+
+	spec := kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter()
+	spec.GroupByTransaction = true
+	res, err = client.EthGetLogs(ctx, spec)
+	require.NoError(err)
+	require.Nil(res.Results)
+	require.Equal(want, res.ByTransaction)
+
+	// every group holds the logs of one of the invocations
+	for txHash, logs := range res.ByTransaction {
+		require.Contains(messages, txHash)
+		for _, elog := range logs {
+			require.Equal(txHash, elog.TransactionHash)
+		}
+	}
+
+	// without matches, the result is an empty group set
+	spec.Topics = ethtypes.EthTopicSpec{{ethtypes.EthHash{}}}
+	res, err = client.EthGetLogs(ctx, spec)
+	require.NoError(err)
+	require.NotNil(res.ByTransaction)
+	require.Empty(res.ByTransaction)
+}
+
+func TestEthGetLogsExcludeTopics(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, _, invocations := prepareEventMatrixInvocations(ctx, t, client)
+	invokeAndWaitUntilAllOnChain(t, client, invocations)
+
+	res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter())
+	require.NoError(err)
+	all, err := parseEthLogsFromFilterResult(res)
+	require.NoError(err)
+
+	excluded := []ethtypes.EthHash{kit.EventMatrixContract.Ev["EventTwoIndexed"], kit.EventMatrixContract.Ev["EventOneData"]}
+	want := []*ethtypes.EthLog{}
+	var dropped int
+	for _, elog := range all {
+		if elog.Topics[0] == excluded[0] || elog.Topics[0] == excluded[1] {
+			dropped++
+			continue
+		}
+		want = append(want, elog)
+	}
+	require.NotZero(dropped)
+	require.NotEmpty(want)
+
+	spec := kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter()
+	spec.ExcludeTopics = ethtypes.EthTopicSpec{excluded}
+	res, err = client.EthGetLogs(ctx, spec)
+	require.NoError(err)
+	got, err := parseEthLogsFromFilterResult(res)
+	require.NoError(err)
+	require.Equal(want, got)
+
+	// exclusions apply after the inclusion match
+	spec = kit.NewEthFilterBuilder().FromBlockEpoch(0).Topic1OneOf(kit.EventMatrixContract.Ev["EventTwoIndexed"]).Filter()
+	res, err = client.EthGetLogs(ctx, spec)
+	require.NoError(err)
+	require.NotEmpty(res.Results)
+
+	spec.ExcludeTopics = ethtypes.EthTopicSpec{excluded}
+	res, err = client.EthGetLogs(ctx, spec)
+	require.NoError(err)
+	require.Empty(res.Results)
+}
+
+func TestEthGetLogsFirstTopicOneOf(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// the contract logs a Transfer, an Approval and an event without topics
+	fromAddr, idAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/tokenevents.bin")
+	invokeAndWaitUntilAllOnChain(t, client, []Invocation{{
+		Sender:   fromAddr,
+		Target:   idAddr,
+		Selector: []byte{0, 0, 0, 0},
+	}})
+	contract := getEthAddress(ctx, t, client, idAddr)
+
+	transfer := kit.EthTopicHash("Transfer(address,address,uint256)")
+	approval := kit.EthTopicHash("Approval(address,address,uint256)")
+
+	getLogs := func(topics ...ethtypes.EthHash) []*ethtypes.EthLog {
+		res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).AddressOneOf(contract).Topic1OneOf(topics...).Filter())
+		require.NoError(err)
+		elogs, err := parseEthLogsFromFilterResult(res)
+		require.NoError(err)
+		return elogs
+	}
+
+	// the signatures at the first position are ORed
+	elogs := getLogs(transfer, approval)
+	require.Len(elogs, 2)
+	require.Equal([]ethtypes.EthHash{transfer}, elogs[0].Topics)
+	require.Equal([]ethtypes.EthHash{approval}, elogs[1].Topics)
+
+	elogs = getLogs(approval)
+	require.Len(elogs, 1)
+	require.Equal([]ethtypes.EthHash{approval}, elogs[0].Topics)
+
+	// the event without topics only matches a filter without topics
+	require.Len(getLogs(), 3)
+}
+
+func TestEthGetLogsNoMatches(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	invokeLogFourData(t, client, 1)
+
+	// The client only decodes a non-nil list of results from [], a null response leaves it nil.
+	res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Topic1OneOf(kit.EthTopicHash("NoSuchEvent()")).Filter())
+	require.NoError(err)
+	require.NotNil(res)
+	require.NotNil(res.Results)
+	require.Empty(res.Results)
+}
+
+func TestEthGetLogsChecksummedAddress(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ethAddr, messages := invokeLogFourData(t, client, 2)
+
+	// Filter with the EIP-55 form of the address through the raw API, so the mixed case reaches
+	// the node as sent.
+	checksummed := toChecksumAddress(ethAddr)
+	require.NotEqual(ethAddr.String(), checksummed, "address has no letters to checksum")
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[{"fromBlock":"0x0","address":%q}]}`, checksummed)
+	code, resp := client.DoRawRPCRequest(t, 1, body)
+	require.Equal(200, code, string(resp))
+
+	var res struct {
+		Result []ethtypes.EthLog `json:"result"`
+	}
+	require.NoError(json.Unmarshal(resp, &res), string(resp))
+	require.Len(res.Result, len(messages))
+	for _, elog := range res.Result {
+		require.Equal(ethAddr, elog.Address)
+		require.Contains(messages, elog.TransactionHash)
+	}
+
+	// Logs are always reported with the lowercase address.
+	require.Contains(string(resp), `"address":"`+ethAddr.String()+`"`)
+}
+
+// toChecksumAddress returns the EIP-55 mixed-case form of an address.
+func toChecksumAddress(addr ethtypes.EthAddress) string {
+	lower := hex.EncodeToString(addr[:])
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(lower))
+	hash := hex.EncodeToString(hasher.Sum(nil))
+
+	out := []byte(lower)
+	for i, c := range out {
+		if c >= 'a' && c <= 'f' && hash[i] >= '8' {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+func TestEthGetLogsFromSubcall(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sender, inner := client.EVM().DeployContractFromFilename(ctx, kit.EventMatrixContract.Filename)
+	_, outer := client.EVM().DeployContractFromFilename(ctx, "contracts/eventforwarder.bin")
+
+	ethAddress := func(idAddr address.Address) ethtypes.EthAddress {
+		actor, err := client.StateGetActor(ctx, idAddr, types.EmptyTSK)
+		require.NoError(err)
+		require.NotNil(actor.DelegatedAddress)
+		ethAddr, err := ethtypes.EthAddressFromFilecoinAddress(*actor.DelegatedAddress)
+		require.NoError(err)
+		return ethAddr
+	}
+	innerEth, outerEth := ethAddress(inner), ethAddress(outer)
+
+	// The outer contract makes the inner contract log EventOneIndexed(44).
+	target := make([]byte, 32)
+	copy(target[12:], innerEth[:])
+	input := append(append([]byte{}, kit.EventMatrixContract.Fn["logEventOneIndexed"]...), packUint64Values(44)...)
+	wait, err := client.EVM().InvokeSolidity(ctx, sender, outer, target, input)
+	require.NoError(err)
+	require.True(wait.Receipt.ExitCode.IsSuccess(), "forwarding the call failed: %s", wait.Receipt.ExitCode)
+
+	txHash, err := client.EthGetTransactionHashByCid(ctx, wait.Message)
+	require.NoError(err)
+	require.NotNil(txHash)
+	tx, err := client.EthGetTransactionByHash(ctx, txHash)
+	require.NoError(err)
+	require.Equal(outerEth, *tx.To)
+
+	res, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter())
+	require.NoError(err)
+	elogs, err := parseEthLogsFromFilterResult(res)
+	require.NoError(err)
+	require.Len(elogs, 1)
+
+	// The log is attributed to the inner contract emitting it, not to the transaction's target.
+	require.Equal(innerEth, elogs[0].Address)
+	require.Equal(*txHash, elogs[0].TransactionHash)
+	require.Equal([]ethtypes.EthHash{kit.EventMatrixContract.Ev["EventOneIndexed"], uint64EthHash(44)}, elogs[0].Topics)
+
+	res, err = client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).AddressOneOf(innerEth).Filter())
+	require.NoError(err)
+	require.Len(res.Results, 1)
+
+	res, err = client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).AddressOneOf(outerEth).Filter())
+	require.NoError(err)
+	require.Empty(res.Results)
+}
+
+func TestEthGetLogsTimeout(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC(), kit.GetLogsTimeout(time.Nanosecond))
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	invokeLogFourData(t, client, 2)
+
+	// the query can't scan the whole chain in time
+	_, err := client.EthGetLogs(ctx, kit.NewEthFilterBuilder().FromBlockEpoch(0).Filter())
+	require.ErrorContains(err, "log query timeout")
+}
+
+func TestEthExplainLogs(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client.WaitTillChain(ctx, kit.HeightAtLeast(10))
+
+	from, to := "0x2", "0x6"
+	plan, err := client.EthExplainLogs(ctx, &ethtypes.EthFilterSpec{FromBlock: &from, ToBlock: &to})
+	require.NoError(err)
+	require.NotNil(plan.FromBlock)
+	require.NotNil(plan.ToBlock)
+	require.Equal(ethtypes.EthUint64(2), *plan.FromBlock)
+	require.Equal(ethtypes.EthUint64(6), *plan.ToBlock)
+	require.Nil(plan.BlockHash)
+	require.Equal(ethtypes.EthUint64(5), plan.Tipsets)
+	require.Equal([]string{"blockRange"}, plan.IndexFilters)
+	require.Empty(plan.PostFilters)
+
+	// Addresses and topics narrow the index scan, while the minimum number of topics is checked on
+	// the events it returns.
+	plan, err = client.EthExplainLogs(ctx, &ethtypes.EthFilterSpec{
+		FromBlock: &from,
+		ToBlock:   &to,
+		Address:   ethtypes.EthAddressList{{1}},
+		Topics:    ethtypes.EthTopicSpec{{ethtypes.EthHash{1}}},
+		MinTopics: 2,
+	})
+	require.NoError(err)
+	require.Equal([]string{"blockRange", "address", "topics"}, plan.IndexFilters)
+	require.Equal([]string{"minTopics"}, plan.PostFilters)
+
+	// Queries of a single block scan a single tipset.
+	ts, err := client.ChainGetTipSetByHeight(ctx, 4, types.EmptyTSK)
+	require.NoError(err)
+	tsCid, err := ts.Key().Cid()
+	require.NoError(err)
+	blockHash, err := ethtypes.EthHashFromCid(tsCid)
+	require.NoError(err)
+	plan, err = client.EthExplainLogs(ctx, &ethtypes.EthFilterSpec{BlockHash: &blockHash})
+	require.NoError(err)
+	require.Nil(plan.FromBlock)
+	require.Equal(&blockHash, plan.BlockHash)
+	require.Equal(ethtypes.EthUint64(1), plan.Tipsets)
+	require.Equal([]string{"blockHash"}, plan.IndexFilters)
+}
+
 func TestEthGetFilterChanges(t *testing.T) {
 	require := require.New(t)
 	kit.QuietAllLogsExcept("events", "messagepool")
@@ -978,6 +1505,101 @@ func TestEthSubscribeLogs(t *testing.T) {
 	}
 }
 
+func TestEthSubscribeLogsMatchGetLogs(t *testing.T) {
+	require := require.New(t)
+	kit.QuietAllLogsExcept("events", "messagepool")
+
+	blockTime := 100 * time.Millisecond
+
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contract1, contract2, invocations := prepareEventMatrixInvocations(ctx, t, client)
+
+	specs := map[string]*ethtypes.EthSubscriptionParams{}
+	for _, tc := range getCombinationFilterTestCases(contract1, contract2, "latest") {
+		specs[tc.name] = &ethtypes.EthSubscriptionParams{Topics: tc.spec.Topics, Address: tc.spec.Address}
+	}
+	specs["event signature"] = &ethtypes.EthSubscriptionParams{
+		Topics:         ethtypes.EthTopicSpec{nil, {uint64EthHash(44)}},
+		EventSignature: "EventTwoIndexed(uint256,uint256)",
+	}
+	specs["min topics"] = &ethtypes.EthSubscriptionParams{MinTopics: 3}
+	specs["exclude topics"] = &ethtypes.EthSubscriptionParams{
+		Address:       ethtypes.EthAddressList{contract1, contract2},
+		ExcludeTopics: ethtypes.EthTopicSpec{{kit.EventMatrixContract.Ev["EventTwoIndexed"]}},
+	}
+
+	head, err := client.ChainHead(ctx)
+	require.NoError(err)
+
+	testResponses := map[string]chan ethtypes.EthSubscriptionResponse{}
+	for name, params := range specs {
+		subParam, err := json.Marshal(ethtypes.EthSubscribeParams{EventType: "logs", Params: params})
+		require.NoError(err)
+
+		subId, err := client.EthSubscribe(ctx, subParam)
+		require.NoError(err)
+
+		responseCh := make(chan ethtypes.EthSubscriptionResponse, 2*len(invocations))
+		testResponses[name] = responseCh
+
+		err = client.EthSubRouter.AddSub(ctx, subId, func(ctx context.Context, resp *ethtypes.EthSubscriptionResponse) error {
+			responseCh <- *resp
+			return nil
+		})
+		require.NoError(err)
+	}
+
+	invokeAndWaitUntilAllOnChain(t, client, invocations)
+
+	// wait a little for subscriptions to gather results
+	time.Sleep(blockTime * 6)
+
+	for name, params := range specs {
+		name, params := name, params // appease the lint despot
+		t.Run(name, func(t *testing.T) {
+			responseCh := testResponses[name]
+			close(responseCh)
+
+			var got []*ethtypes.EthLog
+			for resp := range responseCh {
+				rmap, ok := resp.Result.(map[string]interface{})
+				require.True(ok, "expected subscription result entry to be map[string]interface{}, but was %T", resp.Result)
+
+				elog, err := ParseEthLog(rmap)
+				require.NoError(err)
+				got = append(got, elog)
+			}
+
+			// the subscription matches the same logs, with the same log indexes, as eth_getLogs
+			spec := params.FilterSpec()
+			from := ethtypes.EthUint64(head.Height()).Hex()
+			spec.FromBlock = &from
+			res, err := client.EthGetLogs(ctx, spec)
+			require.NoError(err)
+			want, err := parseEthLogsFromFilterResult(res)
+			require.NoError(err)
+			require.ElementsMatch(want, got)
+		})
+	}
+
+	// criteria are validated like those of eth_getLogs
+	subParam, err := json.Marshal(ethtypes.EthSubscribeParams{
+		EventType: "logs",
+		Params: &ethtypes.EthSubscriptionParams{
+			Topics:         ethtypes.EthTopicSpec{{kit.EventMatrixContract.Ev["EventOneData"]}},
+			EventSignature: "EventOneData(uint256)",
+		},
+	})
+	require.NoError(err)
+	_, err = client.EthSubscribe(ctx, subParam)
+	require.ErrorContains(err, "must not specify both event signature and first topic")
+}
+
 func TestEthGetFilterLogs(t *testing.T) {
 	require := require.New(t)
 	kit.QuietAllLogsExcept("events", "messagepool")
@@ -1212,6 +1834,18 @@ func TestEthGetLogsWithBlockRanges(t *testing.T) {
 			expected: union(partition1.expected),
 		},
 
+		{
+			name:     "find all events from earliest to end of partition2",
+			spec:     kit.NewEthFilterBuilder().FromBlock("earliest").ToBlockEpoch(partition2.end).Topic1OneOf(topics...).Filter(),
+			expected: union(partition1.expected, partition2.expected),
+		},
+
+		{
+			name:     "find all events from start of partition2 to latest",
+			spec:     kit.NewEthFilterBuilder().FromBlockEpoch(partition2.start).ToBlock("latest").Topic1OneOf(topics...).Filter(),
+			expected: union(partition2.expected, partition3.expected),
+		},
+
 		{
 			name:     "find all events from start of partition3 to latest",
 			spec:     kit.NewEthFilterBuilder().FromBlockEpoch(partition3.start).ToBlock("latest").Topic1OneOf(topics...).Filter(),
@@ -1236,6 +1870,29 @@ func TestEthGetLogsWithBlockRanges(t *testing.T) {
 			AssertEthLogs(t, elogs, tc.expected, messages)
 		})
 	}
+
+	// "latest" is past all the events by now, and "earliest" is before all of them, so these ranges
+	// are inverted.
+	invalidCases := []struct {
+		name string
+		spec *ethtypes.EthFilterSpec
+	}{
+		{
+			name: "from latest to end of partition3",
+			spec: kit.NewEthFilterBuilder().FromBlock("latest").ToBlockEpoch(partition3.end).Topic1OneOf(topics...).Filter(),
+		},
+		{
+			name: "from start of partition1 to earliest",
+			spec: kit.NewEthFilterBuilder().FromBlockEpoch(partition1.start).ToBlock("earliest").Topic1OneOf(topics...).Filter(),
+		},
+	}
+
+	for _, tc := range invalidCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.EthGetLogs(ctx, tc.spec)
+			require.ErrorContains(err, "must be after from block")
+		})
+	}
 }
 
 func TestEthNewFilterMergesHistoricWithRealtime(t *testing.T) {
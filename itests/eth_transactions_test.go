@@ -21,6 +21,7 @@ import (
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/messagepool"
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
@@ -403,6 +404,328 @@ func TestContractInvocationMultiple(t *testing.T) {
 	}
 }
 
+func TestEthCallPendingAppliesPendingMessages(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	miners := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contractHex, err := os.ReadFile("./contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	// The deployer is credited with the initial supply of the coin.
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+	contractTx, err := deployContractTx(ctx, client, ethAddr, contract)
+	require.NoError(t, err)
+	client.EVM().SignTransaction(contractTx, key.PrivateKey)
+	receipt, err := client.EVM().WaitTransaction(ctx, client.EVM().SubmitTransaction(ctx, contractTx))
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+	contractAddr := client.EVM().ComputeContractAddress(ethAddr, 0)
+
+	_, recipient, _ := client.EVM().NewAccount()
+	withRecipient := func(signature string, args ...[]byte) []byte {
+		input := kit.CalcFuncSignature(signature)
+		input = append(input, make([]byte, 12)...)
+		input = append(input, recipient[:]...)
+		for _, arg := range args {
+			input = append(input, arg...)
+		}
+		return input
+	}
+	getBalance := withRecipient("getBalance(address)")
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From: &ethAddr,
+		To:   &contractAddr,
+		Data: withRecipient("sendCoin(address,uint256)", paddedUint64(1)),
+	}})
+	require.NoError(t, err)
+	gasLimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	// Keep two transfers from the deployer pending.
+	for _, m := range miners {
+		m.Pause()
+	}
+	for nonce := 1; nonce <= 2; nonce++ {
+		tx := ethtypes.Eth1559TxArgs{
+			ChainID:              buildconstants.Eip155ChainId,
+			Nonce:                nonce,
+			To:                   &contractAddr,
+			Value:                big.Zero(),
+			MaxFeePerGas:         types.NanoFil,
+			MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+			GasLimit:             int(gasLimit),
+			Input:                withRecipient("sendCoin(address,uint256)", paddedUint64(uint64(nonce))),
+			V:                    big.Zero(),
+			R:                    big.Zero(),
+			S:                    big.Zero(),
+		}
+		client.EVM().SignTransaction(&tx, key.PrivateKey)
+		client.EVM().SubmitTransaction(ctx, &tx)
+	}
+
+	call := func(blkParam string, tx ethtypes.EthCall) *ethtypes.EthCallDetailedResult {
+		blk := ethtypes.NewEthBlockNumberOrHashFromPredefined(blkParam)
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: tx, BlkParam: &blk})
+		require.NoError(t, err)
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	// At the pending block, calls see the effects of the pending transfers, whoever makes them.
+	res := call("pending", ethtypes.EthCall{From: &ethAddr, To: &contractAddr, Data: getBalance})
+	require.Equal(t, paddedUint64(3), res.ReturnData)
+	res = call("pending", ethtypes.EthCall{From: &recipient, To: &contractAddr, Data: getBalance})
+	require.Equal(t, paddedUint64(3), res.ReturnData)
+	res = call("pending", ethtypes.EthCall{To: &contractAddr, Data: getBalance})
+	require.Equal(t, paddedUint64(3), res.ReturnData)
+	// Calls at the latest block don't.
+	res = call("latest", ethtypes.EthCall{From: &ethAddr, To: &contractAddr, Data: getBalance})
+	require.Equal(t, paddedUint64(0), res.ReturnData)
+
+	// The nonce of the deployer also accounts for them, so a contract it creates at the pending
+	// block lands at the address of its next transaction.
+	res = call("pending", ethtypes.EthCall{From: &ethAddr, Data: contract})
+	require.NotNil(t, res.CreatedAddress)
+	require.Equal(t, client.EVM().ComputeContractAddress(ethAddr, 3), *res.CreatedAddress)
+
+	for _, m := range miners {
+		m.Restart()
+	}
+}
+
+func TestEthGetBalancePending(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	miners := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, recipient, recipientFil := client.EVM().NewAccount()
+
+	// Keep a transfer to the recipient pending.
+	for _, m := range miners {
+		m.Pause()
+	}
+	value := types.FromFil(3)
+	_, err := client.MpoolPushMessage(ctx, &types.Message{From: client.DefaultKey.Address, To: recipientFil, Value: value}, nil)
+	require.NoError(t, err)
+
+	balance := func(blkParam string) big.Int {
+		bal, err := client.EthGetBalance(ctx, recipient, ethtypes.NewEthBlockNumberOrHashFromPredefined(blkParam))
+		require.NoError(t, err)
+		return big.Int(bal)
+	}
+
+	// Only the pending block sees the transfer.
+	require.Equal(t, value.String(), balance("pending").String())
+	require.True(t, balance("latest").IsZero())
+
+	for _, m := range miners {
+		m.Restart()
+	}
+}
+
+func TestEthGetTransactionReceiptBlockHash(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contractHex, err := os.ReadFile("./contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+	contractTx, err := deployContractTx(ctx, client, ethAddr, contract)
+	require.NoError(t, err)
+	client.EVM().SignTransaction(contractTx, key.PrivateKey)
+	receipt, err := client.EVM().WaitTransaction(ctx, client.EVM().SubmitTransaction(ctx, contractTx))
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+	contractAddr := client.EVM().ComputeContractAddress(ethAddr, 0)
+
+	// A transfer of the coin emits a log, whose block hash must match too.
+	_, recipient, _ := client.EVM().NewAccount()
+	input := kit.CalcFuncSignature("sendCoin(address,uint256)")
+	input = append(input, make([]byte, 12)...)
+	input = append(input, recipient[:]...)
+	input = append(input, paddedUint64(1)...)
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From: &ethAddr,
+		To:   &contractAddr,
+		Data: input,
+	}})
+	require.NoError(t, err)
+	gasLimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	tx := ethtypes.Eth1559TxArgs{
+		ChainID:              buildconstants.Eip155ChainId,
+		Nonce:                1,
+		To:                   &contractAddr,
+		Value:                big.Zero(),
+		MaxFeePerGas:         types.NanoFil,
+		MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+		GasLimit:             int(gasLimit),
+		Input:                input,
+		V:                    big.Zero(),
+		R:                    big.Zero(),
+		S:                    big.Zero(),
+	}
+	client.EVM().SignTransaction(&tx, key.PrivateKey)
+	hash := client.EVM().SubmitTransaction(ctx, &tx)
+	receipt, err = client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+	require.Len(t, receipt.Logs, 1)
+
+	// The block hash is the hash of the Ethereum block containing the transaction, not a CID.
+	blk, err := client.EthGetBlockByNumber(ctx, receipt.BlockNumber.Hex(), false)
+	require.NoError(t, err)
+	require.Equal(t, blk.Hash, receipt.BlockHash)
+	require.Contains(t, blk.Transactions, hash.String())
+	require.Equal(t, receipt.BlockHash, receipt.Logs[0].BlockHash)
+
+	blk, err = client.EthGetBlockByHash(ctx, receipt.BlockHash, false)
+	require.NoError(t, err)
+	require.Equal(t, receipt.BlockNumber, blk.Number)
+
+	ethTx, err := client.EthGetTransactionByHash(ctx, &hash)
+	require.NoError(t, err)
+	require.NotNil(t, ethTx.BlockHash)
+	require.Equal(t, receipt.BlockHash, *ethTx.BlockHash)
+}
+
+func TestEthGetTransactionByHashReorg(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	miners := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	_, recipient, _ := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:  &ethAddr,
+		To:    &recipient,
+		Value: ethtypes.EthBigInt(big.NewInt(100)),
+	}})
+	require.NoError(t, err)
+	gasLimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	tx := ethtypes.Eth1559TxArgs{
+		ChainID:              buildconstants.Eip155ChainId,
+		Nonce:                0,
+		To:                   &recipient,
+		Value:                big.NewInt(100),
+		MaxFeePerGas:         types.NanoFil,
+		MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+		GasLimit:             int(gasLimit),
+		V:                    big.Zero(),
+		R:                    big.Zero(),
+		S:                    big.Zero(),
+	}
+	client.EVM().SignTransaction(&tx, key.PrivateKey)
+	hash := client.EVM().SubmitTransaction(ctx, &tx)
+	receipt, err := client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+
+	// Reorg the block including the transaction out by resetting the head to its parent.
+	for _, miner := range miners {
+		miner.Pause()
+	}
+	included, err := client.ChainGetTipSetByHeight(ctx, abi.ChainEpoch(receipt.BlockNumber), types.EmptyTSK)
+	require.NoError(t, err)
+	require.NoError(t, client.ChainSetHead(ctx, included.Parents()))
+
+	// As in Ethereum, a lookup by hash reports the transaction as pending once its block has been
+	// orphaned and the message returned to the mpool.
+	require.Eventually(t, func() bool {
+		ethTx, err := client.EthGetTransactionByHash(ctx, &hash)
+		return err == nil && ethTx != nil && ethTx.BlockHash == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// The orphaned block is still reachable by its hash, and its fields describe the transaction.
+	ethTx, err := client.EthGetTransactionByBlockHashAndIndex(ctx, receipt.BlockHash, receipt.TransactionIndex)
+	require.NoError(t, err)
+	require.NotNil(t, ethTx)
+	require.Equal(t, hash, ethTx.Hash)
+	require.Equal(t, receipt.BlockHash, *ethTx.BlockHash)
+	require.Equal(t, receipt.BlockNumber, *ethTx.BlockNumber)
+	require.Equal(t, receipt.TransactionIndex, *ethTx.TransactionIndex)
+
+	// Once the transaction is included on the new canonical chain, the lookup reports that block.
+	for _, miner := range miners {
+		miner.Restart()
+	}
+	receipt2, err := client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.NotEqual(t, receipt.BlockHash, receipt2.BlockHash)
+
+	ethTx, err = client.EthGetTransactionByHash(ctx, &hash)
+	require.NoError(t, err)
+	require.NotNil(t, ethTx.BlockHash)
+	require.Equal(t, receipt2.BlockHash, *ethTx.BlockHash)
+	require.Equal(t, receipt2.BlockNumber, *ethTx.BlockNumber)
+}
+
+func TestEthGetTransactionReceiptPriorityFee(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contractHex, err := os.ReadFile("./contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+	tx, err := deployContractTx(ctx, client, ethAddr, contract)
+	require.NoError(t, err)
+	client.EVM().SignTransaction(tx, key.PrivateKey)
+	receipt, err := client.EVM().WaitTransaction(ctx, client.EVM().SubmitTransaction(ctx, tx))
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+
+	// The priority fee is paid out of what the fee cap leaves above the base fee of the block.
+	block, err := client.EthGetBlockByHash(ctx, receipt.BlockHash, false)
+	require.NoError(t, err)
+	baseFee := big.Int(block.BaseFeePerGas)
+	expected := big.Min(tx.MaxPriorityFeePerGas, big.Sub(tx.MaxFeePerGas, baseFee))
+	require.True(t, expected.GreaterThan(big.Zero()))
+	require.Equal(t, expected.String(), big.Int(receipt.EffectivePriorityFeePerGas).String())
+}
+
 func TestGetBlockByNumber(t *testing.T) {
 	blockTime := 100 * time.Millisecond
 	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
@@ -757,6 +1080,59 @@ func TestTraceTransaction(t *testing.T) {
 	require.EqualValues(t, traces[0].BlockNumber, receipt.BlockNumber)
 }
 
+func TestEthReplayTransaction(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// install contract
+	contractHex, err := os.ReadFile("./contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	// create a new Ethereum account
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	// send some funds to the f410 address
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+
+	tx, err := deployContractTx(ctx, client, ethAddr, contract)
+	require.NoError(t, err)
+
+	client.EVM().SignTransaction(tx, key.PrivateKey)
+	hash := client.EVM().SubmitTransaction(ctx, tx)
+
+	receipt, err := client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+
+	// the replay matches the receipt of the transaction
+	replay, err := client.EthReplayTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, replay.TransactionHash)
+	require.Equal(t, receipt.BlockHash, replay.BlockHash)
+	require.Equal(t, receipt.BlockNumber, replay.BlockNumber)
+	require.Equal(t, receipt.Status, replay.Status)
+	require.Equal(t, receipt.GasUsed, replay.GasUsed)
+	require.Empty(t, replay.Error)
+	require.NotEmpty(t, replay.Trace)
+	require.Equal(t, "create", replay.Trace[0].Type)
+
+	// replaying again gives the same result
+	again, err := client.EthReplayTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, replay.GasUsed, again.GasUsed)
+
+	_, err = client.EthReplayTransaction(ctx, ethtypes.EthHash{})
+	require.ErrorContains(t, err, "transaction not found")
+}
+
 func TestTraceFilter(t *testing.T) {
 	blockTime := 100 * time.Millisecond
 	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
@@ -898,3 +1274,497 @@ func TestTraceFilter(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, traces, 3) // still the same traces as before
 }
+
+func TestEthTransactionChainID(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	_, ethAddr2, _ := client.EVM().NewAccount()
+
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(1000))
+
+	chainID, err := client.EthChainId(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, buildconstants.Eip155ChainId, chainID)
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:  &ethAddr,
+		To:    &ethAddr2,
+		Value: ethtypes.EthBigInt(big.NewInt(100)),
+	}})
+	require.NoError(t, err)
+
+	gaslimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	// EIP-1559: the chain id is an explicit field of the transaction.
+	tx1559 := ethtypes.Eth1559TxArgs{
+		ChainID:              buildconstants.Eip155ChainId,
+		Value:                big.NewInt(100),
+		Nonce:                0,
+		To:                   &ethAddr2,
+		MaxFeePerGas:         types.NanoFil,
+		MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+		GasLimit:             int(gaslimit),
+		V:                    big.Zero(),
+		R:                    big.Zero(),
+		S:                    big.Zero(),
+	}
+	client.EVM().SignTransaction(&tx1559, key.PrivateKey)
+	hash1559 := client.EVM().SubmitTransaction(ctx, &tx1559)
+
+	// Legacy EIP-155: the chain id is encoded in the V value of the signature.
+	tx155 := ethtypes.NewEthLegacy155TxArgs(&ethtypes.EthLegacyHomesteadTxArgs{
+		Value:    big.NewInt(100),
+		Nonce:    1,
+		To:       &ethAddr2,
+		GasPrice: types.NanoFil,
+		GasLimit: int(gaslimit),
+		V:        big.Zero(),
+		R:        big.Zero(),
+		S:        big.Zero(),
+	})
+	client.EVM().SignLegacyEIP155Transaction(tx155, key.PrivateKey, big.NewInt(buildconstants.Eip155ChainId))
+	hash155 := client.EVM().SubmitTransaction(ctx, tx155)
+
+	for _, tc := range []struct {
+		name   string
+		hash   ethtypes.EthHash
+		txType int
+	}{
+		{"eip1559", hash1559, ethtypes.EIP1559TxType},
+		{"legacy eip155", hash155, ethtypes.EthLegacyTxType},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			receipt, err := client.EVM().WaitTransaction(ctx, tc.hash)
+			require.NoError(t, err)
+			require.NotNil(t, receipt)
+			require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+
+			ethTx, err := client.EthGetTransactionByHash(ctx, &tc.hash)
+			require.NoError(t, err)
+			require.NotNil(t, ethTx)
+			require.EqualValues(t, tc.txType, ethTx.Type)
+			require.Equal(t, chainID, ethTx.ChainID)
+		})
+	}
+
+	// EIP-2930 transactions aren't accepted, so there is no chain id to report for them.
+	_, err = client.EVM().EthSendRawTransaction(ctx, []byte{0x01, 0xc0})
+	require.ErrorContains(t, err, "EIP-2930 transaction is not supported")
+}
+
+func TestEthGetTransactionReceipts(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	_, ethAddr2, _ := client.EVM().NewAccount()
+
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(1000))
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:  &ethAddr,
+		To:    &ethAddr2,
+		Value: ethtypes.EthBigInt(big.NewInt(100)),
+	}})
+	require.NoError(t, err)
+
+	gaslimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	// Submit the transactions together so that some of them land in the same block.
+	var hashes []ethtypes.EthHash
+	for nonce := 0; nonce < 5; nonce++ {
+		tx := ethtypes.Eth1559TxArgs{
+			ChainID:              buildconstants.Eip155ChainId,
+			Value:                big.NewInt(100),
+			Nonce:                nonce,
+			To:                   &ethAddr2,
+			MaxFeePerGas:         types.NanoFil,
+			MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+			GasLimit:             int(gaslimit),
+			V:                    big.Zero(),
+			R:                    big.Zero(),
+			S:                    big.Zero(),
+		}
+		client.EVM().SignTransaction(&tx, key.PrivateKey)
+		hashes = append(hashes, client.EVM().SubmitTransaction(ctx, &tx))
+	}
+
+	expected := make([]*ethtypes.EthTxReceipt, 0, len(hashes))
+	for _, hash := range hashes {
+		receipt, err := client.EVM().WaitTransaction(ctx, hash)
+		require.NoError(t, err)
+		require.NotNil(t, receipt)
+		expected = append(expected, receipt)
+	}
+
+	// An unknown transaction gets a null receipt, without affecting the others.
+	unknown := ethtypes.EthHash{1}
+	batch := append([]ethtypes.EthHash{unknown}, hashes...)
+
+	receipts, err := client.EthGetTransactionReceipts(ctx, batch)
+	require.NoError(t, err)
+	require.Len(t, receipts, len(batch))
+	require.Nil(t, receipts[0])
+	for i, hash := range hashes {
+		receipt, err := client.EthGetTransactionReceipt(ctx, hash)
+		require.NoError(t, err)
+		require.Equal(t, receipt, receipts[i+1])
+		require.Equal(t, expected[i], receipts[i+1])
+	}
+
+	receipts, err = client.EthGetTransactionReceipts(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+}
+
+func TestEthTxReceiptType(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	_, ethAddr2, _ := client.EVM().NewAccount()
+
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(1000))
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:  &ethAddr,
+		To:    &ethAddr2,
+		Value: ethtypes.EthBigInt(big.NewInt(100)),
+	}})
+	require.NoError(t, err)
+
+	gaslimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	homesteadTx := func(nonce int) *ethtypes.EthLegacyHomesteadTxArgs {
+		return &ethtypes.EthLegacyHomesteadTxArgs{
+			Value:    big.NewInt(100),
+			Nonce:    nonce,
+			To:       &ethAddr2,
+			GasPrice: types.NanoFil,
+			GasLimit: int(gaslimit),
+			V:        big.Zero(),
+			R:        big.Zero(),
+			S:        big.Zero(),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		txType int
+		tx     func(nonce int) ethtypes.EthTransaction
+	}{
+		{"LegacyHomestead", ethtypes.EthLegacyTxType, func(nonce int) ethtypes.EthTransaction {
+			tx := homesteadTx(nonce)
+			client.EVM().SignLegacyHomesteadTransaction(tx, key.PrivateKey)
+			return tx
+		}},
+		{"LegacyEIP155", ethtypes.EthLegacyTxType, func(nonce int) ethtypes.EthTransaction {
+			tx := ethtypes.NewEthLegacy155TxArgs(homesteadTx(nonce))
+			client.EVM().SignLegacyEIP155Transaction(tx, key.PrivateKey, big.NewInt(buildconstants.Eip155ChainId))
+			return tx
+		}},
+		{"EIP1559", ethtypes.EIP1559TxType, func(nonce int) ethtypes.EthTransaction {
+			tx := &ethtypes.Eth1559TxArgs{
+				ChainID:              buildconstants.Eip155ChainId,
+				Value:                big.NewInt(100),
+				Nonce:                nonce,
+				To:                   &ethAddr2,
+				MaxFeePerGas:         types.NanoFil,
+				MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+				GasLimit:             int(gaslimit),
+				V:                    big.Zero(),
+				R:                    big.Zero(),
+				S:                    big.Zero(),
+			}
+			client.EVM().SignTransaction(tx, key.PrivateKey)
+			return tx
+		}},
+	}
+
+	for nonce, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash := client.EVM().SubmitTransaction(ctx, tc.tx(nonce))
+
+			receipt, err := client.EVM().WaitTransaction(ctx, hash)
+			require.NoError(t, err)
+			require.NotNil(t, receipt)
+			require.EqualValues(t, tc.txType, receipt.Type)
+
+			ethTx, err := client.EthGetTransactionByHash(ctx, &hash)
+			require.NoError(t, err)
+			require.Equal(t, ethTx.Type, receipt.Type)
+
+			// Block receipts are built the same way.
+			blockReceipts, err := client.EthGetBlockReceipts(ctx, ethtypes.NewEthBlockNumberOrHashFromNumber(receipt.BlockNumber))
+			require.NoError(t, err)
+			var found bool
+			for _, r := range blockReceipts {
+				if r.TransactionHash == hash {
+					require.Equal(t, receipt.Type, r.Type)
+					found = true
+				}
+			}
+			require.True(t, found)
+		})
+	}
+
+	// EIP-2930 transactions aren't supported, so there are no receipts of that type.
+	_, err = client.EVM().EthSendRawTransaction(ctx, []byte{ethtypes.EIP2930TxType, 0xc0})
+	require.ErrorContains(t, err, "EIP-2930 transaction is not supported")
+}
+
+// Filecoin messages can't carry access lists, and the signature of a transaction is checked against
+// the RLP rebuilt from its message, so only empty access lists round-trip through the mpool:
+// transactions with a non-empty one are rejected on submission.
+func TestEthTxAccessList(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	_, ethAddr2, _ := client.EVM().NewAccount()
+
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(1000))
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:  &ethAddr,
+		To:    &ethAddr2,
+		Value: ethtypes.EthBigInt(big.NewInt(100)),
+	}})
+	require.NoError(t, err)
+
+	gaslimit, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	tx := ethtypes.Eth1559TxArgs{
+		ChainID:              buildconstants.Eip155ChainId,
+		Value:                big.NewInt(100),
+		Nonce:                0,
+		To:                   &ethAddr2,
+		MaxFeePerGas:         types.NanoFil,
+		MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+		GasLimit:             int(gaslimit),
+		V:                    big.Zero(),
+		R:                    big.Zero(),
+		S:                    big.Zero(),
+	}
+	client.EVM().SignTransaction(&tx, key.PrivateKey)
+	signed, err := tx.ToRlpSignedMsg()
+	require.NoError(t, err)
+
+	// The same transaction with a non-empty access list is rejected.
+	decoded, err := ethtypes.DecodeRLP(signed[1:])
+	require.NoError(t, err)
+	fields, ok := decoded.([]interface{})
+	require.True(t, ok)
+	storageKey := ethtypes.EthHash{1}
+	fields[8] = []interface{}{[]interface{}{ethAddr2[:], []interface{}{storageKey[:]}}}
+	withAccessList, err := ethtypes.EncodeRLP(fields)
+	require.NoError(t, err)
+	_, err = client.EVM().EthSendRawTransaction(ctx, append([]byte{ethtypes.EIP1559TxType}, withAccessList...))
+	require.ErrorContains(t, err, "access list should be an empty list")
+
+	// The empty access list of the transaction is returned as such.
+	hash, err := client.EVM().EthSendRawTransaction(ctx, signed)
+	require.NoError(t, err)
+	_, err = client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+
+	ethTx, err := client.EthGetTransactionByHash(ctx, &hash)
+	require.NoError(t, err)
+	require.NotNil(t, ethTx.AccessList)
+	require.Empty(t, ethTx.AccessList)
+}
+
+func TestEthSendRawTransactionSimulation(t *testing.T) {
+	sendReverting := func(t *testing.T, opts ...interface{}) (ethtypes.EthHash, error) {
+		blockTime := 100 * time.Millisecond
+		client, _, ens := kit.EnsembleMinimal(t, append([]interface{}{kit.MockProofs(), kit.ThroughRPC()}, opts...)...)
+
+		ens.InterconnectAll().BeginMining(blockTime)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		key, _, deployer := client.EVM().NewAccount()
+		kit.SendFunds(ctx, t, client, deployer, types.FromFil(1000))
+
+		_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/Errors.hex")
+		contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+		require.NoError(t, err)
+
+		maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+		require.NoError(t, err)
+
+		tx := ethtypes.Eth1559TxArgs{
+			ChainID:              buildconstants.Eip155ChainId,
+			Nonce:                0,
+			To:                   &contractAddrEth,
+			Value:                big.Zero(),
+			Input:                kit.CalcFuncSignature("failRevertReason()"),
+			MaxFeePerGas:         types.NanoFil,
+			MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+			GasLimit:             100_000_000,
+			V:                    big.Zero(),
+			R:                    big.Zero(),
+			S:                    big.Zero(),
+		}
+		client.EVM().SignTransaction(&tx, key.PrivateKey)
+		signed, err := tx.ToRlpSignedMsg()
+		require.NoError(t, err)
+
+		return client.EVM().EthSendRawTransaction(ctx, signed)
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		// The transaction is admitted, and reverts once included.
+		_, err := sendReverting(t)
+		require.NoError(t, err)
+	})
+
+	t.Run("Simulated", func(t *testing.T) {
+		_, err := sendReverting(t, kit.EnableEthSendRawTransactionSimulation())
+
+		var reverted *api.ErrExecutionReverted
+		require.ErrorAs(t, err, &reverted)
+		require.Contains(t, reverted.Message, "my reason")
+	})
+}
+
+func TestEthSendRawTransactionDuplicate(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+	miners := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	contractHex, err := os.ReadFile("./contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	key, ethAddr, deployer := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, deployer, types.FromFil(10))
+	tx, err := deployContractTx(ctx, client, ethAddr, contract)
+	require.NoError(t, err)
+	client.EVM().SignTransaction(tx, key.PrivateKey)
+	signed, err := tx.ToRlpSignedMsg()
+	require.NoError(t, err)
+
+	// Keep the transaction pending while it is submitted again.
+	for _, m := range miners {
+		m.Pause()
+	}
+	hash, err := client.EthSendRawTransaction(ctx, signed)
+	require.NoError(t, err)
+	again, err := client.EthSendRawTransaction(ctx, signed)
+	require.NoError(t, err)
+	require.Equal(t, hash, again)
+
+	pending, err := client.MpoolPending(ctx, types.EmptyTSK)
+	require.NoError(t, err)
+	var fromDeployer int
+	for _, m := range pending {
+		if m.Message.From == deployer {
+			fromDeployer++
+		}
+	}
+	require.Equal(t, 1, fromDeployer)
+
+	for _, m := range miners {
+		m.Restart()
+	}
+	receipt, err := client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(0x1), receipt.Status)
+}
+
+func TestEthSendRawTransactionNonceErrors(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	key, _, sender := client.EVM().NewAccount()
+	_, receiver, _ := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, sender, types.FromFil(1000))
+
+	maxPriorityFeePerGas, err := client.EthMaxPriorityFeePerGas(ctx)
+	require.NoError(t, err)
+
+	transfer := func(nonce int, value int64) ethtypes.EthBytes {
+		tx := ethtypes.Eth1559TxArgs{
+			ChainID:              buildconstants.Eip155ChainId,
+			Nonce:                nonce,
+			To:                   &receiver,
+			Value:                big.NewInt(value),
+			MaxFeePerGas:         types.NanoFil,
+			MaxPriorityFeePerGas: big.Int(maxPriorityFeePerGas),
+			GasLimit:             10_000_000,
+			V:                    big.Zero(),
+			R:                    big.Zero(),
+			S:                    big.Zero(),
+		}
+		client.EVM().SignTransaction(&tx, key.PrivateKey)
+		signed, err := tx.ToRlpSignedMsg()
+		require.NoError(t, err)
+		return signed
+	}
+
+	hash, err := client.EVM().EthSendRawTransaction(ctx, transfer(0, 100))
+	require.NoError(t, err)
+	_, err = client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+
+	// The nonce was used by the transaction above.
+	_, err = client.EVM().EthSendRawTransaction(ctx, transfer(0, 200))
+	require.ErrorContains(t, err, "nonce too low")
+	_, err = client.EVM().EthSendRawTransactionUntrusted(ctx, transfer(0, 200))
+	require.ErrorContains(t, err, "nonce too low")
+
+	// Untrusted transactions can't leave a gap, others can only leave a small one.
+	_, err = client.EVM().EthSendRawTransactionUntrusted(ctx, transfer(2, 100))
+	require.ErrorContains(t, err, "nonce too high")
+	_, err = client.EVM().EthSendRawTransaction(ctx, transfer(1+int(messagepool.MaxNonceGap)+1, 100))
+	require.ErrorContains(t, err, "nonce too high")
+}
@@ -4,21 +4,26 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/go-state-types/manifest"
 	"github.com/filecoin-project/go-state-types/network"
@@ -27,6 +32,7 @@ import (
 	"github.com/filecoin-project/lotus/build"
 	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/consensus/filcns"
+	lrand "github.com/filecoin-project/lotus/chain/rand"
 	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/types"
@@ -929,6 +935,30 @@ func TestFEVMGetBlockDifficulty(t *testing.T) {
 	require.Equal(t, len(ret), 32)
 }
 
+// The DIFFICULTY opcode is implemented by the EVM actor, which always gives it its post-merge
+// PREVRANDAO semantics, so eth_call can't offer a fork override switching to pre-merge semantics.
+// This checks that calls see a random value rather than a difficulty.
+func TestEthCallBlockDifficultyIsPrevRandao(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/GetDifficulty.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+		To:   &contractAddrEth,
+		Data: kit.CalcFuncSignature("getDifficulty()"),
+	}})
+	require.NoError(t, err)
+	res, err := client.EthCall(ctx, callParams)
+	require.NoError(t, err)
+	require.Len(t, res, 32)
+
+	// Pre-merge difficulties fit in far fewer bits than a random 256-bit value.
+	require.Greater(t, big.PositiveFromUnsignedBytes(res).BitLen(), 64)
+}
+
 func TestFEVMTestCorrectChainID(t *testing.T) {
 	ctx, cancel, client := kit.SetupFEVMTest(t)
 	defer cancel()
@@ -1041,10 +1071,13 @@ func TestFEVMErrorParsing(t *testing.T) {
 		t.Run(sig, func(t *testing.T) {
 			entryPoint := kit.CalcFuncSignature(sig)
 			t.Run("EthCall", func(t *testing.T) {
-				_, err = e.EthCall(ctx, ethtypes.EthCall{
+				callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
 					To:   &contractAddrEth,
 					Data: entryPoint,
-				}, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+				}})
+				require.NoError(t, err)
+
+				_, err = e.EthCall(ctx, callParams)
 				require.Error(t, err)
 
 				var dataErr *api.ErrExecutionReverted
@@ -1068,6 +1101,88 @@ func TestFEVMErrorParsing(t *testing.T) {
 	}
 }
 
+func TestFEVMNestedRevertReason(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	e := client.EVM()
+
+	// The inner contract reverts with Error("inner failure"), the outer one calls the contract
+	// passed to it and bubbles up its revert data.
+	_, innerAddr := e.DeployContractFromFilename(ctx, "contracts/revertreason.bin")
+	innerAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(innerAddr)
+	require.NoError(t, err)
+	_, outerAddr := e.DeployContractFromFilename(ctx, "contracts/bubblerevert.bin")
+	outerAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(outerAddr)
+	require.NoError(t, err)
+
+	reason := "inner failure"
+	expectedData := "0x08c379a0" + // Error(string)
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		fmt.Sprintf("%064x", len(reason)) +
+		hex.EncodeToString([]byte(reason)) + strings.Repeat("00", 32-len(reason))
+
+	input := make([]byte, 32)
+	copy(input[12:], innerAddrEth[:])
+
+	for name, to := range map[string]ethtypes.EthAddress{
+		"direct": innerAddrEth,
+		"nested": outerAddrEth,
+	} {
+		to := to
+		t.Run(name, func(t *testing.T) {
+			callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+				To:   &to,
+				Data: input,
+			}})
+			require.NoError(t, err)
+
+			_, err = e.EthCall(ctx, callParams)
+			var dataErr *api.ErrExecutionReverted
+			require.ErrorAs(t, err, &dataErr)
+			require.Equal(t, expectedData, dataErr.Data)
+			require.Contains(t, dataErr.Message, "revert reason=[Error(inner failure)]")
+
+			gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+				To:   &to,
+				Data: input,
+			}})
+			require.NoError(t, err)
+
+			_, err = e.EthEstimateGas(ctx, gasParams)
+			require.ErrorAs(t, err, &dataErr)
+			require.Equal(t, expectedData, dataErr.Data)
+		})
+	}
+}
+
+func TestFEVMRevertedTransactionReceiptLogs(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract emits an event before reverting.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/logrevert.bin")
+
+	wait, err := client.EVM().InvokeSolidity(ctx, fromAddr, contractAddr, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, exitcode.ExitCode(33), wait.Receipt.ExitCode) // EVM_CONTRACT_REVERTED
+	require.Nil(t, wait.Receipt.EventsRoot)
+
+	hash, err := client.EthGetTransactionHashByCid(ctx, wait.Message)
+	require.NoError(t, err)
+	require.NotNil(t, hash)
+
+	receipt, err := client.EthGetTransactionReceipt(ctx, *hash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.EqualValues(t, ethtypes.EthUint64(0), receipt.Status)
+
+	// The logs are an empty array rather than null.
+	require.NotNil(t, receipt.Logs)
+	require.Empty(t, receipt.Logs)
+	require.Equal(t, ethtypes.EthBytes(ethtypes.NewEmptyEthBloom()), receipt.LogsBloom)
+}
+
 // TestEthGetBlockReceipts tests retrieving block receipts after invoking a contract
 func TestEthGetBlockReceipts(t *testing.T) {
 	blockTime := 500 * time.Millisecond
@@ -1332,6 +1447,34 @@ func TestEthGetTransactionCount(t *testing.T) {
 	require.Zero(t, contractNonceAfterDestroy)
 }
 
+func TestEthGetTransactionCountFactory(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, idAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/Create2Factory.hex")
+	factoryAddr, err := ethtypes.EthAddressFromFilecoinAddress(idAddr)
+	require.NoError(t, err)
+
+	requireNonce := func(expected uint64) {
+		for _, blk := range []string{"latest", "pending"} {
+			nonce, err := client.EVM().EthGetTransactionCount(ctx, factoryAddr, ethtypes.NewEthBlockNumberOrHashFromPredefined(blk))
+			require.NoError(t, err)
+			require.Equal(t, ethtypes.EthUint64(expected), nonce, "nonce at %s", blk)
+		}
+	}
+
+	// As in Ethereum, a contract starts with a nonce of 1, incremented by each contract it creates.
+	// The mpool only knows the nonces of accounts, so "pending" must also report the contract's.
+	requireNonce(1)
+	for i := uint64(1); i <= 3; i++ {
+		salt := make([]byte, 32)
+		salt[31] = byte(i)
+		_, _, err := client.EVM().InvokeContractByFuncName(ctx, fromAddr, idAddr, "deploy(bytes32)", salt)
+		require.NoError(t, err)
+		requireNonce(1 + i)
+	}
+}
+
 func TestMcopy(t *testing.T) {
 	// MCOPY introduced in nv24, start the test on nv23 to check the error, then upgrade at epoch 100
 	// and check that an MCOPY contract can be deployed and run.
@@ -1436,9 +1579,13 @@ func TestEthGetBlockByNumber(t *testing.T) {
 		}
 	}
 
-	// Test getting a block for a null round
-	_, err = client.EthGetBlockByNumber(ctx, (ethtypes.EthUint64(nullHeight)).Hex(), true)
-	require.ErrorContains(t, err, "requested epoch was a null round")
+	// Test getting a block for a null round, which resolves to the block preceding it
+	nullBlock, err := client.EthGetBlockByNumber(ctx, (ethtypes.EthUint64(nullHeight)).Hex(), true)
+	require.NoError(t, err)
+	prevTs, err := client.ChainGetTipSetByHeight(ctx, nullHeight, types.EmptyTSK)
+	require.NoError(t, err)
+	require.Less(t, prevTs.Height(), nullHeight)
+	require.Equal(t, ethtypes.EthUint64(prevTs.Height()), nullBlock.Number)
 
 	// Test getting balance on a null round
 	bal, err := client.EthGetBalance(ctx, ethAddr, ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(nullHeight)))
@@ -1453,6 +1600,163 @@ func TestEthGetBlockByNumber(t *testing.T) {
 	require.True(t, pendingBlock.Number >= latest)
 }
 
+func TestEthGetBlockByNumberAcrossNullRounds(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	bms := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	head := client.WaitTillChain(ctx, kit.HeightAtLeast(5))
+	bms[0].InjectNulls(5)
+	client.WaitTillChain(ctx, kit.HeightAtLeast(head.Height()+10))
+
+	latest, err := client.EthBlockNumber(ctx)
+	require.NoError(t, err)
+
+	// Every number up to eth_blockNumber resolves to a block. Null rounds resolve to the block
+	// preceding them, so the blocks still form a chain.
+	var nullRounds int
+	var prev ethtypes.EthBlock
+	for num := ethtypes.EthUint64(1); num <= latest; num++ {
+		blk, err := client.EthGetBlockByNumber(ctx, num.Hex(), false)
+		require.NoError(t, err, "block %d", num)
+
+		ts, err := client.ChainGetTipSetByHeight(ctx, abi.ChainEpoch(num), types.EmptyTSK)
+		require.NoError(t, err)
+		require.Equal(t, ethtypes.EthUint64(ts.Height()), blk.Number)
+
+		if blk.Number != num {
+			nullRounds++
+			require.Equal(t, prev.Hash, blk.Hash)
+		} else if num > 1 {
+			require.Equal(t, prev.Hash, blk.ParentHash)
+		}
+		prev = blk
+	}
+	require.GreaterOrEqual(t, nullRounds, 5)
+
+	latestBlock, err := client.EthGetBlockByNumber(ctx, "latest", false)
+	require.NoError(t, err)
+	require.Equal(t, latest, latestBlock.Number)
+}
+
+func TestEthCallAtNullRound(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	bms := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// SimpleCoin credits its deployer with 10000 coins.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	bms[0].InjectNulls(10)
+
+	tctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	ch, err := client.ChainNotify(tctx)
+	require.NoError(t, err)
+	<-ch
+	hc := <-ch
+	require.Equal(t, store.HCApply, hc[0].Type)
+
+	afterNullHeight := hc[0].Val.Height()
+
+	nullHeight := afterNullHeight - 1
+	for nullHeight > 0 {
+		ts, err := client.ChainGetTipSetByHeight(ctx, nullHeight, types.EmptyTSK)
+		require.NoError(t, err)
+		if ts.Height() == nullHeight {
+			nullHeight--
+		} else {
+			break
+		}
+	}
+
+	// Move coins away from the deployer after the null rounds.
+	receiver := make([]byte, 32)
+	receiver[31] = 1
+	_, _, err = client.EVM().InvokeContractByFuncName(ctx, fromAddr, contractAddr, "sendCoin(address,uint256)", append(receiver, paddedUint64(100)...))
+	require.NoError(t, err)
+
+	getBalance := func(blkParam ethtypes.EthBlockNumberOrHash) ethtypes.EthBytes {
+		data, err := hex.DecodeString("f8b2cb4f")
+		require.NoError(t, err)
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx:       ethtypes.EthCall{To: &contractAddrEth, Data: append(data, inputDataFromFrom(ctx, t, client, fromAddr)...)},
+			BlkParam: &blkParam,
+		})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	// A call at the null round uses the state of the tipset preceding it.
+	require.Equal(t, paddedUint64(10000), getBalance(ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(nullHeight))))
+	require.Equal(t, paddedUint64(9900), getBalance(ethtypes.NewEthBlockNumberOrHashFromPredefined("latest")))
+}
+
+func TestEthCallAtStateRoot(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// SimpleCoin credits its deployer with 10000 coins.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	// The state root the head was mined on is the state eth_call sees at the head's parent.
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	parent, err := client.ChainGetTipSet(ctx, head.Parents())
+	require.NoError(t, err)
+	stateRoot := head.ParentState()
+
+	receiver := make([]byte, 32)
+	receiver[31] = 1
+	_, _, err = client.EVM().InvokeContractByFuncName(ctx, fromAddr, contractAddr, "sendCoin(address,uint256)", append(receiver, paddedUint64(100)...))
+	require.NoError(t, err)
+
+	getBalance := ethtypes.EthCall{
+		To:   &contractAddrEth,
+		Data: append(kit.CalcFuncSignature("getBalance(address)"), inputDataFromFrom(ctx, t, client, fromAddr)...),
+	}
+	callAtStateRoot := func(root cid.Cid) (ethtypes.EthBytes, error) {
+		params, err := json.Marshal(ethtypes.EthCallAtStateRootParams{Tx: getBalance, StateRoot: root})
+		require.NoError(t, err)
+		return client.EthCallAtStateRoot(ctx, params)
+	}
+	callAtBlock := func(blkParam ethtypes.EthBlockNumberOrHash) ethtypes.EthBytes {
+		params, err := json.Marshal(ethtypes.EthCallParams{Tx: getBalance, BlkParam: &blkParam})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, params)
+		require.NoError(t, err)
+		return res
+	}
+
+	atRoot, err := callAtStateRoot(stateRoot)
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(10000), atRoot)
+	require.Equal(t, callAtBlock(ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(parent.Height()))), atRoot)
+
+	// The latest state has moved on.
+	require.Equal(t, paddedUint64(9900), callAtBlock(ethtypes.NewEthBlockNumberOrHashFromPredefined("latest")))
+
+	// Unknown state roots are rejected.
+	unknownRoot, err := abi.CidBuilder.Sum([]byte("not a state root"))
+	require.NoError(t, err)
+	_, err = callAtStateRoot(unknownRoot)
+	require.ErrorContains(t, err, "cannot load state root")
+}
+
 func TestEthGetTransactionByBlockHashAndIndexAndNumber(t *testing.T) {
 	ctx, cancel, client := kit.SetupFEVMTest(t)
 	defer cancel()
@@ -1593,21 +1897,28 @@ func TestEthCall(t *testing.T) {
 	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
 	require.NoError(t, err)
 
-	callParams := ethtypes.EthCall{
+	tx := ethtypes.EthCall{
 		From: nil,
 		To:   &contractAddrEth,
 		Data: divideByZeroSignature,
 	}
 
 	t.Run("FailedToProcessBlockParam", func(t *testing.T) {
-		invalidBlockNumber := latestBlock + 1000
-		_, err = client.EthCall(ctx, callParams, ethtypes.NewEthBlockNumberOrHashFromNumber(invalidBlockNumber))
+		invalidBlockNumber := ethtypes.NewEthBlockNumberOrHashFromNumber(latestBlock + 1000)
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: tx, BlkParam: &invalidBlockNumber})
+		require.NoError(t, err)
+
+		_, err = client.EthCall(ctx, callParams)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "requested a future epoch (beyond 'latest')")
 	})
 
 	t.Run("DivideByZeroError", func(t *testing.T) {
-		_, err = client.EthCall(ctx, callParams, ethtypes.NewEthBlockNumberOrHashFromNumber(latestBlock))
+		blkParam := ethtypes.NewEthBlockNumberOrHashFromNumber(latestBlock)
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: tx, BlkParam: &blkParam})
+		require.NoError(t, err)
+
+		_, err = client.EthCall(ctx, callParams)
 		require.Error(t, err)
 
 		var dataErr *api.ErrExecutionReverted
@@ -1619,105 +1930,1910 @@ func TestEthCall(t *testing.T) {
 	})
 }
 
-func TestEthEstimateGas(t *testing.T) {
-	ctx, cancel, client := kit.SetupFEVMTest(t)
-	defer cancel()
+func TestEthCallToNonContractWithData(t *testing.T) {
+	selector := kit.CalcFuncSignature("getBalance(address)")
 
-	_, ethAddr, filAddr := client.EVM().NewAccount()
+	ethCall := func(ctx context.Context, client *kit.TestFullNode, tx ethtypes.EthCall) (ethtypes.EthBytes, error) {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: tx})
+		require.NoError(t, err)
+		return client.EthCall(ctx, callParams)
+	}
 
-	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+	t.Run("Default", func(t *testing.T) {
+		ctx, cancel, client := kit.SetupFEVMTest(t)
+		defer cancel()
 
-	filename := "contracts/Errors.hex"
-	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, filename)
+		_, ethAddr, filAddr := client.EVM().NewAccount()
+		kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
 
-	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
-	require.NoError(t, err)
+		res, err := ethCall(ctx, client, ethtypes.EthCall{To: &ethAddr, Data: selector})
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
 
-	testCases := []struct {
-		name           string
-		function       string
-		expectedError  string
-		expectedErrMsg interface{}
-	}{
-		{"DivideByZero", "failDivZero()", "0x4e487b710000000000000000000000000000000000000000000000000000000000000012", `message execution failed [\s\S]+\[DivideByZero\(\)\]`},
-		{"Assert", "failAssert()", "0x4e487b710000000000000000000000000000000000000000000000000000000000000001", `message execution failed [\s\S]+\[Assert\(\)\]`},
-		{"RevertWithReason", "failRevertReason()", fmt.Sprintf("%x", []byte("my reason")), `message execution failed [\s\S]+\[Error\(my reason\)\]`},
-		{"RevertEmpty", "failRevertEmpty()", "0x", `message execution failed [\s\S]+\[none\]`},
-	}
+	t.Run("StrictMode", func(t *testing.T) {
+		ctx, cancel, client := kit.SetupFEVMTest(t, kit.EnableEthCallStrictMode())
+		defer cancel()
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			signature := kit.CalcFuncSignature(tc.function)
+		_, ethAddr, filAddr := client.EVM().NewAccount()
+		kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
 
-			callParams := ethtypes.EthCall{
-				From: &ethAddr,
-				To:   &contractAddrEth,
-				Data: signature,
-			}
+		_, err := ethCall(ctx, client, ethtypes.EthCall{To: &ethAddr, Data: selector})
+		require.ErrorContains(t, err, "call to non-contract with data")
 
-			gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: callParams})
-			require.NoError(t, err, "Error marshaling gas params")
+		// Calls without data are plain value transfers and remain allowed.
+		res, err := ethCall(ctx, client, ethtypes.EthCall{To: &ethAddr})
+		require.NoError(t, err)
+		require.Empty(t, res)
 
-			_, err = client.EthEstimateGas(ctx, gasParams)
+		// Calls to deployed contracts are unaffected.
+		_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+		contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+		require.NoError(t, err)
 
-			if tc.expectedError != "" {
-				require.Error(t, err)
-				var dataErr *api.ErrExecutionReverted
-				require.ErrorAs(t, err, &dataErr, "Expected error to be ErrExecutionReverted")
-				require.Regexp(t, tc.expectedErrMsg, dataErr.Message)
-				require.Contains(t, dataErr.Data, tc.expectedError)
-			}
-		})
-	}
+		res, err = ethCall(ctx, client, ethtypes.EthCall{To: &contractAddrEth, Data: append(selector, make([]byte, 32)...)})
+		require.NoError(t, err)
+		require.Len(t, res, 32)
+	})
 }
 
-func TestEthNullRoundHandling(t *testing.T) {
-	blockTime := 100 * time.Millisecond
-	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
-
-	bms := ens.InterconnectAll().BeginMining(blockTime)
-
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+func TestEthCallToBuiltinActorWithData(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
 	defer cancel()
 
-	client.WaitTillChain(ctx, kit.HeightAtLeast(10))
+	miners, err := client.StateListMiners(ctx, types.EmptyTSK)
+	require.NoError(t, err)
+	require.NotEmpty(t, miners)
+	minerID, err := client.StateLookupID(ctx, miners[0], types.EmptyTSK)
+	require.NoError(t, err)
+	minerAddr, err := ethtypes.EthAddressFromFilecoinAddress(minerID)
+	require.NoError(t, err)
 
-	bms[0].InjectNulls(10)
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+		To:   &minerAddr,
+		Data: kit.CalcFuncSignature("getBalance(address)"),
+	}})
+	require.NoError(t, err)
+	_, err = client.EthCall(ctx, callParams)
+	require.ErrorContains(t, err, "target is not an EVM contract")
+}
 
-	tctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+func TestEthCallStateOverrideNonceCreate(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
 	defer cancel()
-	ch, err := client.ChainNotify(tctx)
-	require.NoError(t, err)
-	<-ch
-	hc := <-ch
-	require.Equal(t, store.HCApply, hc[0].Type)
 
-	afterNullHeight := hc[0].Val.Height()
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
 
-	nullHeight := afterNullHeight - 1
-	for nullHeight > 0 {
-		ts, err := client.ChainGetTipSetByHeight(ctx, nullHeight, types.EmptyTSK)
+	// This init code reverts with the address it is being deployed to.
+	contractHex, err := os.ReadFile("contracts/revertaddress.bin")
+	require.NoError(t, err)
+	initCode, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	createAddress := func(overrides ethtypes.EthStateOverrides) string {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				From: &ethAddr,
+				Data: initCode,
+			},
+			StateOverrides: overrides,
+		})
 		require.NoError(t, err)
-		if ts.Height() == nullHeight {
-			nullHeight--
-		} else {
-			break
-		}
+
+		_, err = client.EthCall(ctx, callParams)
+		var dataErr *api.ErrExecutionReverted
+		require.ErrorAs(t, err, &dataErr)
+		return dataErr.Data
 	}
 
-	nullBlockHex := fmt.Sprintf("0x%x", int(nullHeight))
-	client.WaitTillChain(ctx, kit.HeightAtLeast(nullHeight+2))
-	testCases := []struct {
-		name     string
-		testFunc func() error
+	expected := func(nonce uint64) string {
+		addr := client.EVM().ComputeContractAddress(ethAddr, nonce)
+		return "0x" + strings.Repeat("00", 12) + hex.EncodeToString(addr[:])
+	}
+
+	require.Equal(t, expected(0), createAddress(nil))
+
+	nonce := ethtypes.EthUint64(42)
+	require.Equal(t, expected(42), createAddress(ethtypes.EthStateOverrides{
+		ethAddr: {Nonce: &nonce},
+	}))
+}
+
+func TestEthCallReentrancy(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract calls back into itself, and returns what the reentrant call read of the state
+	// written before reentering.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/reentrant.bin")
+	contract, err := client.StateGetActor(ctx, contractAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(*contract.DelegatedAddress)
+	require.NoError(t, err)
+
+	callParams, err := json.Marshal(ethtypes.EthCallParams{
+		Tx: ethtypes.EthCall{To: &contractAddrEth},
+	})
+	require.NoError(t, err)
+
+	res, err := client.EthCall(ctx, callParams)
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(1), res)
+
+	// Neither write persisted.
+	latest := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	for _, slot := range []uint64{0, 1} {
+		value, err := client.EVM().EthGetStorageAt(ctx, contractAddrEth, paddedUint64(slot), latest)
+		require.NoError(t, err)
+		require.Equal(t, ethtypes.EthBytes(make([]byte, 32)), value, "slot %d", slot)
+	}
+}
+
+func TestEthCallOrigin(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract returns tx.origin and msg.sender.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/origin.bin")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	_, sender, _ := client.EVM().NewAccount()
+	_, origin, _ := client.EVM().NewAccount()
+	word := func(addr ethtypes.EthAddress) []byte {
+		return append(make([]byte, 12), addr[:]...)
+	}
+	call := func(origin *ethtypes.EthAddress) ethtypes.EthBytes {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From:   &sender,
+			To:     &contractAddrEth,
+			Origin: origin,
+		}})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	// The origin defaults to the sender.
+	require.Equal(t, ethtypes.EthBytes(append(word(sender), word(sender)...)), call(nil))
+	require.Equal(t, ethtypes.EthBytes(append(word(sender), word(sender)...)), call(&sender))
+
+	// The sender remains msg.sender when the origin is overridden.
+	require.Equal(t, ethtypes.EthBytes(append(word(origin), word(sender)...)), call(&origin))
+}
+
+func TestEthCallNonce(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	// This init code reverts with the address it is being deployed to.
+	contractHex, err := os.ReadFile("contracts/revertaddress.bin")
+	require.NoError(t, err)
+	initCode, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	call := func(nonce *ethtypes.EthUint64, overrides ethtypes.EthStateOverrides) error {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				From:  &ethAddr,
+				Data:  initCode,
+				Nonce: nonce,
+			},
+			StateOverrides: overrides,
+		})
+		require.NoError(t, err)
+
+		_, err = client.EthCall(ctx, callParams)
+		return err
+	}
+
+	expected := func(nonce uint64) string {
+		addr := client.EVM().ComputeContractAddress(ethAddr, nonce)
+		return "0x" + strings.Repeat("00", 12) + hex.EncodeToString(addr[:])
+	}
+
+	// The contract is created at the address derived from the nonce of the call.
+	nonce := ethtypes.EthUint64(7)
+	err = call(&nonce, nil)
+	var dataErr *api.ErrExecutionReverted
+	require.ErrorAs(t, err, &dataErr)
+	require.Equal(t, expected(7), dataErr.Data)
+
+	// It may be repeated in a state override of the sender, but not contradicted.
+	err = call(&nonce, ethtypes.EthStateOverrides{ethAddr: {Nonce: &nonce}})
+	require.ErrorAs(t, err, &dataErr)
+	require.Equal(t, expected(7), dataErr.Data)
+
+	other := ethtypes.EthUint64(8)
+	err = call(&nonce, ethtypes.EthStateOverrides{ethAddr: {Nonce: &other}})
+	require.ErrorContains(t, err, "conflicts with the nonce override of its sender")
+
+	// The nonce of the sender isn't changed.
+	actor, err := client.StateGetActor(ctx, filAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	require.Zero(t, actor.Nonce)
+}
+
+func TestEthCallStateOverrideMultipleCode(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// Neither contract exists on chain, both implementations are injected by the call.
+	_, consumerAddr, _ := client.EVM().NewAccount()
+	_, oracleAddr, _ := client.EVM().NewAccount()
+
+	// The consumer returns twice the price reported by the oracle passed to it.
+	contractHex, err := os.ReadFile("contracts/oracleconsumer.bin")
+	require.NoError(t, err)
+	consumerBin, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+	consumerCode := ethtypes.EthBytes(consumerBin[12:]) // skip the init code
+
+	// The oracle returns its price.
+	oracleCode := func(price byte) *ethtypes.EthBytes {
+		code := ethtypes.EthBytes{0x60, price, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+		return &code
+	}
+
+	input := make([]byte, 32)
+	copy(input[12:], oracleAddr[:])
+
+	callConsumer := func(overrides ethtypes.EthStateOverrides) ethtypes.EthBytes {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				To:   &consumerAddr,
+				Data: input,
+			},
+			StateOverrides: overrides,
+		})
+		require.NoError(t, err)
+
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	require.Equal(t, paddedUint64(42), callConsumer(ethtypes.EthStateOverrides{
+		consumerAddr: {Code: &consumerCode},
+		oracleAddr:   {Code: oracleCode(21)},
+	}))
+
+	// Each override only affects its own address.
+	require.Equal(t, paddedUint64(14), callConsumer(ethtypes.EthStateOverrides{
+		consumerAddr: {Code: &consumerCode},
+		oracleAddr:   {Code: oracleCode(7)},
+	}))
+
+	// Nothing persisted.
+	for _, addr := range []ethtypes.EthAddress{consumerAddr, oracleAddr} {
+		code, err := client.EVM().EthGetCode(ctx, addr, ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest))
+		require.NoError(t, err)
+		require.Empty(t, code)
+	}
+}
+
+func TestEthCallStateOverrideStubContract(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// This contract returns twice the price reported by the oracle passed to it.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/oracleconsumer.bin")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	// The oracle doesn't exist on chain.
+	_, oracleAddr, _ := client.EVM().NewAccount()
+	input := make([]byte, 32)
+	copy(input[12:], oracleAddr[:])
+
+	callConsumer := func(overrides ethtypes.EthStateOverrides) ethtypes.EthBytes {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				To:   &contractAddrEth,
+				Data: input,
+			},
+			StateOverrides: overrides,
+		})
+		require.NoError(t, err)
+
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	price := paddedUint64(1234)
+	require.Equal(t, paddedUint64(0), callConsumer(nil))
+
+	// Stub the oracle to return a fixed price.
+	require.Equal(t, paddedUint64(2468), callConsumer(ethtypes.EthStateOverrides{
+		oracleAddr: {ReturnData: &price},
+	}))
+
+	// The same, providing the stub's code directly.
+	stub := ethtypes.EthBytes(append([]byte{0x60, 0x20, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x20, 0x60, 0x00, 0xf3}, price...))
+	require.Equal(t, paddedUint64(2468), callConsumer(ethtypes.EthStateOverrides{
+		oracleAddr: {Code: &stub},
+	}))
+
+	// Overrides are only applied to the call.
+	require.Equal(t, paddedUint64(0), callConsumer(nil))
+}
+
+func TestEthCallStateOverrideStorage(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// SimpleCoin credits its deployer with 10000 coins.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	deployer := inputDataFromFrom(ctx, t, client, fromAddr)
+	_, holderAddr, _ := client.EVM().NewAccount()
+	holder := make([]byte, 32)
+	copy(holder[12:], holderAddr[:])
+
+	// Balances are a mapping at slot 0, so the balance of an address is stored at
+	// keccak256(address . 0).
+	holderSlot := ethtypes.EthHashFromTxBytes(append(holder, make([]byte, 32)...))
+	slots := map[ethtypes.EthHash]ethtypes.EthHash{holderSlot: ethtypes.EthHash(paddedUint64(42))}
+
+	getBalance := func(account []byte, overrides ethtypes.EthStateOverrides) (ethtypes.EthBytes, error) {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				To:   &contractAddrEth,
+				Data: append(kit.CalcFuncSignature("getBalance(address)"), account...),
+			},
+			StateOverrides: overrides,
+		})
+		require.NoError(t, err)
+		return client.EthCall(ctx, callParams)
+	}
+	requireBalance := func(expected uint64, account []byte, overrides ethtypes.EthStateOverrides) {
+		res, err := getBalance(account, overrides)
+		require.NoError(t, err)
+		require.Equal(t, paddedUint64(expected), res)
+	}
+
+	requireBalance(10000, deployer, nil)
+	requireBalance(0, holder, nil)
+
+	// StateDiff only replaces the listed slots.
+	stateDiff := ethtypes.EthStateOverrides{contractAddrEth: {StateDiff: slots}}
+	requireBalance(42, holder, stateDiff)
+	requireBalance(10000, deployer, stateDiff)
+
+	// State replaces the whole storage, wiping the deployer's balance.
+	state := ethtypes.EthStateOverrides{contractAddrEth: {State: slots}}
+	requireBalance(42, holder, state)
+	requireBalance(0, deployer, state)
+
+	_, err = getBalance(holder, ethtypes.EthStateOverrides{contractAddrEth: {State: slots, StateDiff: slots}})
+	require.ErrorContains(t, err, "state and stateDiff can't both be overridden")
+
+	// Overrides are only applied to the call.
+	requireBalance(10000, deployer, nil)
+	requireBalance(0, holder, nil)
+}
+
+func TestEthCallStateOverrideClearCode(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	callParams := func(overrides ethtypes.EthStateOverrides) jsonrpc.RawParams {
+		params, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				To:   &contractAddrEth,
+				Data: append(kit.CalcFuncSignature("getBalance(address)"), inputDataFromFrom(ctx, t, client, fromAddr)...),
+			},
+			StateOverrides: overrides,
+		})
+		require.NoError(t, err)
+		return params
+	}
+
+	res, err := client.EthCall(ctx, callParams(nil))
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(10000), res)
+
+	// Without code, the contract behaves like an account: the call succeeds without returning anything.
+	empty := ethtypes.EthBytes{}
+	res, err = client.EthCall(ctx, callParams(ethtypes.EthStateOverrides{contractAddrEth: {Code: &empty}}))
+	require.NoError(t, err)
+	require.Empty(t, res)
+
+	// Overrides are only applied to the call.
+	res, err = client.EthCall(ctx, callParams(nil))
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(10000), res)
+}
+
+func TestEthCallConcurrentIsolation(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+	deployer := inputDataFromFrom(ctx, t, client, fromAddr)
+
+	padded := func(ethAddr ethtypes.EthAddress) []byte {
+		return append(make([]byte, 12), ethAddr[:]...)
+	}
+	getBalance := func(account []byte) ethtypes.EthCall {
+		return ethtypes.EthCall{
+			To:   &contractAddrEth,
+			Data: append(kit.CalcFuncSignature("getBalance(address)"), account...),
+		}
+	}
+
+	// Each simulated transfer is made by a synthetic sender, which is credited through a storage
+	// override and sends part of its coins to its own recipient.
+	const calls = 64
+	senders := make([]ethtypes.EthAddress, calls)
+	recipients := make([]ethtypes.EthAddress, calls)
+	for i := range senders {
+		_, senders[i], _ = client.EVM().NewAccount()
+		_, recipients[i], _ = client.EVM().NewAccount()
+	}
+
+	var eg errgroup.Group
+	for i := 0; i < calls; i++ {
+		eg.Go(func() error {
+			if i%2 == 0 {
+				params, err := json.Marshal(ethtypes.EthCallParams{Tx: getBalance(deployer)})
+				if err != nil {
+					return err
+				}
+				res, err := client.EthCall(ctx, params)
+				if err != nil {
+					return fmt.Errorf("read %d: %w", i, err)
+				}
+				if !bytes.Equal(paddedUint64(10000), res) {
+					return fmt.Errorf("read %d: unexpected deployer balance %s", i, res)
+				}
+				return nil
+			}
+
+			sender, recipient := senders[i], recipients[i]
+			senderSlot := ethtypes.EthHashFromTxBytes(append(padded(sender), make([]byte, 32)...))
+			transfer := ethtypes.EthCall{
+				From: &sender,
+				To:   &contractAddrEth,
+				Data: append(append(kit.CalcFuncSignature("sendCoin(address,uint256)"), padded(recipient)...), paddedUint64(uint64(i))...),
+			}
+			params, err := json.Marshal(ethtypes.EthCallManyParams{
+				Calls: []ethtypes.EthCall{transfer, getBalance(padded(recipient)), getBalance(padded(sender))},
+				StateOverrides: ethtypes.EthStateOverrides{contractAddrEth: {
+					StateDiff: map[ethtypes.EthHash]ethtypes.EthHash{senderSlot: ethtypes.EthHash(paddedUint64(uint64(2 * i)))},
+				}},
+			})
+			if err != nil {
+				return err
+			}
+			res, err := client.EthCallMany(ctx, params)
+			if err != nil {
+				return fmt.Errorf("transfer %d: %w", i, err)
+			}
+			expected := []ethtypes.EthBytes{paddedUint64(1), paddedUint64(uint64(i)), paddedUint64(uint64(i))}
+			for j, r := range res {
+				if r.Error != "" || !bytes.Equal(expected[j], r.ReturnData) {
+					return fmt.Errorf("transfer %d: unexpected result of call %d: %s %s", i, j, r.ReturnData, r.Error)
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	// None of the simulated transfers persisted, nor were their senders created.
+	callBalance := func(account []byte) ethtypes.EthBytes {
+		params, err := json.Marshal(ethtypes.EthCallParams{Tx: getBalance(account)})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, params)
+		require.NoError(t, err)
+		return res
+	}
+	require.Equal(t, paddedUint64(10000), callBalance(deployer))
+	for i := 1; i < calls; i += 2 {
+		require.Equal(t, paddedUint64(0), callBalance(padded(senders[i])))
+		require.Equal(t, paddedUint64(0), callBalance(padded(recipients[i])))
+
+		senderAddr, err := senders[i].ToFilecoinAddress()
+		require.NoError(t, err)
+		_, err = client.StateGetActor(ctx, senderAddr, types.EmptyTSK)
+		require.ErrorContains(t, err, "actor not found")
+	}
+}
+
+func TestEthCallStateOverrideDelegateCall(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// This contract delegates calls to the implementation passed to it.
+	_, proxyAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/delegateproxy.bin")
+	proxyAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(proxyAddr)
+	require.NoError(t, err)
+
+	// An existing contract, whose code gets replaced.
+	_, existingAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/oracleconsumer.bin")
+	existingAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(existingAddr)
+	require.NoError(t, err)
+
+	// An address without any actor.
+	_, missingAddrEth, _ := client.EVM().NewAccount()
+
+	_, sender, _ := client.EVM().NewAccount()
+
+	// Returns the address it runs as, followed by its caller.
+	impl := ethtypes.EthBytes{
+		0x30,             // ADDRESS
+		0x60, 0x00, 0x52, // MSTORE at 0
+		0x33,             // CALLER
+		0x60, 0x20, 0x52, // MSTORE at 32
+		0x60, 0x40, 0x60, 0x00, 0xf3, // RETURN 64 bytes
+	}
+
+	// The injected code runs in the context of the proxy.
+	expected := make([]byte, 64)
+	copy(expected[12:32], proxyAddrEth[:])
+	copy(expected[44:64], sender[:])
+
+	for _, tc := range []struct {
+		name string
+		impl ethtypes.EthAddress
+	}{
+		{"existing contract", existingAddrEth},
+		{"missing actor", missingAddrEth},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input := make([]byte, 32)
+			copy(input[12:], tc.impl[:])
+
+			callParams, err := json.Marshal(ethtypes.EthCallParams{
+				Tx: ethtypes.EthCall{
+					From: &sender,
+					To:   &proxyAddrEth,
+					Data: input,
+				},
+				StateOverrides: ethtypes.EthStateOverrides{
+					tc.impl: {Code: &impl},
+				},
+			})
+			require.NoError(t, err)
+
+			res, err := client.EthCall(ctx, callParams)
+			require.NoError(t, err)
+			require.Equal(t, ethtypes.EthBytes(expected), res)
+		})
+	}
+}
+
+func TestEthCallDetailedCreate(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// Deploy the contract once to learn its runtime code.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/oracleconsumer.bin")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+	runtimeCode, err := client.EVM().EthGetCode(ctx, contractAddrEth, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+	require.NoError(t, err)
+	require.NotEmpty(t, runtimeCode)
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	contractHex, err := os.ReadFile("contracts/oracleconsumer.bin")
+	require.NoError(t, err)
+	initCode, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	create := func(overrides ethtypes.EthStateOverrides) *ethtypes.EthCallDetailedResult {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				From: &ethAddr,
+				Data: initCode,
+			},
+			StateOverrides: overrides,
+		})
+		require.NoError(t, err)
+
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	res := create(nil)
+	require.NotNil(t, res.CreatedAddress)
+	require.Equal(t, client.EVM().ComputeContractAddress(ethAddr, 0), *res.CreatedAddress)
+	require.Equal(t, runtimeCode, res.ReturnData)
+	require.NotNil(t, res.CodeSize)
+	require.EqualValues(t, len(res.ReturnData), *res.CodeSize)
+	require.NotNil(t, res.CodeHash)
+	require.Equal(t, ethtypes.EthHashFromTxBytes(res.ReturnData), *res.CodeHash)
+
+	nonce := ethtypes.EthUint64(42)
+	res = create(ethtypes.EthStateOverrides{ethAddr: {Nonce: &nonce}})
+	require.NotNil(t, res.CreatedAddress)
+	require.Equal(t, client.EVM().ComputeContractAddress(ethAddr, 42), *res.CreatedAddress)
+
+	// Calls to existing contracts return the call's return data, and no created address.
+	_, oracleAddr, _ := client.EVM().NewAccount()
+	input := make([]byte, 32)
+	copy(input[12:], oracleAddr[:])
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{To: &contractAddrEth, Data: input}})
+	require.NoError(t, err)
+	res, err = client.EthCallDetailed(ctx, callParams)
+	require.NoError(t, err)
+	require.Nil(t, res.CreatedAddress)
+	require.Nil(t, res.CodeSize)
+	require.Nil(t, res.CodeHash)
+	require.Equal(t, paddedUint64(0), res.ReturnData)
+}
+
+func TestEthCallCreateMaxCodeSize(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	// The init code returns size zero bytes from memory as the runtime code:
+	// PUSH2 size, PUSH1 0, RETURN.
+	create := func(size uint16) error {
+		initCode := []byte{0x61, byte(size >> 8), byte(size), 0x60, 0x00, 0xf3}
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From: &ethAddr,
+			Data: initCode,
+		}})
+		require.NoError(t, err)
+		_, err = client.EthCall(ctx, callParams)
+		return err
+	}
+
+	// EIP-170 limits the runtime code to 24576 bytes.
+	require.NoError(t, create(24576))
+	require.ErrorContains(t, create(24577), "max code size exceeded")
+}
+
+func TestEthCallDetailedSender(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	callFrom := func(sender ethtypes.EthAddress) string {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From: &sender,
+			To:   &contractAddrEth,
+			Data: append(kit.CalcFuncSignature("getBalance(address)"), make([]byte, 32)...),
+		}})
+		require.NoError(t, err)
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res.Sender
+	}
+
+	// The sender was never funded, so there is no actor for it.
+	_, missing, _ := client.EVM().NewAccount()
+	require.Equal(t, ethtypes.EthCallSenderSynthetic, callFrom(missing))
+
+	_, funded, fundedFil := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, fundedFil, types.FromFil(10))
+	require.Equal(t, ethtypes.EthCallSenderExisting, callFrom(funded))
+}
+
+func TestEthCallDetailedTouched(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	deploy := func(filename string) ethtypes.EthAddress {
+		_, idAddr := client.EVM().DeployContractFromFilename(ctx, filename)
+		actor, err := client.StateGetActor(ctx, idAddr, types.EmptyTSK)
+		require.NoError(t, err)
+		require.NotNil(t, actor.DelegatedAddress)
+		ethAddr, err := ethtypes.EthAddressFromFilecoinAddress(*actor.DelegatedAddress)
+		require.NoError(t, err)
+		return ethAddr
+	}
+	// The reader returns the size of the code of the account passed to it.
+	reader := deploy("contracts/codesize.bin")
+	target := deploy("contracts/SimpleCoin.hex")
+	untouched := deploy("contracts/valuereceiver.bin")
+
+	_, sender, _ := client.EVM().NewAccount()
+	input := make([]byte, 32)
+	copy(input[12:], target[:])
+	call := func(reportTouched bool) *ethtypes.EthCallDetailedResult {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From:          &sender,
+			To:            &reader,
+			Data:          input,
+			ReportTouched: reportTouched,
+		}})
+		require.NoError(t, err)
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	res := call(false)
+	require.NotEqual(t, paddedUint64(0), res.ReturnData)
+	require.Nil(t, res.Touched)
+
+	// Reading the target's code touches it.
+	res = call(true)
+	require.NotEqual(t, paddedUint64(0), res.ReturnData)
+	require.NotEmpty(t, res.Touched)
+	require.Equal(t, sender, res.Touched[0])
+	require.Contains(t, res.Touched, reader)
+	require.Contains(t, res.Touched, target)
+	require.NotContains(t, res.Touched, untouched)
+}
+
+// Precompiles are run by the EVM actor itself rather than by invoking another actor, so the FVM
+// doesn't trace their invocation and eth_callDetailed can't report it. This checks that calls to
+// them succeed without the precompile being listed among the touched addresses.
+func TestEthCallDetailedPrecompileNotTouched(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The caller returns the SHA-256 hash of its input, computed by the sha256 precompile.
+	_, idAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/sha256caller.bin")
+	actor, err := client.StateGetActor(ctx, idAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	require.NotNil(t, actor.DelegatedAddress)
+	caller, err := ethtypes.EthAddressFromFilecoinAddress(*actor.DelegatedAddress)
+	require.NoError(t, err)
+
+	_, sender, _ := client.EVM().NewAccount()
+	input := []byte("hello precompile")
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+		From:          &sender,
+		To:            &caller,
+		Data:          input,
+		ReportTouched: true,
+	}})
+	require.NoError(t, err)
+	res, err := client.EthCallDetailed(ctx, callParams)
+	require.NoError(t, err)
+
+	hash := sha256.Sum256(input)
+	require.Equal(t, ethtypes.EthBytes(hash[:]), res.ReturnData)
+
+	var sha256Precompile ethtypes.EthAddress
+	sha256Precompile[19] = 2
+	require.Contains(t, res.Touched, caller)
+	require.NotContains(t, res.Touched, sha256Precompile)
+}
+
+// The FVM doesn't trace balance lookups, so eth_callDetailed can't report the accounts whose
+// balance a call read with BALANCE. This checks that such an account isn't listed among the touched
+// addresses, as documented.
+func TestEthCallDetailedBalanceNotTouched(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The reader returns the balance of the account passed to it.
+	_, idAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/balance.bin")
+	actor, err := client.StateGetActor(ctx, idAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	require.NotNil(t, actor.DelegatedAddress)
+	reader, err := ethtypes.EthAddressFromFilecoinAddress(*actor.DelegatedAddress)
+	require.NoError(t, err)
+
+	_, account, accountAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, accountAddr, types.FromFil(1))
+
+	_, sender, _ := client.EVM().NewAccount()
+	input := make([]byte, 32)
+	copy(input[12:], account[:])
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+		From:          &sender,
+		To:            &reader,
+		Data:          input,
+		ReportTouched: true,
+	}})
+	require.NoError(t, err)
+	res, err := client.EthCallDetailed(ctx, callParams)
+	require.NoError(t, err)
+
+	balance := make([]byte, 32)
+	types.FromFil(1).Int.FillBytes(balance)
+	require.Equal(t, ethtypes.EthBytes(balance), res.ReturnData)
+	require.Contains(t, res.Touched, reader)
+	require.NotContains(t, res.Touched, account)
+}
+
+func TestEthCallDetailedGasLimit(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	_, sender, _ := client.EVM().NewAccount()
+	call := func(tx ethtypes.EthCall, overrides ethtypes.EthStateOverrides) *ethtypes.EthCallDetailedResult {
+		tx.From = &sender
+		tx.To = &contractAddrEth
+		tx.Data = append(kit.CalcFuncSignature("getBalance(address)"), make([]byte, 32)...)
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: tx, StateOverrides: overrides})
+		require.NoError(t, err)
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Calls that don't set a gas limit are applied with the block gas limit.
+	res := call(ethtypes.EthCall{}, nil)
+	require.EqualValues(t, buildconstants.BlockGasLimit, res.GasLimit)
+
+	// Affordable calls are capped to what the sender can pay for.
+	balance := ethtypes.EthBigInt(types.FromFil(1))
+	res = call(ethtypes.EthCall{
+		GasPrice:   ethtypes.EthBigInt(big.NewInt(100_000_000_000)),
+		Affordable: true,
+	}, ethtypes.EthStateOverrides{sender: {Balance: &balance}})
+	require.EqualValues(t, 10_000_000, res.GasLimit)
+}
+
+func TestEthCallDetailedSenderBalance(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, sender, senderFil := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, senderFil, types.FromFil(10))
+	_, recipient, _ := client.EVM().NewAccount()
+
+	value := types.FromFil(1)
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+		From:          &sender,
+		To:            &recipient,
+		Value:         ethtypes.EthBigInt(value),
+		ReportBalance: true,
+	}})
+	require.NoError(t, err)
+	res, err := client.EthCallDetailed(ctx, callParams)
+	require.NoError(t, err)
+
+	require.NotNil(t, res.SenderBalanceBefore)
+	require.NotNil(t, res.SenderBalanceAfter)
+	require.Equal(t, ethtypes.EthBigInt(types.FromFil(10)), *res.SenderBalanceBefore)
+	// Calls are applied without charging for gas, so the sender only pays for the value.
+	require.Equal(t, ethtypes.EthBigInt(big.Sub(types.FromFil(10), value)), *res.SenderBalanceAfter)
+}
+
+func TestEthCallDetailedLogs(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The deployer is credited with the initial supply of the coin.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractActor, err := client.StateGetActor(ctx, contractAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(*contractActor.DelegatedAddress)
+	require.NoError(t, err)
+	fromID, err := client.StateLookupID(ctx, fromAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	from, err := ethtypes.EthAddressFromFilecoinAddress(fromID)
+	require.NoError(t, err)
+	_, to, _ := client.EVM().NewAccount()
+
+	input := kit.CalcFuncSignature("sendCoin(address,uint256)")
+	input = append(input, make([]byte, 12)...)
+	input = append(input, to[:]...)
+	input = append(input, paddedUint64(42)...)
+
+	transfer := ethtypes.EthABIEvent{
+		Name: "Transfer",
+		Inputs: []ethtypes.EthABIParam{
+			{Name: "_from", Type: "address", Indexed: true},
+			{Name: "_to", Type: "address", Indexed: true},
+			{Name: "_value", Type: "uint256"},
+		},
+	}
+	call := func(eventsABI []ethtypes.EthABIEvent) *ethtypes.EthCallDetailedResult {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From:      &from,
+			To:        &contract,
+			Data:      input,
+			EventsABI: eventsABI,
+		}})
+		require.NoError(t, err)
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Without an ABI, the raw log is reported.
+	res := call(nil)
+	require.Len(t, res.Logs, 1)
+	require.Equal(t, contract, res.Logs[0].Address)
+	require.Len(t, res.Logs[0].Topics, 3)
+	require.Equal(t, transfer.Topic(), res.Logs[0].Topics[0])
+	require.Equal(t, paddedUint64(42), res.Logs[0].Data)
+	require.Empty(t, res.Logs[0].Event)
+	require.Nil(t, res.Logs[0].Fields)
+
+	// With one, its fields are decoded.
+	res = call([]ethtypes.EthABIEvent{transfer})
+	require.Len(t, res.Logs, 1)
+	require.Equal(t, "Transfer", res.Logs[0].Event)
+	require.Equal(t, map[string]any{
+		"_from":  from.String(),
+		"_to":    to.String(),
+		"_value": "0x2a",
+	}, res.Logs[0].Fields)
+
+	// The transfer isn't persisted.
+	balanceInput := kit.CalcFuncSignature("getBalance(address)")
+	balanceInput = append(balanceInput, make([]byte, 12)...)
+	balanceInput = append(balanceInput, to[:]...)
+	callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{To: &contract, Data: balanceInput}})
+	require.NoError(t, err)
+	balance, err := client.EthCall(ctx, callParams)
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(0), balance)
+}
+
+func TestEthCallDetailedStateChanged(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The deployer is credited with the initial supply of the coin.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractActor, err := client.StateGetActor(ctx, contractAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(*contractActor.DelegatedAddress)
+	require.NoError(t, err)
+	fromID, err := client.StateLookupID(ctx, fromAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	from, err := ethtypes.EthAddressFromFilecoinAddress(fromID)
+	require.NoError(t, err)
+	_, to, _ := client.EVM().NewAccount()
+
+	call := func(signature string, args ...[]byte) bool {
+		input := kit.CalcFuncSignature(signature)
+		input = append(input, make([]byte, 12)...)
+		input = append(input, to[:]...)
+		for _, arg := range args {
+			input = append(input, arg...)
+		}
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From: &from,
+			To:   &contract,
+			Data: input,
+		}})
+		require.NoError(t, err)
+		res, err := client.EthCallDetailed(ctx, callParams)
+		require.NoError(t, err)
+		return res.StateChanged
+	}
+
+	// Reading a balance changes nothing, even though the nonce of the sender is incremented.
+	require.False(t, call("getBalance(address)"))
+	// Transferring coins changes the storage of the contract.
+	require.True(t, call("sendCoin(address,uint256)", paddedUint64(42)))
+}
+
+func TestEthCallDetailedOutput(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The deployer is credited with the initial supply of the coin.
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractActor, err := client.StateGetActor(ctx, contractAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(*contractActor.DelegatedAddress)
+	require.NoError(t, err)
+	fromID, err := client.StateLookupID(ctx, fromAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	from, err := ethtypes.EthAddressFromFilecoinAddress(fromID)
+	require.NoError(t, err)
+
+	input := kit.CalcFuncSignature("getBalance(address)")
+	input = append(input, make([]byte, 12)...)
+	input = append(input, from[:]...)
+	call := func(outputTypes []string) (*ethtypes.EthCallDetailedResult, error) {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			To:          &contract,
+			Data:        input,
+			OutputTypes: outputTypes,
+		}})
+		require.NoError(t, err)
+		return client.EthCallDetailed(ctx, callParams)
+	}
+
+	res, err := call([]string{"uint256"})
+	require.NoError(t, err)
+	require.Len(t, res.ReturnData, 32)
+	balance := ethtypes.EthBigInt(big.PositiveFromUnsignedBytes(res.ReturnData))
+	require.True(t, big.Int(balance).GreaterThan(big.Zero()))
+	require.Equal(t, []any{balance.String()}, res.Output)
+
+	// Without output types, the return data isn't decoded.
+	res, err = call(nil)
+	require.NoError(t, err)
+	require.Nil(t, res.Output)
+
+	// The return data must hold a value of each type.
+	_, err = call([]string{"uint256", "bool"})
+	require.ErrorContains(t, err, "data too short")
+}
+
+func TestEthCallForwardsValue(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	deploy := func(filename string) ethtypes.EthAddress {
+		_, idAddr := client.EVM().DeployContractFromFilename(ctx, filename)
+		ethAddr, err := ethtypes.EthAddressFromFilecoinAddress(idAddr)
+		require.NoError(t, err)
+		return ethAddr
+	}
+	// The receiver records and returns the value it receives.
+	receiver := deploy("contracts/valuereceiver.bin")
+	inner := deploy("contracts/valueforwarder.bin")
+	outer := deploy("contracts/valueforwarder.bin")
+
+	// The outer forwarder calls the inner one, which calls the receiver, each forwarding the value
+	// it received.
+	input := make([]byte, 64)
+	copy(input[12:32], inner[:])
+	copy(input[44:64], receiver[:])
+
+	// The sender doesn't exist, so it is created for the call and funded by an override.
+	_, sender, _ := client.EVM().NewAccount()
+	balance := ethtypes.EthBigInt(types.FromFil(1))
+	callParams, err := json.Marshal(ethtypes.EthCallParams{
+		Tx: ethtypes.EthCall{
+			From:  &sender,
+			To:    &outer,
+			Value: ethtypes.EthBigInt(big.NewInt(1000)),
+			Data:  input,
+		},
+		StateOverrides: ethtypes.EthStateOverrides{sender: {Balance: &balance}},
+	})
+	require.NoError(t, err)
+	res, err := client.EthCall(ctx, callParams)
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(1000), res)
+
+	// Neither the recorded value nor the transfers are persisted.
+	latest := ethtypes.NewEthBlockNumberOrHashFromPredefined("latest")
+	value, err := client.EVM().EthGetStorageAt(ctx, receiver, nil, latest)
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.EthBytes(make([]byte, 32)), value)
+	for _, addr := range []ethtypes.EthAddress{outer, inner, receiver} {
+		bal, err := client.EVM().EthGetBalance(ctx, addr, latest)
+		require.NoError(t, err)
+		require.Zero(t, big.Int(bal).Sign())
+	}
+}
+
+func TestEthCallManyDeployThenInvoke(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	contractHex, err := os.ReadFile("contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	initCode, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	// The first call deploys SimpleCoin, which credits the deployer with 10000 coins, and the second
+	// one reads the deployer's balance from the contract it deployed.
+	contractAddr := client.EVM().ComputeContractAddress(ethAddr, 0)
+	getBalance, err := hex.DecodeString("f8b2cb4f")
+	require.NoError(t, err)
+	getBalance = append(getBalance, inputDataFromArray(ethAddr[:])...)
+
+	callParams, err := json.Marshal(ethtypes.EthCallManyParams{
+		Calls: []ethtypes.EthCall{
+			{From: &ethAddr, Data: initCode},
+			{From: &ethAddr, To: &contractAddr, Data: getBalance},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := client.EthCallMany(ctx, callParams)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	require.Empty(t, res[0].Error)
+	require.NotNil(t, res[0].CreatedAddress)
+	require.Equal(t, contractAddr, *res[0].CreatedAddress)
+
+	require.Empty(t, res[1].Error)
+	require.Nil(t, res[1].CreatedAddress)
+	require.Equal(t, paddedUint64(10000), res[1].ReturnData)
+
+	// The calls are simulated, so the contract was never deployed.
+	code, err := client.EVM().EthGetCode(ctx, contractAddr, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+	require.NoError(t, err)
+	require.Empty(t, code)
+}
+
+func TestEthCallManyTraceReverts(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, errorsAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/Errors.hex")
+	errorsAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(errorsAddr)
+	require.NoError(t, err)
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	contractHex, err := os.ReadFile("contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	initCode, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+	contractAddr := client.EVM().ComputeContractAddress(ethAddr, 0)
+	getBalance, err := hex.DecodeString("f8b2cb4f")
+	require.NoError(t, err)
+	getBalance = append(getBalance, inputDataFromArray(ethAddr[:])...)
+
+	// The second call of the bundle reverts, between a deployment and a call that succeed.
+	callParams, err := json.Marshal(ethtypes.EthCallManyParams{
+		Calls: []ethtypes.EthCall{
+			{From: &ethAddr, Data: initCode},
+			{From: &ethAddr, To: &errorsAddrEth, Data: kit.CalcFuncSignature("failRevertReason()")},
+			{From: &ethAddr, To: &contractAddr, Data: getBalance},
+		},
+		Options: ethtypes.EthCallManyOptions{TraceReverts: true},
+	})
+	require.NoError(t, err)
+
+	res, err := client.EthCallMany(ctx, callParams)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	require.Empty(t, res[0].Error)
+	require.Nil(t, res[0].Trace)
+	require.Empty(t, res[2].Error)
+	require.Nil(t, res[2].Trace)
+	require.Equal(t, paddedUint64(10000), res[2].ReturnData)
+
+	require.NotEmpty(t, res[1].Error)
+	require.NotNil(t, res[1].Trace)
+	require.Equal(t, "CALL", res[1].Trace.Type)
+	require.Equal(t, ethAddr, res[1].Trace.From)
+	require.Equal(t, errorsAddrEth, *res[1].Trace.To)
+	require.Equal(t, "Reverted", res[1].Trace.Error)
+	require.Contains(t, res[1].Trace.Output.String(), fmt.Sprintf("%x", []byte("my reason")))
+
+	// Without the option, reverted calls aren't traced.
+	callParams, err = json.Marshal(ethtypes.EthCallManyParams{
+		Calls: []ethtypes.EthCall{{From: &ethAddr, To: &errorsAddrEth, Data: kit.CalcFuncSignature("failRevertReason()")}},
+	})
+	require.NoError(t, err)
+	res, err = client.EthCallMany(ctx, callParams)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.NotEmpty(t, res[0].Error)
+	require.Nil(t, res[0].Trace)
+}
+
+// TestEthCallGethDifferential compares calls to SimpleCoin with the responses of a Geth node to
+// the same calls, after deploying SimpleCoin there.
+func TestEthCallGethDifferential(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	getBalance := func(addr []byte) []byte {
+		return append([]byte{0xf8, 0xb2, 0xcb, 0x4f}, addr...)
+	}
+	other := make([]byte, 32)
+	other[31] = 1
+
+	testCases := []struct {
+		name string
+		data []byte
+		geth string
+	}{{
+		name: "DeployerBalance",
+		data: getBalance(inputDataFromFrom(ctx, t, client, fromAddr)),
+		geth: `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000002710"}`,
+	}, {
+		name: "OtherBalance",
+		data: getBalance(other),
+		geth: `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000000"}`,
+	}, {
+		name: "UnknownMethod",
+		data: []byte{0xde, 0xad, 0xbe, 0xef},
+		geth: `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, err := kit.ParseGethCallOutcome([]byte(tc.geth))
+			require.NoError(t, err)
+
+			actual := client.EVM().EthCallGethOutcome(ctx, ethtypes.EthCall{To: &contractAddrEth, Data: tc.data}, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+			require.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestEthCallFromMissingSender(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/oracleconsumer.bin")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	// The sender was never funded, so there is no actor for it.
+	_, sender, senderFil := client.EVM().NewAccount()
+	_, err = client.StateGetActor(ctx, senderFil, types.EmptyTSK)
+	require.ErrorContains(t, err, "actor not found")
+
+	_, oracleAddr, _ := client.EVM().NewAccount()
+	input := make([]byte, 32)
+	copy(input[12:], oracleAddr[:])
+
+	call := func(value big.Int, gasPrice big.Int) (ethtypes.EthBytes, error) {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From:     &sender,
+			To:       &contractAddrEth,
+			Data:     input,
+			Value:    ethtypes.EthBigInt(value),
+			GasPrice: ethtypes.EthBigInt(gasPrice),
+		}})
+		require.NoError(t, err)
+		return client.EthCall(ctx, callParams)
+	}
+
+	// Calls are free, whatever the gas price.
+	res, err := call(big.Zero(), big.Zero())
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(0), res)
+
+	res, err = call(big.Zero(), types.NanoFil)
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(0), res)
+
+	// But the sender can't transfer funds it doesn't have.
+	_, err = call(big.NewInt(1), big.Zero())
+	require.Error(t, err)
+
+	// The sender isn't created by the call.
+	_, err = client.StateGetActor(ctx, senderFil, types.EmptyTSK)
+	require.ErrorContains(t, err, "actor not found")
+}
+
+func TestEthCallFromMissingSenderStrictMode(t *testing.T) {
+	call := func(ctx context.Context, t *testing.T, client *kit.TestFullNode) error {
+		_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+		contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+		require.NoError(t, err)
+
+		// The sender was never funded, so there is no actor for it.
+		_, sender, _ := client.EVM().NewAccount()
+
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From: &sender,
+			To:   &contractAddrEth,
+			Data: append(kit.CalcFuncSignature("getBalance(address)"), make([]byte, 32)...),
+		}})
+		require.NoError(t, err)
+		_, err = client.EthCall(ctx, callParams)
+		return err
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		ctx, cancel, client := kit.SetupFEVMTest(t)
+		defer cancel()
+
+		require.NoError(t, call(ctx, t, client))
+	})
+
+	t.Run("StrictMode", func(t *testing.T) {
+		ctx, cancel, client := kit.SetupFEVMTest(t, kit.EnableEthCallStrictMode())
+		defer cancel()
+
+		require.ErrorContains(t, call(ctx, t, client), "does not exist")
+	})
+}
+
+func TestEthCallAffordable(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	// The sender can only afford 100k gas at a gas price of 10^7 attoFIL, far less than the call
+	// needs.
+	_, sender, senderFil := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, senderFil, types.NewInt(1e12))
+
+	call := func(gasPrice int64, affordable bool) error {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			From:       &sender,
+			To:         &contractAddrEth,
+			GasPrice:   ethtypes.EthBigInt(big.NewInt(gasPrice)),
+			Data:       append(kit.CalcFuncSignature("getBalance(address)"), make([]byte, 32)...),
+			Affordable: affordable,
+		}})
+		require.NoError(t, err)
+		_, err = client.EthCall(ctx, callParams)
+		return err
+	}
+
+	// Calls are given the block gas limit by default.
+	require.NoError(t, call(1e7, false))
+
+	err = call(1e7, true)
+	var reverted *api.ErrExecutionReverted
+	require.ErrorAs(t, err, &reverted)
+	require.Contains(t, reverted.Message, "SysErrOutOfGas")
+
+	// At a lower gas price, the sender can afford the block gas limit.
+	require.NoError(t, call(1, true))
+}
+
+func TestEthCallAffordableBaseFeeOverride(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The receiver records and returns the value it receives.
+	_, receiverAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/valuereceiver.bin")
+	receiver, err := ethtypes.EthAddressFromFilecoinAddress(receiverAddr)
+	require.NoError(t, err)
+
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	realBaseFee := head.Blocks()[0].ParentBaseFee
+
+	// After the transfer, the sender can only afford 100k gas at the real base fee, far less than
+	// the call needs.
+	value := big.NewInt(1000)
+	_, sender, _ := client.EVM().NewAccount()
+	balance := ethtypes.EthBigInt(big.Add(value, big.Mul(realBaseFee, big.NewInt(100_000))))
+
+	maxFee := ethtypes.EthBigInt(types.FromFil(1))
+	priorityFee := ethtypes.EthBigInt(big.Zero())
+	call := func(blockOverrides *ethtypes.EthBlockOverrides) (ethtypes.EthBytes, error) {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				From:                 &sender,
+				To:                   &receiver,
+				Value:                ethtypes.EthBigInt(value),
+				MaxFeePerGas:         &maxFee,
+				MaxPriorityFeePerGas: &priorityFee,
+				Affordable:           true,
+			},
+			StateOverrides: ethtypes.EthStateOverrides{sender: {Balance: &balance}},
+			BlockOverrides: blockOverrides,
+		})
+		require.NoError(t, err)
+		return client.EthCall(ctx, callParams)
+	}
+
+	_, err = call(nil)
+	var reverted *api.ErrExecutionReverted
+	require.ErrorAs(t, err, &reverted)
+	require.Contains(t, reverted.Message, "SysErrOutOfGas")
+
+	// At a lower base fee, the sender can afford the call.
+	lowBaseFee := ethtypes.EthBigInt(big.NewInt(1))
+	res, err := call(&ethtypes.EthBlockOverrides{BaseFeePerGas: &lowBaseFee})
+	require.NoError(t, err)
+	require.Equal(t, paddedUint64(1000), res)
+}
+
+func TestEthCallFutureBlockOverride(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract returns the block number, timestamp and PREVRANDAO.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/blockinfo.bin")
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	call := func(blockOverrides *ethtypes.EthBlockOverrides) ethtypes.EthBytes {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx:             ethtypes.EthCall{To: &contract},
+			BlockOverrides: blockOverrides,
+		})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		require.Len(t, res, 96)
+		return res
+	}
+
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	// No randomness can be drawn from that far in the future, so the call draws that of the block
+	// it is simulated in.
+	number := ethtypes.EthUint64(head.Height() + 1_000_000)
+	timestamp := ethtypes.EthUint64(head.MinTimestamp() + 100_000_000)
+	res := call(&ethtypes.EthBlockOverrides{Number: &number, Time: &timestamp})
+	require.Equal(t, paddedUint64(uint64(number)), res[:32])
+	require.Equal(t, paddedUint64(uint64(timestamp)), res[32:64])
+	require.NotEqual(t, make([]byte, 32), []byte(res[64:]))
+
+	// The randomness can be overridden, from which PREVRANDAO is derived.
+	randao := ethtypes.EthHash{1}
+	overridden := call(&ethtypes.EthBlockOverrides{Number: &number, Time: &timestamp, PrevRandao: &randao})
+	require.Equal(t, res[:64], overridden[:64])
+	require.NotEqual(t, res[64:], overridden[64:])
+	require.Equal(t, overridden, call(&ethtypes.EthBlockOverrides{Number: &number, Time: &timestamp, PrevRandao: &randao}))
+
+	// The block number can't go back.
+	past := ethtypes.EthUint64(0)
+	callParams, err := json.Marshal(ethtypes.EthCallParams{
+		Tx:             ethtypes.EthCall{To: &contract},
+		BlockOverrides: &ethtypes.EthBlockOverrides{Number: &past},
+	})
+	require.NoError(t, err)
+	_, err = client.EthCall(ctx, callParams)
+	require.ErrorContains(t, err, "precedes the block the call is simulated in")
+}
+
+func TestEthCallGasLimitAndCoinbaseOverrides(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract returns the block number, timestamp and PREVRANDAO.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/blockinfo.bin")
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	call := func(blockOverrides *ethtypes.EthBlockOverrides) (*ethtypes.EthCallDetailedResult, error) {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx:             ethtypes.EthCall{To: &contract},
+			BlockOverrides: blockOverrides,
+		})
+		require.NoError(t, err)
+		return client.EthCallDetailed(ctx, callParams)
+	}
+
+	// The gas limit lowers the gas available to the call, alongside other block overrides.
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	gasLimit := ethtypes.EthUint64(100_000_000)
+	number := ethtypes.EthUint64(head.Height() + 100)
+	res, err := call(&ethtypes.EthBlockOverrides{GasLimit: &gasLimit, Number: &number})
+	require.NoError(t, err)
+	require.Equal(t, gasLimit, res.GasLimit)
+	require.Equal(t, paddedUint64(uint64(number)), []byte(res.ReturnData[:32]))
+
+	// Gas limits above the block gas limit don't raise it.
+	gasLimit = ethtypes.EthUint64(2 * buildconstants.BlockGasLimit)
+	res, err = call(&ethtypes.EthBlockOverrides{GasLimit: &gasLimit})
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.EthUint64(buildconstants.BlockGasLimit), res.GasLimit)
+
+	// The call runs out of gas under a low enough limit.
+	gasLimit = ethtypes.EthUint64(10_000)
+	_, err = call(&ethtypes.EthBlockOverrides{GasLimit: &gasLimit})
+	var reverted *api.ErrExecutionReverted
+	require.ErrorAs(t, err, &reverted)
+	require.Contains(t, reverted.Message, "SysErrOutOfGas")
+
+	// COINBASE always reports the zero address, which is the only coinbase accepted.
+	coinbase := ethtypes.EthAddress{}
+	_, err = call(&ethtypes.EthBlockOverrides{Coinbase: &coinbase})
+	require.NoError(t, err)
+	coinbase[19] = 1
+	_, err = call(&ethtypes.EthBlockOverrides{Coinbase: &coinbase})
+	require.ErrorContains(t, err, "cannot override the coinbase")
+}
+
+func TestEthCallRandomnessOverride(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract returns the block number, timestamp and PREVRANDAO.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/blockinfo.bin")
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	call := func(blockOverrides *ethtypes.EthBlockOverrides) ethtypes.EthBytes {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx:             ethtypes.EthCall{To: &contract},
+			BlockOverrides: blockOverrides,
+		})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		require.Len(t, res, 96)
+		return res
+	}
+
+	// The EVM draws PREVRANDAO from the overridden beacon randomness, with its own domain separation
+	// tag and entropy, at the epoch the call is applied at.
+	const evmPrevRandaoTag = crypto.DomainSeparationTag(10)
+	randao := ethtypes.EthHash{1}
+	res := call(&ethtypes.EthBlockOverrides{PrevRandao: &randao})
+	epoch := abi.ChainEpoch(big.PositiveFromUnsignedBytes(res[:32]).Int64())
+	expected, err := lrand.DrawRandomnessFromDigest(randao, evmPrevRandaoTag, epoch, []byte("prevrandao"))
+	require.NoError(t, err)
+	require.Equal(t, expected, []byte(res[64:]))
+
+	// The chain randomness is separate from the beacon's, so overriding it doesn't change
+	// PREVRANDAO.
+	chainRandomness := ethtypes.EthHash{2}
+	res = call(&ethtypes.EthBlockOverrides{PrevRandao: &randao, ChainRandomness: &chainRandomness})
+	require.Equal(t, expected, []byte(res[64:]))
+}
+
+func TestEthCallEarliest(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The deployer is credited with the initial supply of the coin.
+	fromAddr, idAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	actor, err := client.StateGetActor(ctx, idAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	require.NotNil(t, actor.DelegatedAddress)
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(*actor.DelegatedAddress)
+	require.NoError(t, err)
+
+	call := func(blkParam string) ethtypes.EthBytes {
+		blk := ethtypes.NewEthBlockNumberOrHashFromPredefined(blkParam)
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx: ethtypes.EthCall{
+				To:   &contract,
+				Data: append(kit.CalcFuncSignature("getBalance(address)"), inputDataFromFrom(ctx, t, client, fromAddr)...),
+			},
+			BlkParam: &blk,
+		})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	require.Equal(t, paddedUint64(10000), call(ethtypes.BlockTagLatest))
+
+	// The contract wasn't deployed yet at genesis, so calls to it return nothing.
+	require.Empty(t, call(ethtypes.BlockTagEarliest))
+}
+
+func TestEthCallChainIDOverride(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The contract returns the chain id.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/chainid.bin")
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	call := func(blockOverrides *ethtypes.EthBlockOverrides) ethtypes.EthBytes {
+		callParams, err := json.Marshal(ethtypes.EthCallParams{
+			Tx:             ethtypes.EthCall{To: &contract},
+			BlockOverrides: blockOverrides,
+		})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err)
+		return res
+	}
+
+	require.Equal(t, paddedUint64(buildconstants.Eip155ChainId), call(nil))
+
+	chainID := ethtypes.EthUint64(1)
+	require.Equal(t, paddedUint64(1), call(&ethtypes.EthBlockOverrides{ChainID: &chainID}))
+}
+
+func TestEthEstimateGasCreateInitCode(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	estimate := func(tx ethtypes.EthCall) (ethtypes.EthUint64, error) {
+		gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: tx})
+		require.NoError(t, err)
+		return client.EthEstimateGas(ctx, gasParams)
+	}
+
+	_, err := estimate(ethtypes.EthCall{From: &ethAddr})
+	require.ErrorContains(t, err, "contract creation requires init code")
+
+	contractHex, err := os.ReadFile("contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	gas, err := estimate(ethtypes.EthCall{From: &ethAddr, Data: contract})
+	require.NoError(t, err)
+	require.NotZero(t, gas)
+}
+
+func TestEthEstimateGasUnfundedCreate(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	// The account holds just enough to exist.
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, abi.NewTokenAmount(1000))
+
+	contractHex, err := os.ReadFile("contracts/SimpleCoin.hex")
+	require.NoError(t, err)
+	contract, err := hex.DecodeString(string(contractHex))
+	require.NoError(t, err)
+
+	estimate := func(tx ethtypes.EthCall) error {
+		gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: tx})
+		require.NoError(t, err)
+		_, err = client.EthEstimateGas(ctx, gasParams)
+		return err
+	}
+
+	// Deploying for free is affordable.
+	require.NoError(t, estimate(ethtypes.EthCall{From: &ethAddr, Data: contract}))
+
+	// Paying for the gas isn't.
+	feeCap := ethtypes.EthBigInt(types.NanoFil)
+	err = estimate(ethtypes.EthCall{From: &ethAddr, Data: contract, MaxFeePerGas: &feeCap})
+	require.ErrorContains(t, err, fmt.Sprintf("insufficient funds for gas * price + value: address %s have 1000 want", ethAddr))
+
+	// Neither is transferring more than the balance.
+	err = estimate(ethtypes.EthCall{From: &ethAddr, Data: contract, Value: ethtypes.EthBigInt(big.NewInt(2000))})
+	require.ErrorContains(t, err, fmt.Sprintf("insufficient funds for gas * price + value: address %s have 1000 want 2000", ethAddr))
+}
+
+func TestEthEstimateGas(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	filename := "contracts/Errors.hex"
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, filename)
+
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		function       string
+		expectedError  string
+		expectedErrMsg interface{}
 	}{
-		{
-			name: "EthGetBlockByNumber",
-			testFunc: func() error {
-				_, err := client.EthGetBlockByNumber(ctx, nullBlockHex, true)
-				return err
+		{"DivideByZero", "failDivZero()", "0x4e487b710000000000000000000000000000000000000000000000000000000000000012", `message execution failed [\s\S]+\[DivideByZero\(\)\]`},
+		{"Assert", "failAssert()", "0x4e487b710000000000000000000000000000000000000000000000000000000000000001", `message execution failed [\s\S]+\[Assert\(\)\]`},
+		{"RevertWithReason", "failRevertReason()", fmt.Sprintf("%x", []byte("my reason")), `message execution failed [\s\S]+\[Error\(my reason\)\]`},
+		{"RevertEmpty", "failRevertEmpty()", "0x", `message execution failed [\s\S]+\[none\]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signature := kit.CalcFuncSignature(tc.function)
+
+			callParams := ethtypes.EthCall{
+				From: &ethAddr,
+				To:   &contractAddrEth,
+				Data: signature,
+			}
+
+			gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: callParams})
+			require.NoError(t, err, "Error marshaling gas params")
+
+			_, err = client.EthEstimateGas(ctx, gasParams)
+
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				var dataErr *api.ErrExecutionReverted
+				require.ErrorAs(t, err, &dataErr, "Expected error to be ErrExecutionReverted")
+				require.Regexp(t, tc.expectedErrMsg, dataErr.Message)
+				require.Contains(t, dataErr.Data, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestEthEstimateGasExceedsBlockGasLimit(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(10))
+
+	// The contract loops forever, so no gas limit is enough for a call to it.
+	_, idAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/infiniteloop.bin")
+	actor, err := client.StateGetActor(ctx, idAddr, types.EmptyTSK)
+	require.NoError(t, err)
+	require.NotNil(t, actor.DelegatedAddress)
+	contractAddr, err := ethtypes.EthAddressFromFilecoinAddress(*actor.DelegatedAddress)
+	require.NoError(t, err)
+
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From: &ethAddr,
+		To:   &contractAddr,
+	}})
+	require.NoError(t, err)
+	_, err = client.EthEstimateGas(ctx, gasParams)
+	require.ErrorContains(t, err, fmt.Sprintf("gas required exceeds allowance (%d) or always failing transaction", buildconstants.BlockGasLimit))
+}
+
+func TestEthEstimateGasWithFeeParams(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	key, ethAddr, filAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, filAddr, types.FromFil(100))
+
+	// This contract reverts unless it observes a non-zero base fee.
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/basefee.bin")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	// Without fee parameters the gas limit is estimated with a zero base fee.
+	gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From: &ethAddr,
+		To:   &contractAddrEth,
+	}})
+	require.NoError(t, err)
+
+	_, err = client.EthEstimateGas(ctx, gasParams)
+	var dataErr *api.ErrExecutionReverted
+	require.ErrorAs(t, err, &dataErr)
+
+	head, err := client.ChainHead(ctx)
+	require.NoError(t, err)
+	maxFee := ethtypes.EthBigInt(big.Mul(head.Blocks()[0].ParentBaseFee, big.NewInt(10)))
+	maxPriorityFee := ethtypes.EthBigInt(big.NewInt(100))
+
+	gasParams, err = json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:                 &ethAddr,
+		To:                   &contractAddrEth,
+		MaxFeePerGas:         &maxFee,
+		MaxPriorityFeePerGas: &maxPriorityFee,
+	}})
+	require.NoError(t, err)
+
+	gas, err := client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+	require.NotZero(t, gas)
+
+	// The estimate must be sufficient for the transaction to land.
+	tx := ethtypes.Eth1559TxArgs{
+		ChainID:              buildconstants.Eip155ChainId,
+		Nonce:                0,
+		To:                   &contractAddrEth,
+		Value:                big.Zero(),
+		MaxFeePerGas:         big.Int(maxFee),
+		MaxPriorityFeePerGas: big.Int(maxPriorityFee),
+		GasLimit:             int(gas),
+	}
+	client.EVM().SignTransaction(&tx, key.PrivateKey)
+	hash := client.EVM().SubmitTransaction(ctx, &tx)
+
+	receipt, err := client.EVM().WaitTransaction(ctx, hash)
+	require.NoError(t, err)
+	require.EqualValues(t, ethtypes.EthUint64(1), receipt.Status)
+
+	// Senders needn't afford the block gas limit at their fee cap for the estimate to run: 1 FIL
+	// doesn't cover 10B gas at 100 gwei, but does cover the call.
+	_, poorAddr, poorFilAddr := client.EVM().NewAccount()
+	kit.SendFunds(ctx, t, client, poorFilAddr, types.FromFil(1))
+
+	highFee := ethtypes.EthBigInt(big.NewInt(100_000_000_000))
+	require.True(t, big.Mul(big.Int(highFee), big.NewInt(buildconstants.BlockGasLimit)).GreaterThan(types.FromFil(1)))
+	gasParams, err = json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{
+		From:                 &poorAddr,
+		To:                   &contractAddrEth,
+		MaxFeePerGas:         &highFee,
+		MaxPriorityFeePerGas: &maxPriorityFee,
+	}})
+	require.NoError(t, err)
+
+	gas, err = client.EthEstimateGas(ctx, gasParams)
+	require.NoError(t, err)
+	require.NotZero(t, gas)
+}
+
+func TestEthEstimateGasWithAccessList(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, contractAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/SimpleCoin.hex")
+	contractAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+
+	// getBalance reads the deployer's entry of the balances mapping, at slot 0.
+	owner := inputDataFromFrom(ctx, t, client, fromAddr)
+	data := append([]byte{0xf8, 0xb2, 0xcb, 0x4f}, owner...)
+	slot := ethtypes.EthHashFromTxBytes(append(owner, make([]byte, 32)...))
+
+	blkNum, err := client.EthBlockNumber(ctx)
+	require.NoError(t, err)
+	blkParam := ethtypes.NewEthBlockNumberOrHashFromNumber(blkNum)
+
+	estimate := func(accessList []ethtypes.EthAccessTuple) ethtypes.EthUint64 {
+		txType := ethtypes.EthUint64(ethtypes.EIP1559TxType)
+		gasParams, err := json.Marshal(ethtypes.EthEstimateGasParams{
+			Tx: ethtypes.EthCall{
+				To:         &contractAddrEth,
+				Data:       data,
+				Type:       &txType,
+				AccessList: accessList,
 			},
-		},
+			BlkParam: &blkParam,
+		})
+		require.NoError(t, err)
+		gas, err := client.EthEstimateGas(ctx, gasParams)
+		require.NoError(t, err)
+		return gas
+	}
+
+	// The FEVM doesn't price storage accesses by warmth and transactions can't carry access lists,
+	// so pre-warming the slot read by the call doesn't lower its estimate.
+	withoutList := estimate(nil)
+	withList := estimate([]ethtypes.EthAccessTuple{{Address: contractAddrEth, StorageKeys: []ethtypes.EthHash{slot}}})
+	require.Equal(t, withoutList, withList)
+}
+
+func TestEthNullRoundHandling(t *testing.T) {
+	blockTime := 100 * time.Millisecond
+	client, _, ens := kit.EnsembleMinimal(t, kit.MockProofs(), kit.ThroughRPC())
+
+	bms := ens.InterconnectAll().BeginMining(blockTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client.WaitTillChain(ctx, kit.HeightAtLeast(10))
+
+	bms[0].InjectNulls(10)
+
+	tctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	ch, err := client.ChainNotify(tctx)
+	require.NoError(t, err)
+	<-ch
+	hc := <-ch
+	require.Equal(t, store.HCApply, hc[0].Type)
+
+	afterNullHeight := hc[0].Val.Height()
+
+	nullHeight := afterNullHeight - 1
+	for nullHeight > 0 {
+		ts, err := client.ChainGetTipSetByHeight(ctx, nullHeight, types.EmptyTSK)
+		require.NoError(t, err)
+		if ts.Height() == nullHeight {
+			nullHeight--
+		} else {
+			break
+		}
+	}
+
+	nullBlockHex := fmt.Sprintf("0x%x", int(nullHeight))
+	client.WaitTillChain(ctx, kit.HeightAtLeast(nullHeight+2))
+	testCases := []struct {
+		name     string
+		testFunc func() error
+	}{
 		{
 			name: "EthFeeHistory",
 			testFunc: func() error {
@@ -1854,6 +3970,45 @@ func TestTstore(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Transient storage only lives for the duration of a transaction, so within an eth_call TSTORE and
+// TLOAD behave as in a transaction while nothing is left for the next call to read.
+func TestEthCallTransientStorage(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	filename := "contracts/TransientStorageTest.hex"
+	_, contractAddr := client.EVM().DeployContractFromFilename(ctx, filename)
+	contract, err := ethtypes.EthAddressFromFilecoinAddress(contractAddr)
+	require.NoError(t, err)
+	_, otherAddr := client.EVM().DeployContractFromFilename(ctx, filename)
+
+	call := func(signature string, args ...[]byte) ethtypes.EthBytes {
+		data := kit.CalcFuncSignature(signature)
+		for _, arg := range args {
+			data = append(data, arg...)
+		}
+		callParams, err := json.Marshal(ethtypes.EthCallParams{Tx: ethtypes.EthCall{
+			To:   &contract,
+			Data: data,
+		}})
+		require.NoError(t, err)
+		res, err := client.EthCall(ctx, callParams)
+		require.NoError(t, err, signature)
+		return res
+	}
+
+	// The contract reverts if values stored with TSTORE aren't read back by TLOAD within the call,
+	// including across nested and reentrant calls.
+	call("runTests()")
+	require.Equal(t, paddedUint64(1), call("testNestedContracts(address)", inputDataFromFrom(ctx, t, client, otherAddr)))
+	require.Equal(t, paddedUint64(1), call("testReentry(address)", inputDataFromFrom(ctx, t, client, otherAddr)))
+
+	// Values stored by a call aren't seen by the next one.
+	call("writeTransientData(uint256,uint256)", paddedUint64(7), paddedUint64(5))
+	require.Equal(t, paddedUint64(0), call("readTransientData(uint256)", paddedUint64(7)))
+	require.Equal(t, paddedUint64(1), call("isStorageCleared(uint256)", paddedUint64(7)))
+}
+
 func TestFEVMTestBLS(t *testing.T) {
 	ctx, cancel, client := kit.SetupFEVMTest(t)
 	defer cancel()
@@ -1878,3 +4033,214 @@ func TestFEVMTestBLS(t *testing.T) {
 		})
 	}
 }
+
+// TestEthGetProof checks that eth_getProof reports the account and storage of a contract as the
+// other eth APIs do, with the blocks proving the account.
+func TestEthGetProof(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, actorAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/DelegatecallActor.hex")
+	_, storageAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/DelegatecallStorage.hex")
+
+	// Set the "counter" variable of the storage contract, in its first slot, to 7.
+	inputData := append(inputDataFromFrom(ctx, t, client, actorAddr), inputDataFromArray([]byte{7})...)
+	_, _, err := client.EVM().InvokeContractByFuncName(ctx, fromAddr, storageAddr, "setVars(address,uint256)", inputData)
+	require.NoError(t, err)
+
+	storageAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(storageAddr)
+	require.NoError(t, err)
+	latest := ethtypes.NewEthBlockNumberOrHashFromPredefined("latest")
+
+	var counterKey, otherKey ethtypes.EthHash
+	otherKey[31] = 1
+	proof, err := client.EVM().EthGetProof(ctx, storageAddrEth, []ethtypes.EthHash{counterKey, otherKey}, latest)
+	require.NoError(t, err)
+	require.Equal(t, storageAddrEth, proof.Address)
+	require.NotEmpty(t, proof.AccountProof)
+	require.NotEqual(t, ethtypes.EthHash{}, proof.StorageHash)
+
+	balance, err := client.EVM().EthGetBalance(ctx, storageAddrEth, latest)
+	require.NoError(t, err)
+	require.Equal(t, balance.String(), proof.Balance.String())
+	nonce, err := client.EVM().EthGetTransactionCount(ctx, storageAddrEth, latest)
+	require.NoError(t, err)
+	require.Equal(t, nonce, proof.Nonce)
+	code, err := client.EVM().EthGetCode(ctx, storageAddrEth, latest)
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.EthHashFromTxBytes(code), proof.CodeHash)
+
+	require.Len(t, proof.StorageProof, 2)
+	require.Equal(t, counterKey, proof.StorageProof[0].Key)
+	require.EqualValues(t, 7, proof.StorageProof[0].Value.Int64())
+	require.Empty(t, proof.StorageProof[0].Proof)
+	require.Equal(t, otherKey, proof.StorageProof[1].Key)
+	require.Zero(t, proof.StorageProof[1].Value.Sign())
+
+	// Accounts that don't exist are reported empty, with the blocks proving their absence.
+	var missing ethtypes.EthAddress
+	missing[0] = 0xff
+	proof, err = client.EVM().EthGetProof(ctx, missing, nil, latest)
+	require.NoError(t, err)
+	require.Zero(t, proof.Balance.Sign())
+	require.Zero(t, proof.Nonce)
+	require.Equal(t, ethtypes.EthHash{}, proof.CodeHash)
+	require.NotEmpty(t, proof.AccountProof)
+	require.Empty(t, proof.StorageProof)
+}
+
+// TestEthTraceCall checks the traces debug_traceCall reports for nested and reverted calls.
+func TestEthTraceCall(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	_, actorAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/DelegatecallActor.hex")
+	_, storageAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/DelegatecallStorage.hex")
+	_, errorsAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/Errors.hex")
+	actorAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(actorAddr)
+	require.NoError(t, err)
+	storageAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(storageAddr)
+	require.NoError(t, err)
+	errorsAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(errorsAddr)
+	require.NoError(t, err)
+
+	traceCall := func(tx ethtypes.EthCall, config ethtypes.EthTraceCallConfig) (*ethtypes.EthTraceCallResult, error) {
+		params, err := json.Marshal(ethtypes.EthTraceCallParams{Tx: tx, Config: config})
+		require.NoError(t, err)
+		return client.EVM().EthTraceCall(ctx, params)
+	}
+
+	// setVars delegates to the actor contract.
+	inputData := append(inputDataFromFrom(ctx, t, client, actorAddr), inputDataFromArray([]byte{7})...)
+	setVars := ethtypes.EthCall{
+		To:   &storageAddrEth,
+		Data: append(kit.CalcFuncSignature("setVars(address,uint256)"), inputData...),
+	}
+	res, err := traceCall(setVars, ethtypes.EthTraceCallConfig{Tracer: ethtypes.EthCallTracer})
+	require.NoError(t, err)
+	frame := res.CallFrame
+	require.NotNil(t, frame)
+	require.Equal(t, "CALL", frame.Type)
+	require.Equal(t, &storageAddrEth, frame.To)
+	require.Equal(t, setVars.Data, frame.Input)
+	require.Empty(t, frame.Error)
+	require.NotZero(t, frame.GasUsed)
+	require.Len(t, frame.Calls, 1)
+	require.Equal(t, "DELEGATECALL", frame.Calls[0].Type)
+	require.Equal(t, storageAddrEth, frame.Calls[0].From)
+	require.Equal(t, &actorAddrEth, frame.Calls[0].To)
+
+	res, err = traceCall(setVars, ethtypes.EthTraceCallConfig{
+		Tracer:       ethtypes.EthCallTracer,
+		TracerConfig: &ethtypes.EthTracerConfig{OnlyTopCall: true},
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.CallFrame.Calls)
+
+	res, err = traceCall(setVars, ethtypes.EthTraceCallConfig{Tracer: ethtypes.EthPrestateTracer})
+	require.NoError(t, err)
+	require.Nil(t, res.CallFrame)
+	require.Contains(t, res.Prestate, storageAddrEth)
+	require.Contains(t, res.Prestate, actorAddrEth)
+	code, err := client.EVM().EthGetCode(ctx, storageAddrEth, ethtypes.NewEthBlockNumberOrHashFromPredefined("latest"))
+	require.NoError(t, err)
+	require.Equal(t, code, res.Prestate[storageAddrEth].Code)
+
+	// Reverted calls are traced with their revert data.
+	res, err = traceCall(ethtypes.EthCall{
+		To:   &errorsAddrEth,
+		Data: kit.CalcFuncSignature("failRevertReason()"),
+	}, ethtypes.EthTraceCallConfig{Tracer: ethtypes.EthCallTracer})
+	require.NoError(t, err)
+	require.Equal(t, "Reverted", res.CallFrame.Error)
+	require.Contains(t, res.CallFrame.Output.String(), fmt.Sprintf("%x", []byte("my reason")))
+
+	// The opcode logger isn't supported.
+	_, err = traceCall(setVars, ethtypes.EthTraceCallConfig{})
+	require.ErrorContains(t, err, "opcode logger isn't supported")
+}
+
+func TestEthTraceBlockByNumberAndHash(t *testing.T) {
+	ctx, cancel, client := kit.SetupFEVMTest(t)
+	defer cancel()
+
+	fromAddr, actorAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/DelegatecallActor.hex")
+	_, storageAddr := client.EVM().DeployContractFromFilename(ctx, "contracts/DelegatecallStorage.hex")
+	actorAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(actorAddr)
+	require.NoError(t, err)
+	storageAddrEth, err := ethtypes.EthAddressFromFilecoinAddress(storageAddr)
+	require.NoError(t, err)
+
+	traceBlock := func(block string, config ethtypes.EthTraceBlockConfig, byHash bool) ([]*ethtypes.EthTraceBlockResult, error) {
+		params, err := json.Marshal(ethtypes.EthTraceBlockParams{Block: block, Config: config})
+		require.NoError(t, err)
+		if byHash {
+			return client.EthTraceBlockByHash(ctx, params)
+		}
+		return client.EthTraceBlockByNumber(ctx, params)
+	}
+	// txTrace returns the trace of the transaction in the results, if they include it.
+	txTrace := func(results []*ethtypes.EthTraceBlockResult, txHash ethtypes.EthHash) *ethtypes.EthCallFrame {
+		for _, res := range results {
+			if res.TxHash == txHash {
+				return res.Result
+			}
+		}
+		return nil
+	}
+	receipt := func(msgCid cid.Cid) *ethtypes.EthTxReceipt {
+		txHash, err := client.EthGetTransactionHashByCid(ctx, msgCid)
+		require.NoError(t, err)
+		rct, err := client.EthGetTransactionReceipt(ctx, *txHash)
+		require.NoError(t, err)
+		require.NotNil(t, rct)
+		return rct
+	}
+	callTracer := ethtypes.EthTraceBlockConfig{Tracer: ethtypes.EthCallTracer}
+
+	// setVars delegates to the actor contract.
+	inputData := append(inputDataFromFrom(ctx, t, client, actorAddr), inputDataFromArray([]byte{7})...)
+	wait, err := client.EVM().InvokeSolidity(ctx, fromAddr, storageAddr, kit.CalcFuncSignature("setVars(address,uint256)"), inputData)
+	require.NoError(t, err)
+	require.True(t, wait.Receipt.ExitCode.IsSuccess())
+	rct := receipt(wait.Message)
+
+	byNumber, err := traceBlock(rct.BlockNumber.Hex(), callTracer, false)
+	require.NoError(t, err)
+	frame := txTrace(byNumber, rct.TransactionHash)
+	require.NotNil(t, frame)
+	require.Equal(t, "CALL", frame.Type)
+	require.Equal(t, &storageAddrEth, frame.To)
+	require.Len(t, frame.Calls, 1)
+	require.Equal(t, "DELEGATECALL", frame.Calls[0].Type)
+	require.Equal(t, &actorAddrEth, frame.Calls[0].To)
+
+	byHash, err := traceBlock(rct.BlockHash.String(), callTracer, true)
+	require.NoError(t, err)
+	require.Equal(t, byNumber, byHash)
+
+	onlyTopCall := callTracer
+	onlyTopCall.TracerConfig = &ethtypes.EthTracerConfig{OnlyTopCall: true}
+	results, err := traceBlock(rct.BlockNumber.Hex(), onlyTopCall, false)
+	require.NoError(t, err)
+	require.Empty(t, txTrace(results, rct.TransactionHash).Calls)
+
+	// Native transfers are left out of the traces of EVM messages.
+	_, recipient, _ := client.EVM().NewAccount()
+	recipientAddr, err := recipient.ToFilecoinAddress()
+	require.NoError(t, err)
+	rct = receipt(client.EVM().TransferValueOrFail(ctx, fromAddr, recipientAddr, big.NewInt(1)).Message)
+
+	results, err = traceBlock(rct.BlockNumber.Hex(), callTracer, false)
+	require.NoError(t, err)
+	require.NotNil(t, txTrace(results, rct.TransactionHash))
+	results, err = traceBlock(rct.BlockNumber.Hex(), ethtypes.EthTraceBlockConfig{Tracer: ethtypes.EthCallTracer, EVMOnly: true}, false)
+	require.NoError(t, err)
+	require.Nil(t, txTrace(results, rct.TransactionHash))
+
+	// Only the call tracer is supported.
+	_, err = traceBlock(rct.BlockNumber.Hex(), ethtypes.EthTraceBlockConfig{Tracer: ethtypes.EthPrestateTracer}, false)
+	require.ErrorContains(t, err, "prestateTracer isn't supported for blocks")
+	_, err = traceBlock(rct.BlockNumber.Hex(), ethtypes.EthTraceBlockConfig{}, false)
+	require.ErrorContains(t, err, "opcode logger isn't supported")
+}
@@ -0,0 +1,85 @@
+package kit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+)
+
+const (
+	// GethExecutionRevertedCode is the JSON-RPC error code Geth returns for calls reverting with
+	// data.
+	GethExecutionRevertedCode = 3
+	// GethDefaultErrorCode is the JSON-RPC error code Geth returns for other failed calls,
+	// including calls reverting without data.
+	GethDefaultErrorCode = -32000
+)
+
+// GethCallOutcome is the outcome of an eth_call in the shape of a Geth JSON-RPC response, so that
+// calls made through a Lotus node can be compared with the same calls made against Geth. Hex
+// strings are normalized to lowercase with a 0x prefix.
+type GethCallOutcome struct {
+	Result string         `json:"result,omitempty"`
+	Error  *GethCallError `json:"error,omitempty"`
+}
+
+// GethCallError is the JSON-RPC error of a failed eth_call. The revert reason Geth appends to the
+// message is dropped, as Lotus reports it differently; it can be compared through the data.
+type GethCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// ParseGethCallOutcome parses the JSON-RPC response of a Geth node to an eth_call.
+func ParseGethCallOutcome(resp []byte) (GethCallOutcome, error) {
+	var outcome GethCallOutcome
+	if err := json.Unmarshal(resp, &outcome); err != nil {
+		return GethCallOutcome{}, err
+	}
+	return outcome.normalize(), nil
+}
+
+func (o GethCallOutcome) normalize() GethCallOutcome {
+	if o.Error != nil {
+		err := *o.Error
+		if strings.HasPrefix(err.Message, "execution reverted") {
+			err.Message = "execution reverted"
+		}
+		if err.Data != "" {
+			err.Data = normalizeGethHex(err.Data)
+		}
+		return GethCallOutcome{Error: &err}
+	}
+	return GethCallOutcome{Result: normalizeGethHex(o.Result)}
+}
+
+func normalizeGethHex(s string) string {
+	return "0x" + strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+}
+
+// EthCallGethOutcome makes the call through the node and returns its outcome in the form Geth
+// reports it. Errors other than reverts fail the test.
+func (e *EVM) EthCallGethOutcome(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) GethCallOutcome {
+	params, err := json.Marshal(ethtypes.EthCallParams{Tx: tx, BlkParam: &blkParam})
+	require.NoError(e.t, err)
+
+	res, err := e.EthCall(ctx, params)
+	var reverted *api.ErrExecutionReverted
+	if errors.As(err, &reverted) {
+		callErr := GethCallError{Code: GethDefaultErrorCode, Message: "execution reverted"}
+		if reverted.Data != "0x" {
+			callErr.Code = GethExecutionRevertedCode
+			callErr.Data = reverted.Data
+		}
+		return GethCallOutcome{Error: &callErr}.normalize()
+	}
+	require.NoError(e.t, err)
+	return GethCallOutcome{Result: res.String()}.normalize()
+}
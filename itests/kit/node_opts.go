@@ -368,6 +368,36 @@ func DisableETHBlockCache() NodeOpt {
 	})
 }
 
+func EnableEthCallStrictMode() NodeOpt {
+	return WithCfgOpt(func(cfg *config.FullNode) error {
+		cfg.Fevm.EthCallStrictMode = true
+		return nil
+	})
+}
+
+// MaxGetLogsResults sets the maximum number of logs a single eth_getLogs query may return.
+func MaxGetLogsResults(n int) NodeOpt {
+	return WithCfgOpt(func(cfg *config.FullNode) error {
+		cfg.Events.MaxGetLogsResults = n
+		return nil
+	})
+}
+
+// GetLogsTimeout sets the time a single eth_getLogs query may take.
+func GetLogsTimeout(d time.Duration) NodeOpt {
+	return WithCfgOpt(func(cfg *config.FullNode) error {
+		cfg.Events.GetLogsTimeout = config.Duration(d)
+		return nil
+	})
+}
+
+func EnableEthSendRawTransactionSimulation() NodeOpt {
+	return WithCfgOpt(func(cfg *config.FullNode) error {
+		cfg.Fevm.EthSendRawTransactionSimulate = true
+		return nil
+	})
+}
+
 func DisableEthRPC() NodeOpt {
 	return WithCfgOpt(func(cfg *config.FullNode) error {
 		cfg.Fevm.EnableEthRPC = false
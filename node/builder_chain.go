@@ -301,19 +301,19 @@ func ConfigFullNode(c interface{}) Option {
 				Override(new(eth.GasAPI), From(new(full.GasModule))),
 
 				Override(new(eth.EthBasicAPI), eth.NewEthBasicAPI),
-				Override(new(eth.EthSendAPI), eth.NewEthSendAPI),
+				Override(new(eth.EthSendAPI), modules.MakeEthSend(cfg.Fevm)),
 				Override(new(eth.EthEventsInternal), modules.MakeEthEventsExtended(cfg.Events, cfg.Fevm.EnableEthRPC)),
 				Override(new(eth.EthEventsAPI), From(new(eth.EthEventsInternal))),
 
 				Override(new(full.EthTransactionAPIV1), modules.MakeEthTransactionV1(cfg.Fevm)),
 				Override(new(full.EthLookupAPIV1), modules.MakeEthLookupV1),
 				Override(new(full.EthTraceAPIV1), modules.MakeEthTraceV1(cfg.Fevm)),
-				Override(new(full.EthGasAPIV1), modules.MakeEthGasV1),
+				Override(new(full.EthGasAPIV1), modules.MakeEthGasV1(cfg.Fevm)),
 
 				Override(new(full.EthTransactionAPIV2), modules.MakeEthTransactionV2(cfg.Fevm)),
 				Override(new(full.EthLookupAPIV2), modules.MakeEthLookupV2),
 				Override(new(full.EthTraceAPIV2), modules.MakeEthTraceV2(cfg.Fevm)),
-				Override(new(full.EthGasAPIV2), modules.MakeEthGasV2),
+				Override(new(full.EthGasAPIV2), modules.MakeEthGasV2(cfg.Fevm)),
 			),
 			If(!cfg.Fevm.EnableEthRPC,
 				Override(new(eth.EthBasicAPI), &eth.EthBasicDisabled{}),
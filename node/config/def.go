@@ -83,15 +83,20 @@ func DefaultFullNode() *FullNode {
 			},
 		},
 		Fevm: FevmConfig{
-			EnableEthRPC:             false,
-			EthTraceFilterMaxResults: 500,
-			EthBlkCacheSize:          500,
+			EnableEthRPC:                  false,
+			EthTraceFilterMaxResults:      500,
+			EthBlkCacheSize:               500,
+			EthCallStrictMode:             false,
+			EthSendRawTransactionSimulate: false,
 		},
 		Events: EventsConfig{
 			EnableActorEventsAPI: false,
 			FilterTTL:            Duration(time.Hour * 1),
 			MaxFilters:           100,
 			MaxFilterResults:     10000,
+			MaxGetLogsResults:    10000,
+			GetLogsTimeout:       Duration(time.Minute),
+			MaxFilterAddresses:   1000,
 			MaxFilterHeightRange: 2880, // conservative limit of one day
 		},
 		ChainIndexer: ChainIndexerConfig{
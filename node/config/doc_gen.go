@@ -226,6 +226,30 @@ of filters per connection.`,
 
 			Comment: `MaxFilterResults specifies the maximum number of results that can be accumulated by an actor event filter.`,
 		},
+		{
+			Name: "MaxGetLogsResults",
+			Type: "int",
+
+			Comment: `MaxGetLogsResults caps the number of logs a single eth_getLogs query may return. Queries matching
+more logs fail with "query returned more than N results, narrow your filter" rather than returning
+a truncated or very large response. Set to 0 to only apply MaxFilterResults.`,
+		},
+		{
+			Name: "GetLogsTimeout",
+			Type: "Duration",
+
+			Comment: `GetLogsTimeout bounds the time a single eth_getLogs query may spend scanning the index and
+decoding the matched events. Queries taking longer fail with "log query timeout" rather than
+tying up the node. Set to 0 to disable.`,
+		},
+		{
+			Name: "MaxFilterAddresses",
+			Type: "int",
+
+			Comment: `MaxFilterAddresses caps the number of addresses an Ethereum filter, such as the one of an
+eth_getLogs query, may match logs of. Filters listing more addresses are rejected, as each of them
+has to be looked up in the index. Set to 0 to disable.`,
+		},
 		{
 			Name: "MaxFilterHeightRange",
 			Type: "uint64",
@@ -296,6 +320,27 @@ recently requested blocks that are already cached.
 The default size of the cache is 500 blocks.
 Note: Setting this value to 0 disables the cache.`,
 		},
+		{
+			Name: "EthCallStrictMode",
+			Type: "bool",
+
+			Comment: `EthCallStrictMode makes eth_call reject requests that are most likely the result of a client bug
+instead of silently succeeding. When enabled, calls that carry input data to an address with no
+deployed EVM bytecode fail with "call to non-contract with data"; by default such calls succeed
+with an empty result, matching Ethereum behaviour. Likewise, calls from senders that don't exist
+on chain fail in strict mode, while by default they are simulated from an account without funds.`,
+		},
+		{
+			Name: "EthSendRawTransactionSimulate",
+			Type: "bool",
+
+			Comment: `EthSendRawTransactionSimulate makes eth_sendRawTransaction simulate incoming transactions on the
+state of the heaviest tipset before adding them to the mpool, rejecting those that would revert
+with their revert reason, to reduce spam. The messages of the sender pending in the mpool are
+applied first, and transactions with a nonce gap aren't simulated, but transactions depending on
+messages of other senders still pending in the mpool may be rejected. Simulating every
+transaction costs CPU, so this is disabled by default.`,
+		},
 	},
 	"FullNode": {
 		{
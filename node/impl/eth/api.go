@@ -16,6 +16,7 @@ import (
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/actors/adt"
 	"github.com/filecoin-project/lotus/chain/state"
+	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/store"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
@@ -32,6 +33,7 @@ var log = logging.Logger("node/eth")
 
 type EthFilecoinAPI interface {
 	EthAddressToFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error)
+	EthAddressToRobustFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error)
 	FilecoinAddressToEthAddress(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthAddress, error)
 }
 
@@ -75,6 +77,8 @@ type EthTransactionAPI interface {
 
 	EthGetTransactionReceipt(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthTxReceipt, error)
 	EthGetTransactionReceiptLimited(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*ethtypes.EthTxReceipt, error)
+	EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error)
+	EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error)
 	EthGetBlockReceipts(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash) ([]*ethtypes.EthTxReceipt, error)
 	EthGetBlockReceiptsLimited(ctx context.Context, blkParam ethtypes.EthBlockNumberOrHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error)
 }
@@ -85,6 +89,7 @@ type EthLookupAPI interface {
 	EthGetCode(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
 	EthGetStorageAt(ctx context.Context, ethAddr ethtypes.EthAddress, position ethtypes.EthBytes, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
 	EthGetBalance(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBigInt, error)
+	EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error)
 }
 
 // EthTrace ----------------------------------------------------------------------------------------
@@ -93,6 +98,9 @@ type EthTraceAPI interface {
 	EthTraceBlock(ctx context.Context, blkNum string) ([]*ethtypes.EthTraceBlock, error)
 	EthTraceReplayBlockTransactions(ctx context.Context, blkNum string, traceTypes []string) ([]*ethtypes.EthTraceReplayBlockTransaction, error)
 	EthTraceTransaction(ctx context.Context, txHash string) ([]*ethtypes.EthTraceTransaction, error)
+	EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error)
+	EthTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error)
+	EthTraceBlockByHash(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error)
 	EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error)
 }
 
@@ -103,7 +111,11 @@ type EthGasAPI interface {
 	EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthFeeHistory, error)
 	EthMaxPriorityFeePerGas(ctx context.Context) (ethtypes.EthBigInt, error)
 	EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error)
-	EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error)
+	EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error)
+	EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error)
+	EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error)
+	EthCallAtStateRoot(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error)
+	EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error)
 }
 
 // EthEvents ---------------------------------------------------------------------------------------
@@ -116,6 +128,8 @@ const (
 
 type EthEventsAPI interface {
 	EthGetLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error)
+	EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error)
+	EthExplainLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error)
 	EthNewBlockFilter(ctx context.Context) (ethtypes.EthFilterID, error)
 	EthNewPendingTransactionFilter(ctx context.Context) (ethtypes.EthFilterID, error)
 	EthNewFilter(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthFilterID, error)
@@ -186,6 +200,9 @@ type ChainStore interface {
 	MessagesForTipset(ctx context.Context, ts *types.TipSet) ([]types.ChainMsg, error)
 	ReadReceipts(ctx context.Context, root cid.Cid) ([]types.MessageReceipt, error)
 
+	// Fees
+	ComputeBaseFee(ctx context.Context, ts *types.TipSet) (abi.TokenAmount, error)
+
 	// Misc
 	ActorStore(ctx context.Context) adt.Store
 }
@@ -213,6 +230,9 @@ type StateManager interface {
 	CallOnState(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet) (*api.InvocResult, error)
 	CallWithGas(ctx context.Context, msg *types.Message, priorMsgs []types.ChainMsg, ts *types.TipSet, applyTsMessages bool) (*api.InvocResult, error)
 	ApplyOnStateWithGas(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet) (*api.InvocResult, error)
+	ApplyOnStateWithOptions(ctx context.Context, stateCid cid.Cid, msg *types.Message, ts *types.TipSet, opts *stmgr.CallOptions) (*api.InvocResult, error)
+	ApplyManyOnStateWithOptions(ctx context.Context, stateCid cid.Cid, msgs []*types.Message, ts *types.TipSet, opts *stmgr.CallOptions) ([]*api.InvocResult, error)
+	StateAfterMessages(ctx context.Context, stateCid cid.Cid, msgs []types.ChainMsg, ts *types.TipSet) (*state.StateTree, error)
 
 	HasExpensiveForkBetween(parent, height abi.ChainEpoch) bool
 }
@@ -227,6 +247,7 @@ type MpoolAPI interface {
 
 // MessagePool is a minimal version of messagepool.MessagePool
 type MessagePool interface {
+	Pending(ctx context.Context) ([]*types.SignedMessage, *types.TipSet)
 	PendingFor(ctx context.Context, a address.Address) ([]*types.SignedMessage, *types.TipSet)
 	GetConfig() *types.MpoolConfig
 }
@@ -28,6 +28,9 @@ var (
 	_ EthEventsAPI      = (*EthEventsDisabled)(nil)
 )
 
+// errLogQueryTimeout is returned by eth_getLogs queries running longer than the configured timeout.
+var errLogQueryTimeout = xerrors.New("log query timeout")
+
 type filterEventCollector interface {
 	TakeCollectedEvents(context.Context) []*index.CollectedEvent
 }
@@ -51,6 +54,9 @@ type ethEvents struct {
 	filterStore          filter.FilterStore
 	subscriptionManager  *EthSubscriptionManager
 	maxFilterHeightRange abi.ChainEpoch
+	maxGetLogsResults    int
+	getLogsTimeout       time.Duration
+	maxFilterAddresses   int
 }
 
 func NewEthEventsAPI(
@@ -64,6 +70,9 @@ func NewEthEventsAPI(
 	filterStore filter.FilterStore,
 	subscriptionManager *EthSubscriptionManager,
 	maxFilterHeightRange abi.ChainEpoch,
+	maxGetLogsResults int,
+	getLogsTimeout time.Duration,
+	maxFilterAddresses int,
 ) EthEventsInternal {
 	return &ethEvents{
 		subscriptionCtx:      subscriptionCtx,
@@ -76,15 +85,65 @@ func NewEthEventsAPI(
 		filterStore:          filterStore,
 		subscriptionManager:  subscriptionManager,
 		maxFilterHeightRange: maxFilterHeightRange,
+		maxGetLogsResults:    maxGetLogsResults,
+		getLogsTimeout:       getLogsTimeout,
+		maxFilterAddresses:   maxFilterAddresses,
 	}
 }
 
 func (e *ethEvents) EthGetLogs(ctx context.Context, filterSpec *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error) {
-	ces, err := e.ethGetEventsForFilter(ctx, filterSpec)
+	ef, err := e.ethEventFilter(ctx, filterSpec)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to get events for filter: %w", err)
 	}
-	return ethFilterResultFromEvents(ctx, ces, e.chainStore, e.stateManager)
+
+	// Apply the eth_getLogs cap through the indexer's result limit, which fails as soon as one more
+	// event than allowed is matched.
+	capped := e.maxGetLogsResults > 0 && (ef.MaxResults == 0 || ef.MaxResults >= e.maxGetLogsResults)
+	if capped {
+		ef.MaxResults = e.maxGetLogsResults
+	}
+
+	// Bound the time spent scanning the index and decoding the matched events.
+	if e.getLogsTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, e.getLogsTimeout, errLogQueryTimeout)
+		defer cancel()
+	}
+
+	ces, err := e.chainIndexer.GetEventsForFilter(ctx, ef)
+	if err != nil {
+		if errors.Is(context.Cause(ctx), errLogQueryTimeout) {
+			return nil, errLogQueryTimeout
+		}
+		if capped && errors.Is(err, index.ErrMaxResultsReached) {
+			return nil, xerrors.Errorf("query returned more than %d results, narrow your filter", e.maxGetLogsResults)
+		}
+		return nil, xerrors.Errorf("failed to get events for filter: %w", ethIndexerError(err))
+	}
+	var res *ethtypes.EthFilterResult
+	if filterSpec.GroupByTransaction {
+		res, err = ethFilterResultByTransaction(ctx, newLogPostFilter(filterSpec).apply(ces), e.chainStore, e.stateManager)
+	} else {
+		res, err = ethFilterResultFromEvents(ctx, newLogPostFilter(filterSpec).apply(ces), e.chainStore, e.stateManager)
+	}
+	if err != nil && errors.Is(context.Cause(ctx), errLogQueryTimeout) {
+		return nil, errLogQueryTimeout
+	}
+	return res, err
+}
+
+func (e *ethEvents) EthEstimateLogsCount(ctx context.Context, filterSpec *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	ef, err := e.ethEventFilter(ctx, filterSpec)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to estimate logs count: %w", err)
+	}
+
+	count, err := e.chainIndexer.CountEventsForFilter(ctx, ef)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to estimate logs count: %w", ethIndexerError(err))
+	}
+	return ethtypes.EthUint64(count), nil
 }
 
 func (e *ethEvents) EthNewBlockFilter(ctx context.Context) (ethtypes.EthFilterID, error) {
@@ -138,7 +197,7 @@ func (e *ethEvents) EthNewFilter(ctx context.Context, filterSpec *ethtypes.EthFi
 		return ethtypes.EthFilterID{}, api.ErrNotSupported
 	}
 
-	pf, err := e.parseEthFilterSpec(filterSpec)
+	pf, err := e.parseEthFilterSpec(filterSpec, e.chainStore.GetHeaviestTipSet())
 	if err != nil {
 		return ethtypes.EthFilterID{}, err
 	}
@@ -147,6 +206,9 @@ func (e *ethEvents) EthNewFilter(ctx context.Context, filterSpec *ethtypes.EthFi
 	if err != nil {
 		return ethtypes.EthFilterID{}, xerrors.Errorf("failed to install event filter: %w", err)
 	}
+	if !pf.post.isEmpty() {
+		f = &postFilteredEventFilter{EventFilter: f, post: pf.post}
+	}
 
 	if err := e.filterStore.Add(ctx, f); err != nil {
 		// Could not record in store, attempt to delete filter to clean up
@@ -251,34 +313,24 @@ func (e *ethEvents) EthSubscribe(ctx context.Context, p jsonrpc.RawParams) (etht
 		sub.addFilter(f)
 
 	case EthSubscribeEventTypeLogs:
-		keys := map[string][][]byte{}
-		if params.Params != nil {
-			var err error
-			keys, err = parseEthTopics(params.Params.Topics)
-			if err != nil {
-				// clean up any previous filters added and stop the sub
-				_, _ = e.EthUnsubscribe(ctx, sub.id)
-				return ethtypes.EthSubscriptionID{}, err
-			}
-		}
-
-		var addresses []address.Address
-		if params.Params != nil {
-			for _, ea := range params.Params.Address {
-				a, err := ea.ToFilecoinAddress()
-				if err != nil {
-					return ethtypes.EthSubscriptionID{}, xerrors.Errorf("invalid address %x", ea)
-				}
-				addresses = append(addresses, a)
-			}
+		addresses, keys, post, err := e.parseEthFilterCriteria(params.Params.FilterSpec())
+		if err != nil {
+			// clean up any previous filters added and stop the sub
+			_, _ = e.EthUnsubscribe(ctx, sub.id)
+			return ethtypes.EthSubscriptionID{}, err
 		}
 
-		f, err := e.eventFilterManager.Install(ctx, -1, -1, cid.Undef, addresses, keysToKeysWithCodec(keys))
+		// Events of reverted tipsets are pushed to the subscription again by the filter, flagged as
+		// reverted, so subscribers receive them with removed set to true.
+		f, err := e.eventFilterManager.Install(ctx, -1, -1, cid.Undef, addresses, keys)
 		if err != nil {
 			// clean up any previous filters added and stop the sub
 			_, _ = e.EthUnsubscribe(ctx, sub.id)
 			return ethtypes.EthSubscriptionID{}, err
 		}
+		// The filter only matches addresses and topics, so the subscription applies the rest of the
+		// criteria, such as MinTopics and ExcludeTopics, with the same post filter as eth_getLogs.
+		sub.logPost = post
 		sub.addFilter(f)
 	case EthSubscribeEventTypePendingTransactions:
 		f, err := e.memPoolFilterManager.Install(ctx)
@@ -351,7 +403,67 @@ func (e *ethEvents) GC(ctx context.Context, ttl time.Duration) {
 	}
 }
 
+func (e *ethEvents) EthExplainLogs(ctx context.Context, filterSpec *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	ef, err := e.ethEventFilter(ctx, filterSpec)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to explain logs query: %w", err)
+	}
+
+	plan := &ethtypes.EthLogsQueryPlan{IndexFilters: []string{}}
+	if ef.TipsetCid != cid.Undef {
+		blockHash, err := ethtypes.EthHashFromCid(ef.TipsetCid)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to explain logs query: %w", err)
+		}
+		plan.BlockHash = &blockHash
+		plan.Tipsets = 1
+		plan.IndexFilters = append(plan.IndexFilters, "blockHash")
+	} else {
+		from, to := ethtypes.EthUint64(ef.MinHeight), ethtypes.EthUint64(ef.MaxHeight)
+		plan.FromBlock, plan.ToBlock = &from, &to
+		plan.Tipsets = to - from + 1
+		plan.IndexFilters = append(plan.IndexFilters, "blockRange")
+	}
+	if len(ef.Addresses) > 0 {
+		plan.IndexFilters = append(plan.IndexFilters, "address")
+	}
+	if len(ef.KeysWithCodec) > 0 {
+		plan.IndexFilters = append(plan.IndexFilters, "topics")
+	}
+
+	post := newLogPostFilter(filterSpec)
+	if post.minTopics > 0 {
+		plan.PostFilters = append(plan.PostFilters, "minTopics")
+	}
+	if len(post.excludeTopics) > 0 {
+		plan.PostFilters = append(plan.PostFilters, "excludeTopics")
+	}
+
+	// As in EthGetLogs, the lowest of the filter and eth_getLogs limits applies.
+	maxResults := ef.MaxResults
+	if e.maxGetLogsResults > 0 && (maxResults == 0 || maxResults >= e.maxGetLogsResults) {
+		maxResults = e.maxGetLogsResults
+	}
+	plan.MaxResults = ethtypes.EthUint64(maxResults)
+	return plan, nil
+}
+
 func (e *ethEvents) ethGetEventsForFilter(ctx context.Context, filterSpec *ethtypes.EthFilterSpec) ([]*index.CollectedEvent, error) {
+	ef, err := e.ethEventFilter(ctx, filterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	ces, err := e.chainIndexer.GetEventsForFilter(ctx, ef)
+	if err != nil {
+		return nil, ethIndexerError(err)
+	}
+
+	return ces, nil
+}
+
+// ethEventFilter validates an Ethereum filter spec and converts it into a chain index event filter.
+func (e *ethEvents) ethEventFilter(ctx context.Context, filterSpec *ethtypes.EthFilterSpec) (*index.EventFilter, error) {
 	if e.eventFilterManager == nil {
 		return nil, api.ErrNotSupported
 	}
@@ -360,12 +472,17 @@ func (e *ethEvents) ethGetEventsForFilter(ctx context.Context, filterSpec *ethty
 		return nil, ErrChainIndexerDisabled
 	}
 
-	pf, err := e.parseEthFilterSpec(filterSpec)
+	// "latest" is resolved against a single head for the whole query, whichever bound it is used
+	// for, so that tipsets added while the query runs don't change its result.
+	head := e.chainStore.GetHeaviestTipSet()
+	pf, err := e.parseEthFilterSpec(filterSpec, head)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to parse eth filter spec: %w", err)
 	}
+	if pf.tipsetCid == cid.Undef && pf.maxHeight == -1 {
+		pf.maxHeight = head.Height() - 1
+	}
 
-	head := e.chainStore.GetHeaviestTipSet()
 	// should not ask for events for a tipset >= head because of deferred execution
 	if pf.tipsetCid != cid.Undef {
 		ts, err := e.chainStore.GetTipSetByCid(ctx, pf.tipsetCid)
@@ -381,7 +498,7 @@ func (e *ethEvents) ethGetEventsForFilter(ctx context.Context, filterSpec *ethty
 		return nil, xerrors.New("cannot ask for events for a tipset at or greater than head")
 	}
 
-	ef := &index.EventFilter{
+	return &index.EventFilter{
 		MinHeight:     pf.minHeight,
 		MaxHeight:     pf.maxHeight,
 		TipsetCid:     pf.tipsetCid,
@@ -389,14 +506,17 @@ func (e *ethEvents) ethGetEventsForFilter(ctx context.Context, filterSpec *ethty
 		KeysWithCodec: pf.keys,
 		Codec:         multicodec.Raw,
 		MaxResults:    e.eventFilterManager.MaxFilterResults,
-	}
+	}, nil
+}
 
-	ces, err := e.chainIndexer.GetEventsForFilter(ctx, ef)
-	if err != nil {
-		return nil, xerrors.Errorf("failed to get events for filter from chain indexer: %w", err)
+// ethIndexerError converts an error from querying events in the chain index into an error for the
+// Ethereum API.
+func ethIndexerError(err error) error {
+	var notIndexed *index.ErrRangeNotIndexed
+	if errors.As(err, &notIndexed) {
+		return xerrors.Errorf("logs not available before epoch %d (no event index)", notIndexed.EarliestEpoch)
 	}
-
-	return ces, nil
+	return xerrors.Errorf("failed to get events for filter from chain indexer: %w", err)
 }
 
 func ethFilterResultFromEvents(ctx context.Context, evs []*index.CollectedEvent, cs ChainStore, sa StateManager) (*ethtypes.EthFilterResult, error) {
@@ -413,6 +533,22 @@ func ethFilterResultFromEvents(ctx context.Context, evs []*index.CollectedEvent,
 	return res, nil
 }
 
+// ethFilterResultByTransaction is like ethFilterResultFromEvents, but groups the logs by the hash of
+// the transaction that emitted them.
+func ethFilterResultByTransaction(ctx context.Context, evs []*index.CollectedEvent, cs ChainStore, sa StateManager) (*ethtypes.EthFilterResult, error) {
+	logs, err := ethFilterLogsFromEvents(ctx, evs, cs, sa)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ethtypes.EthFilterResult{ByTransaction: make(map[ethtypes.EthHash][]ethtypes.EthLog)}
+	for _, log := range logs {
+		res.ByTransaction[log.TransactionHash] = append(res.ByTransaction[log.TransactionHash], log)
+	}
+
+	return res, nil
+}
+
 func ethFilterResultFromTipSets(tsks []types.TipSetKey) (*ethtypes.EthFilterResult, error) {
 	res := &ethtypes.EthFilterResult{}
 
@@ -450,6 +586,10 @@ func ethFilterResultFromMessages(cs []*types.SignedMessage) (*ethtypes.EthFilter
 func ethFilterLogsFromEvents(ctx context.Context, evs []*index.CollectedEvent, cs ChainStore, sa StateManager) ([]ethtypes.EthLog, error) {
 	var logs []ethtypes.EthLog
 	for _, ev := range evs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		log := ethtypes.EthLog{
 			Removed:          ev.Reverted,
 			LogIndex:         ethtypes.EthUint64(ev.EventIdx),
@@ -627,7 +767,7 @@ func parseBlockRange(heaviest abi.ChainEpoch, fromBlock, toBlock *string, maxRan
 		}
 	} else if minHeight >= 0 && maxHeight >= 0 {
 		if minHeight > maxHeight {
-			return 0, 0, xerrors.Errorf("invalid epoch range: to block (%d) must be after from block (%d)", minHeight, maxHeight)
+			return 0, 0, xerrors.Errorf("invalid epoch range: to block (%d) must be after from block (%d)", maxHeight, minHeight)
 		} else if maxHeight-minHeight > maxRange {
 			return 0, 0, xerrors.Errorf("invalid epoch range: range between to and from blocks is too large (maximum: %d)", maxRange)
 		}
@@ -641,15 +781,15 @@ type parsedFilter struct {
 	tipsetCid cid.Cid
 	addresses []address.Address
 	keys      map[string][]types.ActorEventBlock
+	post      logPostFilter
 }
 
-func (e *ethEvents) parseEthFilterSpec(filterSpec *ethtypes.EthFilterSpec) (*parsedFilter, error) {
+// parseEthFilterSpec parses an Ethereum filter spec, resolving "latest" against the given head.
+func (e *ethEvents) parseEthFilterSpec(filterSpec *ethtypes.EthFilterSpec, head *types.TipSet) (*parsedFilter, error) {
 	var (
 		minHeight abi.ChainEpoch
 		maxHeight abi.ChainEpoch
 		tipsetCid cid.Cid
-		addresses []address.Address
-		keys      = map[string][][]byte{}
 	)
 
 	if filterSpec.BlockHash != nil {
@@ -661,33 +801,132 @@ func (e *ethEvents) parseEthFilterSpec(filterSpec *ethtypes.EthFilterSpec) (*par
 	} else {
 		var err error
 		// Because of deferred execution, we need to subtract 1 from the heaviest tipset height for the "heaviest" parameter
-		minHeight, maxHeight, err = parseBlockRange(e.chainStore.GetHeaviestTipSet().Height()-1, filterSpec.FromBlock, filterSpec.ToBlock, e.maxFilterHeightRange)
+		minHeight, maxHeight, err = parseBlockRange(head.Height()-1, filterSpec.FromBlock, filterSpec.ToBlock, e.maxFilterHeightRange)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	addresses, keys, post, err := e.parseEthFilterCriteria(filterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsedFilter{
+		minHeight: minHeight,
+		maxHeight: maxHeight,
+		tipsetCid: tipsetCid,
+		addresses: addresses,
+		keys:      keys,
+		post:      post,
+	}, nil
+}
+
+// parseEthFilterCriteria parses the criteria of an Ethereum filter spec that events are matched
+// against, leaving out the block range. It is shared by log filters and log subscriptions so both
+// match events the same way.
+func (e *ethEvents) parseEthFilterCriteria(filterSpec *ethtypes.EthFilterSpec) ([]address.Address, map[string][]types.ActorEventBlock, logPostFilter, error) {
+	var addresses []address.Address
+
+	if e.maxFilterAddresses > 0 && len(filterSpec.Address) > e.maxFilterAddresses {
+		return nil, nil, logPostFilter{}, xerrors.Errorf("too many addresses in filter: %d, the maximum is %d", len(filterSpec.Address), e.maxFilterAddresses)
+	}
+
 	// Convert all addresses to filecoin f4 addresses
 	for _, ea := range filterSpec.Address {
 		a, err := ea.ToFilecoinAddress()
 		if err != nil {
-			return nil, xerrors.Errorf("invalid address %x", ea)
+			return nil, nil, logPostFilter{}, xerrors.Errorf("invalid address %x", ea)
 		}
 		addresses = append(addresses, a)
 	}
 
-	keys, err := parseEthTopics(filterSpec.Topics)
+	topics := filterSpec.Topics
+	if filterSpec.EventSignature != "" {
+		if len(topics) > 0 && len(topics[0]) > 0 {
+			return nil, nil, logPostFilter{}, xerrors.New("must not specify both event signature and first topic")
+		}
+		sigTopics := ethtypes.EthTopicSpec{{ethtypes.EthHashFromTxBytes([]byte(filterSpec.EventSignature))}}
+		if len(topics) > 1 {
+			sigTopics = append(sigTopics, topics[1:]...)
+		}
+		topics = sigTopics
+	}
+
+	keys, err := parseEthTopics(topics)
 	if err != nil {
-		return nil, err
+		return nil, nil, logPostFilter{}, err
 	}
 
-	return &parsedFilter{
-		minHeight: minHeight,
-		maxHeight: maxHeight,
-		tipsetCid: tipsetCid,
-		addresses: addresses,
-		keys:      keysToKeysWithCodec(keys),
-	}, nil
+	if filterSpec.MinTopics > 4 {
+		return nil, nil, logPostFilter{}, xerrors.Errorf("invalid min topics %d: events have at most 4 topics", filterSpec.MinTopics)
+	}
+	if len(filterSpec.ExcludeTopics) > 4 {
+		return nil, nil, logPostFilter{}, xerrors.Errorf("invalid excluded topics: events have at most 4 topics, got %d positions", len(filterSpec.ExcludeTopics))
+	}
+
+	return addresses, keysToKeysWithCodec(keys), newLogPostFilter(filterSpec), nil
+}
+
+// logPostFilter holds the criteria of an Ethereum filter that the chain index can't match, which
+// are applied to the events it returns instead.
+type logPostFilter struct {
+	minTopics     int
+	excludeTopics ethtypes.EthTopicSpec
+}
+
+func newLogPostFilter(filterSpec *ethtypes.EthFilterSpec) logPostFilter {
+	return logPostFilter{
+		minTopics:     int(filterSpec.MinTopics),
+		excludeTopics: filterSpec.ExcludeTopics,
+	}
+}
+
+func (p logPostFilter) isEmpty() bool {
+	return p.minTopics == 0 && len(p.excludeTopics) == 0
+}
+
+// apply returns the events matching the criteria. Events that can't be represented as Ethereum
+// logs are kept, as they are dropped later anyway.
+func (p logPostFilter) apply(ces []*index.CollectedEvent) []*index.CollectedEvent {
+	if p.isEmpty() {
+		return ces
+	}
+	matched := make([]*index.CollectedEvent, 0, len(ces))
+	for _, ce := range ces {
+		if _, topics, ok := ethLogFromEvent(ce.Entries); !ok || p.matches(topics) {
+			matched = append(matched, ce)
+		}
+	}
+	return matched
+}
+
+func (p logPostFilter) matches(topics []ethtypes.EthHash) bool {
+	if len(topics) < p.minTopics {
+		return false
+	}
+	for i, excluded := range p.excludeTopics {
+		if i >= len(topics) {
+			break
+		}
+		for _, h := range excluded {
+			if topics[i] == h {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// postFilteredEventFilter is an installed event filter only handing out the collected events
+// matching its post filter.
+type postFilteredEventFilter struct {
+	filter.EventFilter
+	post logPostFilter
+}
+
+func (f *postFilteredEventFilter) TakeCollectedEvents(ctx context.Context) []*index.CollectedEvent {
+	return f.post.apply(f.EventFilter.TakeCollectedEvents(ctx))
 }
 
 func keysToKeysWithCodec(keys map[string][][]byte) map[string][]types.ActorEventBlock {
@@ -732,6 +971,12 @@ type EthEventsDisabled struct{}
 func (EthEventsDisabled) EthGetLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthFilterResult, error) {
 	return nil, ErrModuleDisabled
 }
+func (EthEventsDisabled) EthEstimateLogsCount(ctx context.Context, filter *ethtypes.EthFilterSpec) (ethtypes.EthUint64, error) {
+	return 0, ErrModuleDisabled
+}
+func (EthEventsDisabled) EthExplainLogs(ctx context.Context, filter *ethtypes.EthFilterSpec) (*ethtypes.EthLogsQueryPlan, error) {
+	return nil, ErrModuleDisabled
+}
 func (EthEventsDisabled) EthNewBlockFilter(ctx context.Context) (ethtypes.EthFilterID, error) {
 	return ethtypes.EthFilterID{}, ErrModuleDisabled
 }
@@ -1,6 +1,8 @@
 package eth
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -68,6 +70,44 @@ func TestParseBlockRange(t *testing.T) {
 			minOut:   16,
 			maxOut:   48,
 		},
+		"works when min is specified and max is latest": {
+			heaviest: 500,
+			from:     pstring("0x1f0"),
+			to:       pstring("latest"),
+			maxRange: 1000,
+			minOut:   496,
+			maxOut:   -1,
+		},
+		"works when min is latest and max is specified": {
+			heaviest: 500,
+			from:     pstring("latest"),
+			to:       pstring("0x1f4"),
+			maxRange: 1000,
+			minOut:   500,
+			maxOut:   500,
+		},
+		"fails when min is latest and max is before it": {
+			heaviest: 500,
+			from:     pstring("latest"),
+			to:       pstring("0x1f0"),
+			maxRange: 1000,
+			errStr:   "to block (496) must be after from block (500)",
+		},
+		"works when min is earliest and max is specified": {
+			heaviest: 500,
+			from:     pstring("earliest"),
+			to:       pstring("0x30"),
+			maxRange: 1000,
+			minOut:   0,
+			maxOut:   48,
+		},
+		"fails when min is specified and max is earliest": {
+			heaviest: 500,
+			from:     pstring("0x10"),
+			to:       pstring("earliest"),
+			maxRange: 1000,
+			errStr:   "must be after from block",
+		},
 	}
 
 	for name, tc := range tcs {
@@ -178,3 +218,38 @@ func TestEthLogFromEvent(t *testing.T) {
 	require.Len(t, topics, 1)
 	require.Equal(t, topics[0], ethtypes.EthHash{})
 }
+
+func TestEthFilterResultFromNoEvents(t *testing.T) {
+	res, err := ethFilterResultFromEvents(context.Background(), nil, nil, nil)
+	require.NoError(t, err)
+
+	// Many clients can't handle null in place of an empty list of logs.
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(b))
+}
+
+func TestParseEthFilterSpecMaxAddresses(t *testing.T) {
+	e := &ethEvents{maxFilterAddresses: 2}
+	blockHash := ethtypes.EthHash{1}
+	spec := func(n int) *ethtypes.EthFilterSpec {
+		addrs := make(ethtypes.EthAddressList, n)
+		for i := range addrs {
+			addrs[i][19] = byte(i + 1)
+		}
+		return &ethtypes.EthFilterSpec{BlockHash: &blockHash, Address: addrs}
+	}
+
+	pf, err := e.parseEthFilterSpec(spec(2), nil)
+	require.NoError(t, err)
+	require.Len(t, pf.addresses, 2)
+
+	_, err = e.parseEthFilterSpec(spec(3), nil)
+	require.ErrorContains(t, err, "too many addresses in filter: 3, the maximum is 2")
+
+	// Without a maximum, any number of addresses is accepted.
+	e.maxFilterAddresses = 0
+	pf, err = e.parseEthFilterSpec(spec(3), nil)
+	require.NoError(t, err)
+	require.Len(t, pf.addresses, 3)
+}
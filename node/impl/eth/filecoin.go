@@ -2,12 +2,18 @@ package eth
 
 import (
 	"context"
+	"errors"
 
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/go-state-types/abi"
 
+	"github.com/filecoin-project/lotus/chain/actors/adt"
+	builtinactors "github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/account"
+	init_ "github.com/filecoin-project/lotus/chain/actors/builtin/init"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 )
 
@@ -30,6 +36,82 @@ func (e *ethFilecoin) EthAddressToFilecoinAddress(ctx context.Context, ethAddres
 	return ethAddress.ToFilecoinAddress()
 }
 
+func (e *ethFilecoin) EthAddressToRobustFilecoinAddress(ctx context.Context, ethAddress ethtypes.EthAddress) (address.Address, error) {
+	addr, err := ethAddress.ToFilecoinAddress()
+	if err != nil {
+		return address.Undef, err
+	}
+
+	ts, err := e.tipsetResolver.GetTipsetByBlockNumber(ctx, ethtypes.BlockTagLatest, false)
+	if err != nil {
+		return address.Undef, err
+	}
+
+	st, _, err := e.stateManager.TipSetState(ctx, ts)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to compute tipset state: %w", err)
+	}
+	stateTree, err := e.stateManager.StateTree(st)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to load state tree: %w", err)
+	}
+
+	idAddr, err := stateTree.LookupIDAddress(addr)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to lookup ID address for %s: %w", ethAddress, err)
+	}
+
+	actor, err := stateTree.GetActor(idAddr)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to load actor %s: %w", idAddr, err)
+	}
+
+	store := adt.WrapStore(ctx, stateTree.Store)
+
+	// Accounts are keyed by their public key address.
+	if builtinactors.IsAccountActor(actor.Code) {
+		accountState, err := account.Load(store, actor)
+		if err != nil {
+			return address.Undef, xerrors.Errorf("failed to load account state for %s: %w", idAddr, err)
+		}
+		return accountState.PubkeyAddress()
+	}
+
+	// Other actors are only known by the robust address they were created with, which the init
+	// actor maps to their ID address alongside their f4 address, if any.
+	initActor, err := stateTree.GetActor(builtinactors.InitActorAddr)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to load init actor: %w", err)
+	}
+	initState, err := init_.Load(store, initActor)
+	if err != nil {
+		return address.Undef, xerrors.Errorf("failed to load init actor state: %w", err)
+	}
+
+	id, err := address.IDFromAddress(idAddr)
+	if err != nil {
+		return address.Undef, err
+	}
+
+	robustAddr := address.Undef
+	errFound := errors.New("robust address found")
+	err = initState.ForEachActor(func(actorID abi.ActorID, a address.Address) error {
+		if uint64(actorID) == id && a.Protocol() != address.Delegated {
+			robustAddr = a
+			return errFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return address.Undef, xerrors.Errorf("failed to search the init actor's address map: %w", err)
+	}
+	if robustAddr == address.Undef {
+		return address.Undef, xerrors.Errorf("actor %s only has an ID address, there is no robust address for it", idAddr)
+	}
+
+	return robustAddr, nil
+}
+
 func (e *ethFilecoin) FilecoinAddressToEthAddress(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthAddress, error) {
 	params, err := jsonrpc.DecodeParams[ethtypes.FilecoinAddressToEthAddressParams](p)
 	if err != nil {
@@ -4,21 +4,33 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"sort"
 
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
 	cbg "github.com/whyrusleeping/cbor-gen"
+	"go.opencensus.io/trace"
 	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v10/eam"
+	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
 
 	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/blockstore"
 	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/actors/adt"
 	builtinactors "github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/evm"
+	"github.com/filecoin-project/lotus/chain/state"
 	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
@@ -34,6 +46,12 @@ var (
 
 var minGasPremium = ethtypes.EthBigInt(types.NewInt(gasutils.MinGasPremium))
 
+// errMaxCodeSizeExceeded is returned, as by Ethereum clients, for contract creations failing because
+// the code they deploy exceeds the maximum code size of EIP-170. The EVM actor enforces the limit,
+// failing such creations with USR_ILLEGAL_ARGUMENT, while init code failing as it runs exits with an
+// EVM-specific exit code.
+var errMaxCodeSizeExceeded = xerrors.New("max code size exceeded")
+
 type ethGas struct {
 	chainStore   ChainStore
 	stateManager StateManager
@@ -41,6 +59,8 @@ type ethGas struct {
 	gasApi       GasAPI
 
 	tipsetResolver TipSetResolver
+
+	strictCallMode bool // see FevmConfig.EthCallStrictMode
 }
 
 func NewEthGasAPI(
@@ -49,6 +69,7 @@ func NewEthGasAPI(
 	messagePool MessagePool,
 	gasApi GasAPI,
 	tipsetResolver TipSetResolver,
+	strictCallMode bool,
 ) EthGasAPI {
 	return &ethGas{
 		chainStore:     chainStore,
@@ -56,6 +77,7 @@ func NewEthGasAPI(
 		messagePool:    messagePool,
 		gasApi:         gasApi,
 		tipsetResolver: tipsetResolver,
+		strictCallMode: strictCallMode,
 	}
 }
 
@@ -103,38 +125,39 @@ func (e *ethGas) EthFeeHistory(ctx context.Context, p jsonrpc.RawParams) (ethtyp
 	if err != nil {
 		return ethtypes.EthFeeHistory{}, err // don't wrap, to preserve ErrNullRound
 	}
+	// There is no history of the epochs before the EVM was active, before which there were no
+	// Ethereum transactions to pay fees.
+	if params.BlkCount > 0 && e.stateManager.GetNetworkVersion(ctx, ts.Height()) < network.Version18 {
+		return ethtypes.EthFeeHistory{}, xerrors.Errorf("no fee history before network version 18, which block %d precedes", ts.Height())
+	}
+
+	// As in Ethereum, baseFeePerGas includes the base fee of the block after the newest of the
+	// range. It only depends on the gas limits of the messages in the newest tipset, so it's known
+	// even if the next tipset wasn't mined yet.
+	nextBaseFee, err := e.chainStore.ComputeBaseFee(ctx, ts)
+	if err != nil {
+		return ethtypes.EthFeeHistory{}, xerrors.Errorf("failed to compute the next base fee: %w", err)
+	}
 
 	var (
-		basefee         = ts.Blocks()[0].ParentBaseFee
-		oldestBlkHeight = uint64(1)
-
-		// NOTE: baseFeePerGas should include the next block after the newest of the returned range,
-		//  because the next base fee can be inferred from the messages in the newest block.
-		//  However, this is NOT the case in Filecoin due to deferred execution, so the best
-		//  we can do is duplicate the last value.
-		baseFeeArray      = []ethtypes.EthBigInt{ethtypes.EthBigInt(basefee)}
+		basefee           abi.TokenAmount
+		oldestBlkHeight   = uint64(1)
+		baseFeeArray      = []ethtypes.EthBigInt{ethtypes.EthBigInt(nextBaseFee)}
 		rewardsArray      = make([][]ethtypes.EthBigInt, 0)
 		gasUsedRatioArray = []float64{}
 		blocksIncluded    int
 	)
 
-	for blocksIncluded < int(params.BlkCount) && ts.Height() > 0 {
+	// A range extending before the EVM was active is clamped to the epochs it was active in.
+	for blocksIncluded < int(params.BlkCount) && ts.Height() > 0 && e.stateManager.GetNetworkVersion(ctx, ts.Height()) >= network.Version18 {
 		basefee = ts.Blocks()[0].ParentBaseFee
 		_, msgs, rcpts, err := executeTipset(ctx, ts, e.chainStore, e.stateManager)
 		if err != nil {
 			return ethtypes.EthFeeHistory{}, xerrors.Errorf("failed to retrieve messages and receipts for height %d: %w", ts.Height(), err)
 		}
 
-		txGasRewards := gasRewardSorter{}
-		for i, msg := range msgs {
-			effectivePremium := msg.VMMessage().EffectiveGasPremium(basefee)
-			txGasRewards = append(txGasRewards, gasRewardTuple{
-				premium: effectivePremium,
-				gasUsed: rcpts[i].GasUsed,
-			})
-		}
-
-		rewards, totalGasUsed := calculateRewardsAndGasUsed(rewardPercentiles, txGasRewards)
+		txGasRewards, totalGasUsed := blockGasRewards(msgs, rcpts, basefee)
+		rewards, _ := calculateRewardsAndGasUsed(rewardPercentiles, txGasRewards)
 		maxGas := buildconstants.BlockGasLimit * int64(len(ts.Blocks()))
 
 		// arrays should be reversed at the end
@@ -182,9 +205,27 @@ func (e *ethGas) EthMaxPriorityFeePerGas(ctx context.Context) (ethtypes.EthBigIn
 }
 
 func (e *ethGas) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error) {
-	params, err := jsonrpc.DecodeParams[ethtypes.EthEstimateGasParams](p)
+	params, err := decodeCallParams[ethtypes.EthEstimateGasParams](p)
 	if err != nil {
-		return ethtypes.EthUint64(0), xerrors.Errorf("decoding params: %w", err)
+		return ethtypes.EthUint64(0), err
+	}
+
+	if err := params.Tx.CheckType(); err != nil {
+		return ethtypes.EthUint64(0), err
+	}
+	// The Lotus extensions changing how eth_call executes a call don't apply to estimation, which
+	// executes the message as it would be sent.
+	switch {
+	case params.Tx.Nonce != nil:
+		return ethtypes.EthUint64(0), api.NewErrInvalidParams(xerrors.New("setting the nonce of a call isn't supported by gas estimation"))
+	case params.Tx.Origin != nil:
+		return ethtypes.EthUint64(0), api.NewErrInvalidParams(xerrors.New("setting the origin of a call isn't supported by gas estimation"))
+	case params.Tx.Affordable:
+		return ethtypes.EthUint64(0), api.NewErrInvalidParams(xerrors.New("affordable calls aren't supported by gas estimation"))
+	}
+	// The init code of a contract creation is taken from the input, which is all it executes.
+	if params.Tx.To == nil && len(params.Tx.Data) == 0 {
+		return ethtypes.EthUint64(0), xerrors.New("contract creation requires init code")
 	}
 
 	msg, err := params.Tx.ToFilecoinMessage()
@@ -206,8 +247,43 @@ func (e *ethGas) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethty
 		}
 	}
 
+	gas, err := e.estimateGas(ctx, params.Tx, msg, ts)
+	if err != nil && params.Tx.To == nil {
+		// A contract creation the sender can't afford fails to estimate; report why.
+		if err := e.checkCreateFunds(ctx, params.Tx, msg, ts); err != nil {
+			return ethtypes.EthUint64(0), err
+		}
+	}
+	return gas, err
+}
+
+// estimateGas estimates the gas limit of msg, the Filecoin message of tx, at ts.
+func (e *ethGas) estimateGas(ctx context.Context, tx ethtypes.EthCall, msg *types.Message, ts *types.TipSet) (ethtypes.EthUint64, error) {
+	var err error
+	if feeCap, premium := tx.FeeParams(); feeCap != nil || premium != nil {
+		if feeCap != nil {
+			msg.GasFeeCap = big.Int(*feeCap)
+		}
+		if premium != nil {
+			msg.GasPremium = big.Int(*premium)
+		}
+		// GasEstimateMessageGas estimates the gas limit for free, i.e. with a zero base fee. When
+		// the caller specified fees, estimate the limit against the real base fee instead so
+		// contracts that depend on the base fee behave as they will on-chain.
+		if !msg.GasFeeCap.NilOrZero() {
+			msg.GasLimit, err = e.estimateGasLimitWithFees(ctx, msg, ts)
+			if err != nil {
+				return ethtypes.EthUint64(0), err
+			}
+		}
+	}
+
 	gassedMsg, err := e.gasApi.GasEstimateMessageGas(ctx, msg, nil, ts.Key())
 	if err != nil {
+		if errors.As(err, new(*api.ErrOutOfGas)) {
+			return ethtypes.EthUint64(0), gasExceedsAllowanceError()
+		}
+
 		// On failure, GasEstimateMessageGas doesn't actually return the invocation result,
 		// it just returns an error. That means we can't get the revert reason.
 		//
@@ -215,7 +291,7 @@ func (e *ethGas) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethty
 		// guts of EthCall). This will give us an ethereum specific error with revert
 		// information.
 		msg.GasLimit = buildconstants.BlockGasLimit
-		if _, err2 := e.applyMessage(ctx, msg, ts.Key()); err2 != nil {
+		if _, err2 := e.applyMessage(ctx, msg, ts.Key(), nil); err2 != nil {
 			// If err2 is an ExecutionRevertedError, return it
 			var ed *api.ErrExecutionReverted
 			if errors.As(err2, &ed) {
@@ -230,30 +306,844 @@ func (e *ethGas) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethty
 	}
 
 	expectedGas, err := ethGasSearch(ctx, e.chainStore, e.stateManager, e.messagePool, gassedMsg, ts)
-	if err != nil {
+	if errors.Is(err, errGasExceedsAllowance) {
+		return 0, gasExceedsAllowanceError()
+	} else if err != nil {
 		return 0, xerrors.Errorf("gas search failed: %w", err)
 	}
 
 	return ethtypes.EthUint64(expectedGas), nil
 }
 
-func (e *ethGas) EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+// checkCreateFunds returns an error if the sender of a contract creation can't pay for the value it
+// transfers and the gas the deployment uses at the call's gas price, which would otherwise only
+// surface as a generic estimation failure. It is only run once estimating msg failed, and returns
+// nil when the funds of the sender aren't the cause, leaving the estimation error to be reported.
+func (e *ethGas) checkCreateFunds(ctx context.Context, tx ethtypes.EthCall, msg *types.Message, ts *types.TipSet) error {
+	balance, err := e.senderBalance(ctx, msg.From, ts)
+	if err != nil {
+		return err
+	}
+
+	cost := big.Zero()
+	if msg.Value.Int != nil {
+		cost = msg.Value
+	}
+	price := big.Int(tx.GasPrice)
+	if feeCap, _ := tx.FeeParams(); feeCap != nil {
+		price = big.Int(*feeCap)
+	}
+	// Only price the gas of the deployment when the value alone is affordable. The deployment is
+	// executed for free, so its unscaled gas usage is known even if the sender can't pay for it.
+	if price.Int != nil && price.Sign() > 0 && !balance.LessThan(cost) {
+		freeMsg := *msg
+		freeMsg.GasLimit = buildconstants.BlockGasLimit
+		freeMsg.GasFeeCap = big.Zero()
+		freeMsg.GasPremium = big.Zero()
+		res, err := e.applyMessage(ctx, &freeMsg, ts.Key(), nil)
+		if res == nil {
+			return err
+		}
+		cost = big.Add(cost, big.Mul(price, big.NewInt(res.MsgRct.GasUsed)))
+	}
+
+	if balance.LessThan(cost) {
+		return xerrors.Errorf("insufficient funds for gas * price + value: address %s have %s want %s", senderEthAddress(tx), balance, cost)
+	}
+	return nil
+}
+
+// estimateGasLimitWithFees executes msg against the base fee of ts, rather than the zero base fee
+// GasEstimateGasLimit executes it with, and returns the gas used scaled by the mpool's
+// overestimation factor. The base fee is only applied as an override: the fee cap and premium of
+// msg are cleared, so nothing is charged and senders needn't afford the block gas limit the
+// message is executed with. Like GasEstimateGasLimit, the pending messages of the sender are
+// applied first.
+func (e *ethGas) estimateGasLimitWithFees(ctx context.Context, msgIn *types.Message, ts *types.TipSet) (int64, error) {
+	msg := *msgIn
+	msg.GasLimit = buildconstants.BlockGasLimit
+	msg.GasFeeCap = big.Zero()
+	msg.GasPremium = big.Zero()
+
+	pending, _ := e.messagePool.PendingFor(ctx, msg.From)
+	priorMsgs := make([]types.ChainMsg, 0, len(pending))
+	for _, m := range pending {
+		if m.Message.Nonce == msg.Nonce {
+			break
+		}
+		priorMsgs = append(priorMsgs, m)
+	}
+
+	baseFee := ts.Blocks()[0].ParentBaseFee
+	res, err := e.applyMessage(ctx, &msg, ts.Key(), &stmgr.CallOptions{PriorMessages: priorMsgs, BaseFee: &baseFee})
+	if res != nil && res.MsgRct.ExitCode == exitcode.SysErrOutOfGas {
+		return 0, gasExceedsAllowanceError()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	gasLimit := int64(float64(res.MsgRct.GasUsed) * e.messagePool.GetConfig().GasLimitOverestimation)
+	if gasLimit > buildconstants.BlockGasLimit {
+		gasLimit = buildconstants.BlockGasLimit
+	}
+	return gasLimit, nil
+}
+
+func (e *ethGas) EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	params, err := decodeCallParams[ethtypes.EthCallParams](p)
+	if err != nil {
+		return nil, err
+	}
+
+	invokeResult, err := e.ethCall(ctx, params, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ethCallReturnData(invokeResult)
+}
+
+func (e *ethGas) EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	params, err := decodeCallParams[ethtypes.EthCallParams](p)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ethtypes.EthCallDetailedResult
+	var sender callSender
+	// The state the call resulted in, to resolve the addresses it touched and the balance it left
+	// the sender with.
+	var postState *state.StateTree
+
+	var evs []types.Event
+
+	inspect := func(ctx context.Context, st *state.StateTree, rct *types.MessageReceipt) error {
+		postState = st
+		if params.Tx.To != nil {
+			return nil
+		}
+		// For contract creations, look up the created contract and its runtime code in the state
+		// the call resulted in.
+		return inspectCreation(ctx, st, rct, &result)
+	}
+	events := func(ctx context.Context, emitted []types.Event) error {
+		evs = emitted
+		return nil
+	}
+
+	invokeResult, err := e.ethCall(ctx, params, inspect, events, &sender)
+	if err != nil {
+		return nil, err
+	}
+	if len(evs) > 0 {
+		if postState == nil {
+			return nil, xerrors.New("the state the call resulted in is unavailable")
+		}
+		result.Logs, err = ethCallLogs(evs, postState, params.Tx.EventsABI)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.Tx.To != nil {
+		result.ReturnData, err = ethCallReturnData(invokeResult)
+		if err != nil {
+			return nil, err
+		}
+		if len(params.Tx.OutputTypes) > 0 {
+			result.Output, err = decodeCallOutput(params.Tx.OutputTypes, result.ReturnData)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if params.Tx.ReportTouched {
+		if postState == nil {
+			return nil, xerrors.New("the state the call resulted in is unavailable")
+		}
+		result.Touched, err = touchedAddresses(invokeResult.ExecutionTrace, postState)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if params.Tx.ReportBalance {
+		if postState == nil {
+			return nil, xerrors.New("the state the call resulted in is unavailable")
+		}
+		act, err := postState.GetActor(invokeResult.Msg.From)
+		if err != nil {
+			return nil, xerrors.Errorf("loading sender after the call: %w", err)
+		}
+		before, after := ethtypes.EthBigInt(sender.balance), ethtypes.EthBigInt(act.Balance)
+		result.SenderBalanceBefore, result.SenderBalanceAfter = &before, &after
+	}
+	if postState != nil {
+		// Failed calls are reverted, so only successful ones can change any state.
+		result.StateChanged, err = stateChanged(ctx, sender.root, postState, invokeResult.Msg.From)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Sender = callSenderKind(sender.exists)
+	result.GasLimit = ethtypes.EthUint64(invokeResult.Msg.GasLimit)
+	return &result, nil
+}
+
+// stateChanged returns whether the state st a call from sender resulted in differs from the state
+// with the given root it was applied on, other than in the nonce of the sender and in its code, as
+// placeholder senders become Ethereum accounts when sending their first message.
+func stateChanged(ctx context.Context, root cid.Cid, st *state.StateTree, sender address.Address) (bool, error) {
+	before, err := state.LoadStateTree(st.Store, root)
+	if err != nil {
+		return false, xerrors.Errorf("loading the state the call was applied on: %w", err)
+	}
+	senderBefore, err := before.GetActor(sender)
+	if err != nil {
+		return false, xerrors.Errorf("loading sender before the call: %w", err)
+	}
+
+	// Restore the nonce and code of the sender in a copy of the resulting state, and compare the roots.
+	afterRoot, err := st.Flush(ctx)
+	if err != nil {
+		return false, xerrors.Errorf("flushing the state the call resulted in: %w", err)
+	}
+	after, err := state.LoadStateTree(st.Store, afterRoot)
+	if err != nil {
+		return false, xerrors.Errorf("loading the state the call resulted in: %w", err)
+	}
+	senderAfter, err := after.GetActor(sender)
+	if err != nil {
+		return false, xerrors.Errorf("loading sender after the call: %w", err)
+	}
+	restored := *senderAfter
+	restored.Nonce, restored.Code = senderBefore.Nonce, senderBefore.Code
+	if err := after.SetActor(sender, &restored); err != nil {
+		return false, xerrors.Errorf("restoring the sender: %w", err)
+	}
+	restoredRoot, err := after.Flush(ctx)
+	if err != nil {
+		return false, xerrors.Errorf("flushing the state the call resulted in: %w", err)
+	}
+	return restoredRoot != root, nil
+}
+
+// ethCallLogs converts the events emitted by a call to logs, decoding those emitted by one of the
+// events of eventsABI.
+func ethCallLogs(evs []types.Event, st *state.StateTree, eventsABI []ethtypes.EthABIEvent) ([]ethtypes.EthCallLog, error) {
+	logs := make([]ethtypes.EthCallLog, 0, len(evs))
+	for _, ev := range evs {
+		data, topics, ok := ethLogFromEvent(ev.Entries)
+		if !ok {
+			// Not an EVM log, such as an event emitted by a built-in actor.
+			continue
+		}
+		emitter, err := lookupEthAddress(address.NewIDAddress(uint64(ev.Emitter)), st)
+		if err != nil {
+			return nil, xerrors.Errorf("resolving the emitter of a log: %w", err)
+		}
+		ethLog := ethtypes.EthCallLog{
+			Address: emitter,
+			Data:    data,
+			Topics:  topics,
+		}
+		for _, event := range eventsABI {
+			fields, ok, err := event.DecodeLog(topics, data)
+			if err != nil {
+				return nil, xerrors.Errorf("decoding log of %s: %w", event.Name, err)
+			}
+			if ok {
+				ethLog.Event, ethLog.Fields = event.Name, fields
+				break
+			}
+		}
+		logs = append(logs, ethLog)
+	}
+	return logs, nil
+}
+
+// decodeCallOutput decodes the data returned by a call as values of the given ABI types.
+func decodeCallOutput(outputTypes []string, data []byte) ([]any, error) {
+	outputs := make([]ethtypes.EthABIParam, len(outputTypes))
+	for i, typ := range outputTypes {
+		outputs[i] = ethtypes.EthABIParam{Name: fmt.Sprintf("output %d", i), Type: typ}
+	}
+	values, err := ethtypes.DecodeABIValues(outputs, data)
+	if err != nil {
+		return nil, xerrors.Errorf("decoding the return data: %w", err)
+	}
+	return values, nil
+}
+
+func inspectCreation(ctx context.Context, st *state.StateTree, rct *types.MessageReceipt, result *ethtypes.EthCallDetailedResult) error {
+	var ret eam.CreateExternalReturn
+	if err := ret.UnmarshalCBOR(bytes.NewReader(rct.Return)); err != nil {
+		return xerrors.Errorf("failed to parse contract creation result: %w", err)
+	}
+	createdAddr := ethtypes.EthAddress(ret.EthAddress)
+	result.CreatedAddress = &createdAddr
+
+	idAddr, err := address.NewIDAddress(ret.ActorID)
+	if err != nil {
+		return err
+	}
+	actor, err := st.GetActor(idAddr)
+	if err != nil {
+		return xerrors.Errorf("loading created actor: %w", err)
+	}
+	evmState, err := evm.Load(adt.WrapStore(ctx, st.Store), actor)
+	if err != nil {
+		return xerrors.Errorf("loading created actor state: %w", err)
+	}
+	result.ReturnData, err = evmState.GetBytecode()
+	if err != nil {
+		return xerrors.Errorf("loading created actor bytecode: %w", err)
+	}
+	codeHash, err := evmState.GetBytecodeHash()
+	if err != nil {
+		return xerrors.Errorf("loading created actor bytecode hash: %w", err)
+	}
+	codeSize := ethtypes.EthUint64(len(result.ReturnData))
+	result.CodeSize = &codeSize
+	result.CodeHash = (*ethtypes.EthHash)(&codeHash)
+	return nil
+}
+
+// touchedAddresses returns the sender of the traced message followed by the recipients of the
+// message and of its subcalls, in the order they were first touched, resolved in st.
+func touchedAddresses(et types.ExecutionTrace, st *state.StateTree) ([]ethtypes.EthAddress, error) {
+	var touched []ethtypes.EthAddress
+	seen := make(map[ethtypes.EthAddress]struct{})
+	touch := func(addr address.Address) error {
+		ethAddr, err := lookupEthAddress(addr, st)
+		if err != nil {
+			return xerrors.Errorf("resolving touched address %s: %w", addr, err)
+		}
+		if _, ok := seen[ethAddr]; !ok {
+			seen[ethAddr] = struct{}{}
+			touched = append(touched, ethAddr)
+		}
+		return nil
+	}
+
+	if err := forEachTouched(&et, touch); err != nil {
+		return nil, err
+	}
+	return touched, nil
+}
+
+// forEachTouched invokes touch with the sender of the traced message followed by the recipients of
+// the message and of its subcalls, in order. Addresses touched several times are repeated.
+func forEachTouched(et *types.ExecutionTrace, touch func(address.Address) error) error {
+	if err := touch(et.Msg.From); err != nil {
+		return err
+	}
+	var walk func(et *types.ExecutionTrace) error
+	walk = func(et *types.ExecutionTrace) error {
+		if err := touch(et.Msg.To); err != nil {
+			return err
+		}
+		for i := range et.Subcalls {
+			if err := walk(&et.Subcalls[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(et)
+}
+
+func callSenderKind(exists bool) string {
+	if exists {
+		return ethtypes.EthCallSenderExisting
+	}
+	return ethtypes.EthCallSenderSynthetic
+}
+
+// callSender describes the sender of a call before it is applied.
+type callSender struct {
+	// exists is whether the sender had an actor before the call, rather than one being created for
+	// it.
+	exists bool
+	// balance is the balance of the sender once the state overrides are applied.
+	balance big.Int
+	// root is the root of the state the call is applied on, once the state overrides are applied.
+	root cid.Cid
+	// store holds the state with the given root, which may only exist for the call.
+	store blockstore.Blockstore
+}
+
+// ethCall applies the call described by params, optionally inspecting the resulting state and the
+// events the call emitted. If sender is set, it is set to describe the sender of the call. The
+// result of reverted calls is returned along with their error.
+func (e *ethGas) ethCall(
+	ctx context.Context,
+	params ethtypes.EthCallParams,
+	inspect func(context.Context, *state.StateTree, *types.MessageReceipt) error,
+	events func(context.Context, []types.Event) error,
+	sender *callSender,
+) (*api.InvocResult, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.call")
+	defer span.End()
+
+	tx := params.Tx
+
+	if err := tx.CheckType(); err != nil {
+		return nil, err
+	}
+
 	msg, err := tx.ToFilecoinMessage()
 	if err != nil {
 		return nil, xerrors.Errorf("failed to convert ethcall to filecoin message: %w", err)
 	}
 
-	ts, err := e.tipsetResolver.GetTipsetByBlockNumberOrHash(ctx, blkParam)
+	params.StateOverrides, err = withCallNonce(params.StateOverrides, tx)
+	if err != nil {
+		return nil, err
+	}
+	params.StateOverrides, err = withCallOrigin(params.StateOverrides, tx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := e.callTipSet(ctx, params.BlkParam)
+	if err != nil {
+		return nil, err // don't wrap, to preserve ErrNullRound
+	}
+
+	if tx.To != nil && len(tx.Data) > 0 && !overridesCode(params.StateOverrides, *tx.To) {
+		if err := e.checkCallTarget(ctx, *tx.To, ts); err != nil {
+			return nil, err
+		}
+	}
+
+	if blk := params.BlockOverrides; blk != nil && blk.GasLimit != nil && *blk.GasLimit < ethtypes.EthUint64(msg.GasLimit) {
+		msg.GasLimit = int64(*blk.GasLimit)
+	}
+	if tx.Affordable {
+		msg.GasLimit, err = e.affordableGasLimit(ctx, tx, msg, params.StateOverrides, params.BlockOverrides, ts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Calls at the pending block are applied behind the messages of the message pool, to see their
+	// effects, e.g. on the nonce of their sender or on balances.
+	var priorMsgs []types.ChainMsg
+	if blk := params.BlkParam; blk != nil && blk.PredefinedBlock != nil && *blk.PredefinedBlock == ethtypes.BlockTagPending {
+		var sender address.Address
+		if tx.From != nil {
+			sender = msg.From
+		}
+		priorMsgs = pendingStateMessages(ctx, e.messagePool, e.stateManager, ts, sender)
+	}
+
+	var opts *stmgr.CallOptions
+	stateOverride := e.callStateOverride(params.StateOverrides, msg.From)
+	if sender != nil {
+		stateOverride = checkSenderExists(msg.From, &sender.exists, recordSenderBalance(msg.From, &sender.balance, stateOverride))
+	}
+	if stateOverride != nil || inspect != nil || events != nil || params.BlockOverrides != nil || len(priorMsgs) > 0 {
+		opts = &stmgr.CallOptions{PriorMessages: priorMsgs, StateOverride: stateOverride, Inspect: inspect, Events: events}
+	}
+	if params.BlockOverrides != nil {
+		if err := withBlockOverrides(opts, params.BlockOverrides, ts); err != nil {
+			return nil, err
+		}
+	}
+	opts, err = withStorageOverrides(opts, params.StateOverrides)
+	if err != nil {
+		return nil, err
+	}
+	if sender != nil {
+		opts = recordStateRoot(opts, &sender.root, &sender.store)
+	}
+
+	res, err := e.applyMessage(ctx, msg, ts.Key(), opts)
+	if tx.To == nil && err != nil && res != nil && res.MsgRct.ExitCode == exitcode.ErrIllegalArgument {
+		return nil, errMaxCodeSizeExceeded
+	}
+	return res, err
+}
+
+// affordableGasLimit returns the gas limit of msg capped to the gas its sender can pay for at the
+// call's gas price, once the value of msg is transferred. Calls setting a maximum fee per gas pay
+// the base fee and their priority fee, up to that maximum. Balance and base fee overrides are
+// taken into account. Calls without a gas price aren't capped.
+func (e *ethGas) affordableGasLimit(ctx context.Context, tx ethtypes.EthCall, msg *types.Message, overrides ethtypes.EthStateOverrides, blockOverrides *ethtypes.EthBlockOverrides, ts *types.TipSet) (int64, error) {
+	price := big.Int(tx.GasPrice)
+	if feeCap, premium := tx.FeeParams(); feeCap != nil {
+		tip := big.Zero()
+		if premium != nil {
+			tip = big.Int(*premium)
+		}
+		price = big.Min(big.Int(*feeCap), big.Add(callBaseFee(blockOverrides, ts), tip))
+	}
+	if price.Int == nil || price.Sign() <= 0 {
+		return msg.GasLimit, nil
+	}
+
+	var balance big.Int
+	if override, ok := overrides[senderEthAddress(tx)]; ok && override.Balance != nil {
+		balance = big.Int(*override.Balance)
+	} else {
+		var err error
+		balance, err = e.senderBalance(ctx, msg.From, ts)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	available := big.Sub(balance, msg.Value)
+	if available.Sign() < 0 {
+		return 0, xerrors.Errorf("insufficient funds for transfer: balance %s, value %s", balance, msg.Value)
+	}
+	if affordable := big.Div(available, price); affordable.LessThan(big.NewInt(msg.GasLimit)) {
+		return affordable.Int64(), nil
+	}
+	return msg.GasLimit, nil
+}
+
+// senderBalance returns the balance of the sender at ts, which is zero if it doesn't exist yet.
+func (e *ethGas) senderBalance(ctx context.Context, from address.Address, ts *types.TipSet) (big.Int, error) {
+	stateCid, _, err := e.stateManager.TipSetState(ctx, ts)
+	if err != nil {
+		return big.Int{}, xerrors.Errorf("cannot get tipset state: %w", err)
+	}
+	actor, err := e.stateManager.LoadActorRaw(ctx, from, stateCid)
+	if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+		return big.Int{}, xerrors.Errorf("loading sender: %w", err)
+	}
+	if actor == nil {
+		return big.Zero(), nil
+	}
+	return actor.Balance, nil
+}
+
+// withBlockOverrides sets the block overrides of a call at ts in opts.
+func withBlockOverrides(opts *stmgr.CallOptions, overrides *ethtypes.EthBlockOverrides, ts *types.TipSet) error {
+	if overrides.BaseFeePerGas != nil {
+		baseFee := big.Int(*overrides.BaseFeePerGas)
+		opts.BaseFee = &baseFee
+	}
+	if overrides.Number != nil {
+		if abi.ChainEpoch(*overrides.Number) < ts.Height() {
+			return xerrors.Errorf("block number override %d precedes the block the call is simulated in (%d)", *overrides.Number, ts.Height())
+		}
+		epoch := abi.ChainEpoch(*overrides.Number)
+		opts.Epoch = &epoch
+	}
+	if overrides.Time != nil {
+		timestamp := uint64(*overrides.Time)
+		opts.Timestamp = &timestamp
+	}
+	if overrides.PrevRandao != nil {
+		randomness := [32]byte(*overrides.PrevRandao)
+		opts.BeaconRandomness = &randomness
+	}
+	if overrides.Coinbase != nil && *overrides.Coinbase != (ethtypes.EthAddress{}) {
+		return xerrors.Errorf("cannot override the coinbase with %s: the COINBASE opcode always reports the zero address", *overrides.Coinbase)
+	}
+	if overrides.ChainRandomness != nil {
+		randomness := [32]byte(*overrides.ChainRandomness)
+		opts.ChainRandomness = &randomness
+	}
+	if overrides.ChainID != nil {
+		chainID := uint64(*overrides.ChainID)
+		opts.ChainID = &chainID
+	}
+	return nil
+}
+
+// callBaseFee returns the base fee a call at ts pays, unless it is overridden.
+func callBaseFee(overrides *ethtypes.EthBlockOverrides, ts *types.TipSet) big.Int {
+	if overrides != nil && overrides.BaseFeePerGas != nil {
+		return big.Int(*overrides.BaseFeePerGas)
+	}
+	return ts.Blocks()[0].ParentBaseFee
+}
+
+// decodeCallParams decodes the parameters of a method simulating calls. As they are all supplied by
+// the client, failing to decode them, e.g. because of a negative gas price, is reported as an
+// invalid params error.
+func decodeCallParams[T any](p jsonrpc.RawParams) (T, error) {
+	params, err := jsonrpc.DecodeParams[T](p)
+	if err != nil {
+		return params, api.NewErrInvalidParams(xerrors.Errorf("decoding params: %w", err))
+	}
+	return params, nil
+}
+
+// senderEthAddress returns the sender of the call, which is the zero address if it isn't set.
+func senderEthAddress(tx ethtypes.EthCall) ethtypes.EthAddress {
+	if tx.From == nil {
+		return ethtypes.EthAddress{}
+	}
+	return *tx.From
+}
+
+func (e *ethGas) EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	params, err := decodeCallParams[ethtypes.EthCallManyParams](p)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Calls) == 0 {
+		return nil, xerrors.New("no calls to simulate")
+	}
+
+	msgs := make([]*types.Message, 0, len(params.Calls))
+	senders := make([]address.Address, 0, len(params.Calls))
+	for i, tx := range params.Calls {
+		if err := tx.CheckType(); err != nil {
+			return nil, xerrors.Errorf("call %d: %w", i, err)
+		}
+		// The nonce of later calls depends on the calls before them, so it can't be set per call.
+		if tx.Nonce != nil {
+			return nil, xerrors.Errorf("call %d: setting the nonce of a call isn't supported when simulating several calls, use a state override instead", i)
+		}
+		if tx.Origin != nil {
+			return nil, xerrors.Errorf("call %d: setting the origin of a call isn't supported when simulating several calls", i)
+		}
+		msg, err := tx.ToFilecoinMessage()
+		if err != nil {
+			return nil, xerrors.Errorf("failed to convert call %d to filecoin message: %w", i, err)
+		}
+		msgs = append(msgs, msg)
+		senders = append(senders, msg.From)
+	}
+
+	ts, err := e.callTipSet(ctx, params.BlkParam)
 	if err != nil {
 		return nil, err // don't wrap, to preserve ErrNullRound
 	}
 
-	invokeResult, err := e.applyMessage(ctx, msg, ts.Key())
+	// Call targets aren't checked as they may be deployed by earlier calls of the bundle.
+	var opts *stmgr.CallOptions
+	if stateOverride := e.callStateOverride(params.StateOverrides, senders...); stateOverride != nil {
+		opts = &stmgr.CallOptions{StateOverride: stateOverride}
+	}
+	opts, err = withStorageOverrides(opts, params.StateOverrides)
 	if err != nil {
 		return nil, err
 	}
+	// Senders of reverted calls are resolved in the state the bundle is applied on, when tracing.
+	var traceState struct {
+		root  cid.Cid
+		store blockstore.Blockstore
+		tree  *state.StateTree
+	}
+	if params.Options.TraceReverts {
+		opts = recordStateRoot(opts, &traceState.root, &traceState.store)
+	}
+
+	st, err := e.callState(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	invokeResults, err := e.stateManager.ApplyManyOnStateWithOptions(ctx, st, msgs, ts, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to apply calls: %w", err)
+	}
+
+	results := make([]ethtypes.EthCallManyResult, len(invokeResults))
+	for i, res := range invokeResults {
+		results[i].ReturnData = ethtypes.EthBytes{}
+		switch {
+		case res.MsgRct.ExitCode.IsError():
+			results[i].Error = api.NewErrExecutionRevertedFromResult(res).Error()
+			if revertData, err := cbg.ReadByteArray(bytes.NewReader(res.MsgRct.Return), uint64(len(res.MsgRct.Return))); err == nil {
+				results[i].ReturnData = revertData
+			}
+			if !params.Options.TraceReverts {
+				break
+			}
+			if traceState.tree == nil {
+				traceState.tree, err = state.LoadStateTree(cbor.NewCborStore(traceState.store), traceState.root)
+				if err != nil {
+					return nil, xerrors.Errorf("loading the state the calls were applied on: %w", err)
+				}
+			}
+			results[i].Trace, err = callFrame(traceState.tree, res)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to trace call %d: %w", i, err)
+			}
+		case res.Msg.To == builtintypes.EthereumAddressManagerActorAddr:
+			var ret eam.CreateExternalReturn
+			if err := ret.UnmarshalCBOR(bytes.NewReader(res.MsgRct.Return)); err != nil {
+				return nil, xerrors.Errorf("failed to parse contract creation result of call %d: %w", i, err)
+			}
+			createdAddr := ethtypes.EthAddress(ret.EthAddress)
+			results[i].CreatedAddress = &createdAddr
+		default:
+			results[i].ReturnData, err = ethCallReturnData(res)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to read the return data of call %d: %w", i, err)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (e *ethGas) EthCallAtStateRoot(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	params, err := decodeCallParams[ethtypes.EthCallAtStateRootParams](p)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := params.Tx
+	if err := tx.CheckType(); err != nil {
+		return nil, err
+	}
+	msg, err := tx.ToFilecoinMessage()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to convert ethcall to filecoin message: %w", err)
+	}
+
+	if _, err := e.stateManager.StateTree(params.StateRoot); err != nil {
+		return nil, xerrors.Errorf("cannot load state root %s: %w", params.StateRoot, err)
+	}
+
+	// The state root isn't tied to a tipset, so the call is applied at the epoch of the heaviest
+	// tipset.
+	ts := e.chainStore.GetHeaviestTipSet()
+
+	var opts *stmgr.CallOptions
+	if stateOverride := e.callStateOverride(nil, msg.From); stateOverride != nil {
+		opts = &stmgr.CallOptions{StateOverride: stateOverride}
+	}
+
+	res, err := e.stateManager.ApplyOnStateWithOptions(ctx, params.StateRoot, msg, ts, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to apply call: %w", err)
+	}
+	if res.MsgRct.ExitCode.IsError() {
+		return nil, api.NewErrExecutionRevertedFromResult(res)
+	}
+	return ethCallReturnData(res)
+}
+
+func (e *ethGas) EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
+	params, err := decodeCallParams[ethtypes.EthTraceCallParams](p)
+	if err != nil {
+		return nil, err
+	}
+
+	config := params.Config
+	switch config.Tracer {
+	case ethtypes.EthCallTracer, ethtypes.EthPrestateTracer:
+	case "":
+		return nil, api.NewErrInvalidParams(xerrors.New("the opcode logger isn't supported, as the FVM doesn't trace EVM instructions: use the callTracer or the prestateTracer"))
+	default:
+		return nil, api.NewErrInvalidParams(xerrors.Errorf("unsupported tracer %q", config.Tracer))
+	}
+
+	callParams := ethtypes.EthCallParams{
+		Tx:             params.Tx,
+		BlkParam:       params.BlkParam,
+		StateOverrides: config.StateOverrides,
+		BlockOverrides: config.BlockOverrides,
+	}
+	var sender callSender
+	invokeResult, err := e.ethCall(ctx, callParams, nil, nil, &sender)
+	// Reverted calls are traced too, to tell why they reverted.
+	var reverted *api.ErrExecutionReverted
+	if err != nil && (invokeResult == nil || !errors.As(err, &reverted)) {
+		return nil, err
+	}
 
-	if msg.To == builtintypes.EthereumAddressManagerActorAddr {
+	// Addresses are resolved in the state the call was applied on.
+	st, err := state.LoadStateTree(cbor.NewCborStore(sender.store), sender.root)
+	if err != nil {
+		return nil, xerrors.Errorf("loading the state the call was applied on: %w", err)
+	}
+
+	if config.Tracer == ethtypes.EthPrestateTracer {
+		prestate, err := callPrestate(ctx, invokeResult.ExecutionTrace, st)
+		if err != nil {
+			return nil, err
+		}
+		return &ethtypes.EthTraceCallResult{Prestate: prestate}, nil
+	}
+
+	frame, err := callFrame(st, invokeResult)
+	if err != nil {
+		return nil, err
+	}
+	if config.TracerConfig != nil && config.TracerConfig.OnlyTopCall {
+		frame.Calls = nil
+	}
+	return &ethtypes.EthTraceCallResult{CallFrame: frame}, nil
+}
+
+// callPrestate returns the accounts touched by the traced call as they are in st, the state it was
+// applied on. Accounts created by the call aren't included.
+func callPrestate(ctx context.Context, et types.ExecutionTrace, st *state.StateTree) (ethtypes.EthPrestate, error) {
+	prestate := make(ethtypes.EthPrestate)
+	touch := func(addr address.Address) error {
+		act, err := st.GetActor(addr)
+		if errors.Is(err, types.ErrActorNotFound) {
+			return nil
+		} else if err != nil {
+			return xerrors.Errorf("loading touched actor %s: %w", addr, err)
+		}
+		ethAddr, err := lookupEthAddress(addr, st)
+		if err != nil {
+			return xerrors.Errorf("resolving touched address %s: %w", addr, err)
+		}
+		if _, ok := prestate[ethAddr]; ok {
+			return nil
+		}
+
+		account := ethtypes.EthPrestateAccount{
+			Balance: ethtypes.EthBigInt(act.Balance),
+			Nonce:   ethtypes.EthUint64(act.Nonce),
+		}
+		if builtinactors.IsEvmActor(act.Code) {
+			evmState, err := evm.Load(adt.WrapStore(ctx, st.Store), act)
+			if err != nil {
+				return xerrors.Errorf("loading evm state of %s: %w", ethAddr, err)
+			}
+			nonce, err := evmState.Nonce()
+			if err != nil {
+				return err
+			}
+			account.Nonce = ethtypes.EthUint64(nonce)
+			if account.Code, err = evmState.GetBytecode(); err != nil {
+				return xerrors.Errorf("loading bytecode of %s: %w", ethAddr, err)
+			}
+		}
+		prestate[ethAddr] = account
+		return nil
+	}
+
+	if err := forEachTouched(&et, touch); err != nil {
+		return nil, err
+	}
+	return prestate, nil
+}
+
+// callStateOverride returns the state override to apply to calls from the given senders, or nil if
+// the state doesn't need to be changed.
+func (e *ethGas) callStateOverride(overrides ethtypes.EthStateOverrides, senders ...address.Address) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+	var stateOverride func(context.Context, blockstore.Blockstore, *state.StateTree) error
+	if len(overrides) > 0 {
+		stateOverride = stateOverrideFunc(overrides)
+	}
+	// Calls are made for free, so unless in strict mode, let them be made from accounts that don't
+	// exist on chain yet, as Ethereum does. In strict mode, such calls are rejected with a clear error.
+	for _, sender := range senders {
+		switch {
+		case e.strictCallMode:
+			stateOverride = requireSender(sender, stateOverride)
+		case sender.Protocol() == address.Delegated:
+			stateOverride = createMissingSender(sender, stateOverride)
+		}
+	}
+	return stateOverride
+}
+
+// ethCallReturnData returns the data an Ethereum call returned.
+func ethCallReturnData(invokeResult *api.InvocResult) (ethtypes.EthBytes, error) {
+	if invokeResult.Msg.To == builtintypes.EthereumAddressManagerActorAddr {
 		return ethtypes.EthBytes{}, nil
 	} else if len(invokeResult.MsgRct.Return) > 0 {
 		return cbg.ReadByteArray(bytes.NewReader(invokeResult.MsgRct.Return), uint64(len(invokeResult.MsgRct.Return)))
@@ -262,37 +1152,115 @@ func (e *ethGas) EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam etht
 	return ethtypes.EthBytes{}, nil
 }
 
-func (e *ethGas) applyMessage(ctx context.Context, msg *types.Message, tsk types.TipSetKey) (res *api.InvocResult, err error) {
+// callTipSet resolves the block a call is simulated in, the latest one by default. Unlike other
+// methods, calls accept "earliest", referring to the genesis state. Calls can't be simulated before
+// the EVM was introduced.
+func (e *ethGas) callTipSet(ctx context.Context, blkParam *ethtypes.EthBlockNumberOrHash) (*types.TipSet, error) {
+	param := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagLatest)
+	if blkParam != nil {
+		param = *blkParam
+	}
+	if param.PredefinedBlock != nil && *param.PredefinedBlock == ethtypes.BlockTagEarliest {
+		genesis := ethtypes.EthUint64(0)
+		param = ethtypes.EthBlockNumberOrHash{BlockNumber: &genesis}
+	}
+
+	ts, err := e.tipsetResolver.GetTipsetByBlockNumberOrHash(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	if nv := e.stateManager.GetNetworkVersion(ctx, ts.Height()); nv < network.Version18 {
+		return nil, xerrors.Errorf("cannot simulate calls at epoch %d: the EVM was introduced in network version 18, the network was at version %d", ts.Height(), nv)
+	}
+	return ts, nil
+}
+
+// checkCallTarget flags calls carrying input data to an address that has no EVM bytecode, which
+// almost always means the client got the address wrong. Such calls succeed with an empty result on
+// Ethereum, so they are only rejected when strict mode is enabled. Calls to built-in actors other
+// than accounts, such as miners, are always rejected, as they can't handle EVM calldata.
+func (e *ethGas) checkCallTarget(ctx context.Context, to ethtypes.EthAddress, ts *types.TipSet) error {
+	toAddr, err := to.ToFilecoinAddress()
+	if err != nil {
+		return xerrors.Errorf("cannot get Filecoin address: %w", err)
+	}
+
+	stateCid, _, err := e.stateManager.TipSetState(ctx, ts)
+	if err != nil {
+		return xerrors.Errorf("cannot get tipset state: %w", err)
+	}
+
+	actor, err := e.stateManager.LoadActorRaw(ctx, toAddr, stateCid)
+	if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+		return xerrors.Errorf("failed to lookup call target %s: %w", to, err)
+	}
+	if actor != nil && builtinactors.IsEvmActor(actor.Code) {
+		return nil
+	}
+	if actor != nil && !builtinactors.IsAccountActor(actor.Code) && !builtinactors.IsPlaceholderActor(actor.Code) && !builtinactors.IsEthAccountActor(actor.Code) {
+		return xerrors.Errorf("target is not an EVM contract: %s", to)
+	}
+
+	if e.strictCallMode {
+		return xerrors.Errorf("call to non-contract with data: %s", to)
+	}
+	log.Warnw("eth_call with data to an address with no contract code", "to", to)
+	return nil
+}
+
+func (e *ethGas) applyMessage(ctx context.Context, msg *types.Message, tsk types.TipSetKey, opts *stmgr.CallOptions) (res *api.InvocResult, err error) {
 	ts, err := e.chainStore.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
 		return nil, xerrors.Errorf("cannot get tipset: %w", err)
 	}
 
+	st, err := e.callState(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := trace.StartSpan(ctx, "eth.applyMessage")
+	defer span.End()
+
+	res, err = e.stateManager.ApplyOnStateWithOptions(ctx, st, msg, ts, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("ApplyWithGasOnState failed: %w", err)
+	}
+	span.AddAttributes(
+		trace.Int64Attribute("gas_used", res.MsgRct.GasUsed),
+		trace.Int64Attribute("exit_code", int64(res.MsgRct.ExitCode)),
+	)
+
+	// The result of failed messages is returned along with the error, to trace them.
+	if res.MsgRct.ExitCode.IsError() {
+		return res, api.NewErrExecutionRevertedFromResult(res)
+	}
+
+	return res, nil
+}
+
+// callState returns the state calls at the given tipset are applied on.
+func (e *ethGas) callState(ctx context.Context, ts *types.TipSet) (cid.Cid, error) {
+	ctx, span := trace.StartSpan(ctx, "eth.callState")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("height", int64(ts.Height())))
+
 	if ts.Height() > 0 {
 		pts, err := e.chainStore.GetTipSetFromKey(ctx, ts.Parents())
 		if err != nil {
-			return nil, xerrors.Errorf("failed to find a non-forking epoch: %w", err)
+			return cid.Undef, xerrors.Errorf("failed to find a non-forking epoch: %w", err)
 		}
 		// Check for expensive forks from the parents to the tipset, including nil tipsets
 		if e.stateManager.HasExpensiveForkBetween(pts.Height(), ts.Height()+1) {
-			return nil, stmgr.ErrExpensiveFork
+			return cid.Undef, stmgr.ErrExpensiveFork
 		}
 	}
 
 	st, _, err := e.stateManager.TipSetState(ctx, ts)
 	if err != nil {
-		return nil, xerrors.Errorf("cannot get tipset state: %w", err)
-	}
-	res, err = e.stateManager.ApplyOnStateWithGas(ctx, st, msg, ts)
-	if err != nil {
-		return nil, xerrors.Errorf("ApplyWithGasOnState failed: %w", err)
+		return cid.Undef, xerrors.Errorf("cannot get tipset state: %w", err)
 	}
-
-	if res.MsgRct.ExitCode.IsError() {
-		return nil, api.NewErrExecutionRevertedFromResult(res)
-	}
-
-	return res, nil
+	return st, nil
 }
 
 // ethGasSearch executes a message for gas estimation using the previously estimated gas.
@@ -331,6 +1299,16 @@ func ethGasSearch(
 	return -1, api.NewErrExecutionRevertedFromResult(res)
 }
 
+// errGasExceedsAllowance is returned by gasSearch when a message fails even with the block gas
+// limit.
+var errGasExceedsAllowance = xerrors.New("gas required exceeds allowance")
+
+// gasExceedsAllowanceError returns the error Geth reports when a call can't succeed with the
+// largest gas limit it can be given, the block gas limit.
+func gasExceedsAllowanceError() error {
+	return xerrors.Errorf("gas required exceeds allowance (%d) or always failing transaction", buildconstants.BlockGasLimit)
+}
+
 func traceContainsExitCode(et types.ExecutionTrace, ex exitcode.ExitCode) bool {
 	if et.MsgRct.ExitCode == ex {
 		return true
@@ -390,12 +1368,15 @@ func gasSearch(
 			break
 		}
 
+		if high == buildconstants.BlockGasLimit {
+			return -1, errGasExceedsAllowance
+		}
+
 		low = high
 		high = high * 2
 
 		if high > buildconstants.BlockGasLimit {
 			high = buildconstants.BlockGasLimit
-			break
 		}
 	}
 
@@ -419,6 +1400,26 @@ func gasSearch(
 	return high, nil
 }
 
+// blockGasRewards returns the effective priority fees the Ethereum transactions among the messages
+// of a tipset paid at the given base fee, with the gas they used, and the gas used by all the
+// messages. Other messages aren't priced by Ethereum wallets, so they're left out of the rewards.
+func blockGasRewards(msgs []types.ChainMsg, rcpts []types.MessageReceipt, baseFee abi.TokenAmount) (gasRewardSorter, int64) {
+	var gasUsed int64
+	txGasRewards := gasRewardSorter{}
+	for i, msg := range msgs {
+		gasUsed += rcpts[i].GasUsed
+		smsg, ok := msg.(*types.SignedMessage)
+		if !ok || smsg.Signature.Type != crypto.SigTypeDelegated {
+			continue
+		}
+		txGasRewards = append(txGasRewards, gasRewardTuple{
+			premium: msg.VMMessage().EffectiveGasPremium(baseFee),
+			gasUsed: rcpts[i].GasUsed,
+		})
+	}
+	return txGasRewards, gasUsed
+}
+
 func calculateRewardsAndGasUsed(rewardPercentiles []float64, txGasRewards gasRewardSorter) ([]ethtypes.EthBigInt, int64) {
 	var gasUsedTotal int64
 	for _, tx := range txGasRewards {
@@ -436,13 +1437,16 @@ func calculateRewardsAndGasUsed(rewardPercentiles []float64, txGasRewards gasRew
 
 	sort.Stable(txGasRewards)
 
-	var idx int
-	var sum int64
+	// As in Geth, the reward at a percentile is the premium of the first message at which the gas
+	// used by the messages so far reaches that percentile of the gas used by all of them, so 0
+	// picks the lowest premium and 100 the highest.
+	idx := 0
+	sum := txGasRewards[0].gasUsed
 	for i, percentile := range rewardPercentiles {
 		threshold := int64(float64(gasUsedTotal) * percentile / 100)
 		for sum < threshold && idx < len(txGasRewards)-1 {
-			sum += txGasRewards[idx].gasUsed
 			idx++
+			sum += txGasRewards[idx].gasUsed
 		}
 		rewards[i] = ethtypes.EthBigInt(txGasRewards[idx].premium)
 	}
@@ -480,6 +1484,18 @@ func (EthGasDisabled) EthMaxPriorityFeePerGas(ctx context.Context) (ethtypes.Eth
 func (EthGasDisabled) EthEstimateGas(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthUint64, error) {
 	return ethtypes.EthUint64(0), ErrModuleDisabled
 }
-func (EthGasDisabled) EthCall(ctx context.Context, tx ethtypes.EthCall, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBytes, error) {
+func (EthGasDisabled) EthCall(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthGasDisabled) EthCallDetailed(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthCallDetailedResult, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthGasDisabled) EthCallMany(ctx context.Context, p jsonrpc.RawParams) ([]ethtypes.EthCallManyResult, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthGasDisabled) EthCallAtStateRoot(ctx context.Context, p jsonrpc.RawParams) (ethtypes.EthBytes, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthGasDisabled) EthTraceCall(ctx context.Context, p jsonrpc.RawParams) (*ethtypes.EthTraceCallResult, error) {
 	return nil, ErrModuleDisabled
 }
@@ -0,0 +1,429 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/blockstore"
+	"github.com/filecoin-project/lotus/chain/state"
+	"github.com/filecoin-project/lotus/chain/stmgr"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/filecoin-project/lotus/chain/types/mock"
+	"github.com/filecoin-project/lotus/chain/vm"
+)
+
+// spanRecorder is a trace exporter keeping the last span exported under each name.
+type spanRecorder struct {
+	lk    sync.Mutex
+	spans map[string]*trace.SpanData
+}
+
+func (r *spanRecorder) ExportSpan(sd *trace.SpanData) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.spans[sd.Name] = sd
+}
+
+func (r *spanRecorder) span(t *testing.T, name string) *trace.SpanData {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	sd, ok := r.spans[name]
+	require.True(t, ok, "span %s not exported", name)
+	return sd
+}
+
+type callTestChainStore struct {
+	ChainStore
+	ts *types.TipSet
+}
+
+func (cs *callTestChainStore) GetTipSetFromKey(context.Context, types.TipSetKey) (*types.TipSet, error) {
+	return cs.ts, nil
+}
+
+// callTestStateManager applies the state override of calls to its state tree, and reports every
+// call as successful.
+type callTestStateManager struct {
+	StateManager
+	bs      blockstore.Blockstore
+	st      *state.StateTree
+	gasUsed int64
+}
+
+func (sm *callTestStateManager) TipSetState(context.Context, *types.TipSet) (cid.Cid, cid.Cid, error) {
+	return cid.Undef, cid.Undef, nil
+}
+
+func (sm *callTestStateManager) ApplyOnStateWithOptions(ctx context.Context, _ cid.Cid, msg *types.Message, _ *types.TipSet, opts *stmgr.CallOptions) (*api.InvocResult, error) {
+	if opts != nil && opts.StateOverride != nil {
+		if err := opts.StateOverride(ctx, sm.bs, sm.st); err != nil {
+			return nil, err
+		}
+	}
+	return &api.InvocResult{
+		Msg:    msg,
+		MsgRct: &types.MessageReceipt{ExitCode: exitcode.Ok, GasUsed: sm.gasUsed},
+	}, nil
+}
+
+func TestApplyMessageSpans(t *testing.T) {
+	ctx := context.Background()
+
+	rec := &spanRecorder{spans: make(map[string]*trace.SpanData)}
+	trace.RegisterExporter(rec)
+	defer trace.UnregisterExporter(rec)
+
+	bs := blockstore.NewMemorySync()
+	st, err := state.NewStateTree(cbor.NewCborStore(bs), types.StateTreeVersion5)
+	require.NoError(t, err)
+	sender, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	require.NoError(t, st.SetActor(sender, &types.Actor{Head: vm.EmptyObjectCid, Balance: big.Zero()}))
+
+	gas := &ethGas{
+		chainStore:   &callTestChainStore{ts: mock.TipSet(mock.MkBlock(nil, 1, 1))},
+		stateManager: &callTestStateManager{bs: bs, st: st, gasUsed: 1234},
+	}
+
+	// Sample the spans of the call through their parent.
+	ctx, span := trace.StartSpan(ctx, "test", trace.WithSampler(trace.AlwaysSample()))
+	msg := &types.Message{From: sender, To: sender}
+	_, err = gas.applyMessage(ctx, msg, types.EmptyTSK, &stmgr.CallOptions{StateOverride: createMissingSender(sender, nil)})
+	span.End()
+	require.NoError(t, err)
+
+	stateSpan := rec.span(t, "eth.callState")
+	require.Equal(t, int64(0), stateSpan.Attributes["height"])
+
+	applySpan := rec.span(t, "eth.applyMessage")
+	require.Equal(t, int64(1234), applySpan.Attributes["gas_used"])
+	require.Equal(t, int64(exitcode.Ok), applySpan.Attributes["exit_code"])
+
+	// The sender exists, so there was no need to create it.
+	senderSpan := rec.span(t, "eth.createMissingSender")
+	require.Equal(t, applySpan.SpanID, senderSpan.ParentSpanID)
+	require.Equal(t, sender.String(), senderSpan.Attributes["sender"])
+	require.Equal(t, false, senderSpan.Attributes["created"])
+}
+
+func TestCallStateOverrideStrictMode(t *testing.T) {
+	ctx := context.Background()
+
+	bs := blockstore.NewMemorySync()
+	st, err := state.NewStateTree(cbor.NewCborStore(bs), types.StateTreeVersion5)
+	require.NoError(t, err)
+	existing, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	require.NoError(t, st.SetActor(existing, &types.Actor{Head: vm.EmptyObjectCid, Balance: big.Zero()}))
+	missing, err := address.NewDelegatedAddress(10, make([]byte, 20))
+	require.NoError(t, err)
+
+	gas := &ethGas{strictCallMode: true}
+
+	require.NoError(t, gas.callStateOverride(nil, existing)(ctx, bs, st))
+
+	err = gas.callStateOverride(nil, existing, missing)(ctx, bs, st)
+	require.ErrorContains(t, err, fmt.Sprintf("sender %s does not exist", missing))
+
+	// The sender isn't created.
+	_, err = st.GetActor(missing)
+	require.ErrorIs(t, err, types.ErrActorNotFound)
+}
+
+func TestEmptyCodeOverride(t *testing.T) {
+	ctx := context.Background()
+
+	bs := blockstore.NewMemorySync()
+	st, err := state.NewStateTree(cbor.NewCborStore(bs), types.StateTreeVersion5)
+	require.NoError(t, err)
+
+	// Clearing the code of an account that doesn't exist doesn't create a contract.
+	var missing ethtypes.EthAddress
+	missing[0] = 0xaa
+	empty := ethtypes.EthBytes{}
+	overrides := ethtypes.EthStateOverrides{missing: {Code: &empty}}
+	require.NoError(t, stateOverrideFunc(overrides)(ctx, bs, st))
+	addr, err := missing.ToFilecoinAddress()
+	require.NoError(t, err)
+	_, err = st.GetActor(addr)
+	require.ErrorIs(t, err, types.ErrActorNotFound)
+
+	// Builtin actors other than accounts have no code to clear.
+	native, err := address.NewIDAddress(1000)
+	require.NoError(t, err)
+	require.NoError(t, st.SetActor(native, &types.Actor{Head: vm.EmptyObjectCid, Balance: big.Zero()}))
+	nativeEth, err := ethtypes.EthAddressFromFilecoinAddress(native)
+	require.NoError(t, err)
+	err = stateOverrideFunc(ethtypes.EthStateOverrides{nativeEth: {Code: &empty}})(ctx, bs, st)
+	require.ErrorContains(t, err, "cannot override the code of a non-EVM actor")
+}
+
+func TestAffordableGasLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var sender ethtypes.EthAddress
+	sender[19] = 1
+	balance := ethtypes.EthBigInt(big.NewInt(1_000_000))
+	overrides := ethtypes.EthStateOverrides{sender: {Balance: &balance}}
+
+	gas := &ethGas{}
+	limit := func(gasPrice, value int64) (int64, error) {
+		tx := ethtypes.EthCall{From: &sender, GasPrice: ethtypes.EthBigInt(big.NewInt(gasPrice))}
+		msg := &types.Message{Value: big.NewInt(value), GasLimit: 10_000}
+		return gas.affordableGasLimit(ctx, tx, msg, overrides, nil, nil)
+	}
+
+	// What the sender has left after the transfer pays for the gas.
+	res, err := limit(200, 400_000)
+	require.NoError(t, err)
+	require.Equal(t, int64(3_000), res)
+
+	// The gas limit isn't raised.
+	res, err = limit(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000), res)
+
+	// Calls without a gas price aren't capped.
+	res, err = limit(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000), res)
+
+	_, err = limit(1, 2_000_000)
+	require.ErrorContains(t, err, "insufficient funds")
+
+	// Calls setting a maximum fee per gas pay the base fee and their priority fee.
+	feeCap, premium := ethtypes.EthBigInt(big.NewInt(1_000)), ethtypes.EthBigInt(big.NewInt(50))
+	tx := ethtypes.EthCall{From: &sender, MaxFeePerGas: &feeCap, MaxPriorityFeePerGas: &premium}
+	msg := &types.Message{Value: big.Zero(), GasLimit: 10_000}
+	baseFee := ethtypes.EthBigInt(big.NewInt(450))
+	res, err = gas.affordableGasLimit(ctx, tx, msg, overrides, &ethtypes.EthBlockOverrides{BaseFeePerGas: &baseFee}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2_000), res)
+
+	// Up to their maximum fee per gas.
+	baseFee = ethtypes.EthBigInt(big.NewInt(1_950))
+	res, err = gas.affordableGasLimit(ctx, tx, msg, overrides, &ethtypes.EthBlockOverrides{BaseFeePerGas: &baseFee}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1_000), res)
+}
+
+func TestWithStorageOverrides(t *testing.T) {
+	var contract ethtypes.EthAddress
+	contract[19] = 1
+	slots := map[ethtypes.EthHash]ethtypes.EthHash{{31: 1}: {31: 2}}
+
+	// Overrides that don't touch storage don't need any setup.
+	nonce := ethtypes.EthUint64(1)
+	opts, err := withStorageOverrides(nil, ethtypes.EthStateOverrides{contract: {Nonce: &nonce}})
+	require.NoError(t, err)
+	require.Nil(t, opts)
+
+	_, err = withStorageOverrides(nil, ethtypes.EthStateOverrides{contract: {State: slots, StateDiff: slots}})
+	require.ErrorContains(t, err, "state and stateDiff can't both be overridden")
+
+	opts, err = withStorageOverrides(nil, ethtypes.EthStateOverrides{contract: {StateDiff: slots}})
+	require.NoError(t, err)
+	require.Len(t, opts.SetupMessages, 1)
+	require.NotNil(t, opts.StateOverride)
+	require.NotNil(t, opts.AfterSetup)
+
+	// The stub stores 2 at slot 1.
+	stub := storageStub(slots)
+	require.Len(t, stub, 68)
+	require.Equal(t, byte(0x7f), stub[0])
+	require.Equal(t, byte(2), stub[32])
+	require.Equal(t, byte(0x7f), stub[33])
+	require.Equal(t, byte(1), stub[65])
+	require.Equal(t, []byte{0x55, 0x00}, stub[66:])
+}
+
+func TestWithCallNonce(t *testing.T) {
+	var sender ethtypes.EthAddress
+	sender[19] = 1
+	balance := ethtypes.EthBigInt(big.NewInt(1_000))
+	overrides := ethtypes.EthStateOverrides{sender: {Balance: &balance}}
+
+	// Calls without a nonce keep their overrides.
+	res, err := withCallNonce(overrides, ethtypes.EthCall{From: &sender})
+	require.NoError(t, err)
+	require.Equal(t, overrides, res)
+
+	// The nonce is merged into the override of the sender, without changing the given overrides.
+	nonce := ethtypes.EthUint64(5)
+	res, err = withCallNonce(overrides, ethtypes.EthCall{From: &sender, Nonce: &nonce})
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.EthStateOverrides{sender: {Balance: &balance, Nonce: &nonce}}, res)
+	require.Nil(t, overrides[sender].Nonce)
+
+	// Calls without a sender are made from the zero address.
+	res, err = withCallNonce(nil, ethtypes.EthCall{Nonce: &nonce})
+	require.NoError(t, err)
+	require.Equal(t, ethtypes.EthStateOverrides{{}: {Nonce: &nonce}}, res)
+
+	other := ethtypes.EthUint64(6)
+	_, err = withCallNonce(ethtypes.EthStateOverrides{sender: {Nonce: &other}}, ethtypes.EthCall{From: &sender, Nonce: &nonce})
+	require.ErrorContains(t, err, "conflicts with the nonce override")
+}
+
+func TestWithCallOrigin(t *testing.T) {
+	var sender, origin, contract ethtypes.EthAddress
+	sender[19], origin[19], contract[19] = 1, 2, 3
+	balance := ethtypes.EthBigInt(big.NewInt(1_000))
+	overrides := ethtypes.EthStateOverrides{sender: {Balance: &balance}}
+	newMsg := func() *types.Message {
+		return &types.Message{From: mustFilecoinAddress(t, sender), To: mustFilecoinAddress(t, contract)}
+	}
+
+	// Calls without an origin, or from their origin, are left as they are.
+	for _, o := range []*ethtypes.EthAddress{nil, &sender} {
+		msg := newMsg()
+		res, err := withCallOrigin(overrides, ethtypes.EthCall{From: &sender, To: &contract, Origin: o}, msg)
+		require.NoError(t, err)
+		require.Equal(t, overrides, res)
+		require.Equal(t, newMsg(), msg)
+	}
+
+	// Other calls are sent by the origin through a stub at the sender forwarding them to the
+	// contract, without changing the given overrides.
+	msg := newMsg()
+	res, err := withCallOrigin(overrides, ethtypes.EthCall{From: &sender, To: &contract, Origin: &origin}, msg)
+	require.NoError(t, err)
+	stub := forwardStub(contract)
+	require.Equal(t, ethtypes.EthStateOverrides{sender: {Balance: &balance, Code: &stub}}, res)
+	require.Nil(t, overrides[sender].Code)
+	require.Equal(t, mustFilecoinAddress(t, origin), msg.From)
+	require.Equal(t, mustFilecoinAddress(t, sender), msg.To)
+
+	// The stub jumps to the JUMPDEST following the REVERT when the call succeeded.
+	require.Len(t, stub, 55)
+	require.Equal(t, byte(0x32), stub[44])
+	require.Equal(t, byte(0x5b), stub[0x32])
+
+	_, err = withCallOrigin(overrides, ethtypes.EthCall{From: &sender, Origin: &origin}, newMsg())
+	require.ErrorContains(t, err, "the origin of a contract creation can't be set")
+
+	code := ethtypes.EthBytes{0x00}
+	_, err = withCallOrigin(ethtypes.EthStateOverrides{sender: {Code: &code}}, ethtypes.EthCall{From: &sender, To: &contract, Origin: &origin}, newMsg())
+	require.ErrorContains(t, err, "code of its sender")
+}
+
+func mustFilecoinAddress(t *testing.T, ethAddr ethtypes.EthAddress) address.Address {
+	addr, err := ethAddr.ToFilecoinAddress()
+	require.NoError(t, err)
+	return addr
+}
+
+func TestEthEstimateGasCreateWithoutInitCode(t *testing.T) {
+	var sender ethtypes.EthAddress
+	sender[19] = 1
+	p, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: ethtypes.EthCall{From: &sender}})
+	require.NoError(t, err)
+
+	_, err = (&ethGas{}).EthEstimateGas(context.Background(), p)
+	require.ErrorContains(t, err, "contract creation requires init code")
+}
+
+func TestEthEstimateGasUnsupportedExtensions(t *testing.T) {
+	to := ethtypes.EthAddress{1}
+	nonce := ethtypes.EthUint64(1)
+	origin := ethtypes.EthAddress{2}
+	for name, tx := range map[string]ethtypes.EthCall{
+		"the nonce of a call":  {To: &to, Nonce: &nonce},
+		"the origin of a call": {To: &to, Origin: &origin},
+		"affordable calls":     {To: &to, Affordable: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, err := json.Marshal(ethtypes.EthEstimateGasParams{Tx: tx})
+			require.NoError(t, err)
+			_, err = (&ethGas{}).EthEstimateGas(context.Background(), p)
+
+			var invalid *api.ErrInvalidParams
+			require.ErrorAs(t, err, &invalid)
+			require.ErrorContains(t, err, name)
+		})
+	}
+}
+
+func TestEthCallInvalidGasPrice(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		gasPrice string
+		err      string
+	}{
+		{name: "negative", gasPrice: `"-0x1"`, err: "invalid gasPrice: must not be negative"},
+		{name: "non-hex", gasPrice: `"0xzz"`, err: `invalid gasPrice: invalid hex quantity "0xzz"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := []byte(`[{"to":"0x0000000000000000000000000000000000000001","gasPrice":` + tc.gasPrice + `},"latest"]`)
+
+			_, err := (&ethGas{}).EthCall(context.Background(), p)
+			var invalid *api.ErrInvalidParams
+			require.ErrorAs(t, err, &invalid)
+			require.ErrorContains(t, err, tc.err)
+
+			jerr, err := invalid.ToJSONRPCError()
+			require.NoError(t, err)
+			require.EqualValues(t, -32602, jerr.Code)
+		})
+	}
+}
+
+type callTestTipSetResolver struct {
+	TipSetResolver
+	ts       *types.TipSet
+	blkParam ethtypes.EthBlockNumberOrHash
+}
+
+func (r *callTestTipSetResolver) GetTipsetByBlockNumberOrHash(_ context.Context, blkParam ethtypes.EthBlockNumberOrHash) (*types.TipSet, error) {
+	r.blkParam = blkParam
+	return r.ts, nil
+}
+
+type networkVersionStateManager struct {
+	StateManager
+	nv network.Version
+}
+
+func (sm networkVersionStateManager) GetNetworkVersion(context.Context, abi.ChainEpoch) network.Version {
+	return sm.nv
+}
+
+func TestCallTipSet(t *testing.T) {
+	ctx := context.Background()
+	resolver := &callTestTipSetResolver{ts: mock.TipSet(mock.MkBlock(nil, 1, 1))}
+	gas := &ethGas{tipsetResolver: resolver, stateManager: networkVersionStateManager{nv: network.Version18}}
+
+	// Calls are simulated in the latest block by default.
+	_, err := gas.callTipSet(ctx, nil)
+	require.NoError(t, err)
+	require.NotNil(t, resolver.blkParam.PredefinedBlock)
+	require.Equal(t, ethtypes.BlockTagLatest, *resolver.blkParam.PredefinedBlock)
+
+	// "earliest" refers to the genesis.
+	earliest := ethtypes.NewEthBlockNumberOrHashFromPredefined(ethtypes.BlockTagEarliest)
+	_, err = gas.callTipSet(ctx, &earliest)
+	require.NoError(t, err)
+	require.NotNil(t, resolver.blkParam.BlockNumber)
+	require.Equal(t, ethtypes.EthUint64(0), *resolver.blkParam.BlockNumber)
+
+	// There is no EVM to run calls before network version 18.
+	gas.stateManager = networkVersionStateManager{nv: network.Version17}
+	_, err = gas.callTipSet(ctx, &earliest)
+	require.ErrorContains(t, err, "the EVM was introduced in network version 18")
+}
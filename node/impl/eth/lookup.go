@@ -5,17 +5,24 @@ import (
 	"context"
 	"errors"
 
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
 	"golang.org/x/xerrors"
 
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/big"
 	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/builtin/v10/evm"
 
 	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/blockstore"
 	"github.com/filecoin-project/lotus/build/buildconstants"
 	"github.com/filecoin-project/lotus/chain/actors"
 	builtinactors "github.com/filecoin-project/lotus/chain/actors/builtin"
+	builtinevm "github.com/filecoin-project/lotus/chain/actors/builtin/evm"
+	"github.com/filecoin-project/lotus/chain/state"
 	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
@@ -31,6 +38,7 @@ type ethLookup struct {
 	chainStore      ChainStore
 	stateManager    StateManager
 	syncApi         SyncAPI
+	messagePool     MessagePool
 	stateBlockstore dtypes.StateBlockstore
 
 	tipsetResolver TipSetResolver
@@ -40,6 +48,7 @@ func NewEthLookupAPI(
 	chainStore ChainStore,
 	stateManager StateManager,
 	syncApi SyncAPI,
+	messagePool MessagePool,
 	stateBlockstore dtypes.StateBlockstore,
 	tipsetResolver TipSetResolver,
 ) EthLookupAPI {
@@ -47,6 +56,7 @@ func NewEthLookupAPI(
 		chainStore:      chainStore,
 		stateManager:    stateManager,
 		syncApi:         syncApi,
+		messagePool:     messagePool,
 		stateBlockstore: stateBlockstore,
 		tipsetResolver:  tipsetResolver,
 	}
@@ -148,6 +158,12 @@ func (e *ethLookup) EthGetStorageAt(ctx context.Context, ethAddr ethtypes.EthAdd
 		return nil, err // don't wrap, to preserve ErrNullRound
 	}
 
+	return e.storageAt(ctx, ethAddr, position, ts)
+}
+
+// storageAt returns the value of the storage slot at position of the contract at ethAddr, in the
+// state computed by ts.
+func (e *ethLookup) storageAt(ctx context.Context, ethAddr ethtypes.EthAddress, position ethtypes.EthBytes, ts *types.TipSet) (ethtypes.EthBytes, error) {
 	pl := len(position)
 	if pl > 32 {
 		return nil, xerrors.New("supplied storage key is too long")
@@ -248,7 +264,12 @@ func (e *ethLookup) EthGetBalance(ctx context.Context, address ethtypes.EthAddre
 		return ethtypes.EthBigInt{}, xerrors.Errorf("failed to compute tipset state: %w", err)
 	}
 
-	actor, err := e.stateManager.LoadActorRaw(ctx, filAddr, st)
+	var actor *types.Actor
+	if blkParam.PredefinedBlock != nil && *blkParam.PredefinedBlock == ethtypes.BlockTagPending {
+		actor, err = e.pendingActor(ctx, filAddr, ts, st)
+	} else {
+		actor, err = e.stateManager.LoadActorRaw(ctx, filAddr, st)
+	}
 	if errors.Is(err, types.ErrActorNotFound) {
 		return ethtypes.EthBigIntZero, nil
 	} else if err != nil {
@@ -258,6 +279,123 @@ func (e *ethLookup) EthGetBalance(ctx context.Context, address ethtypes.EthAddre
 	return ethtypes.EthBigInt{Int: actor.Balance.Int}, nil
 }
 
+// pendingActor loads the actor at addr in the state of the pending block, built by applying the
+// messages of the message pool on top of st, the state resulting from the head, ts.
+func (e *ethLookup) pendingActor(ctx context.Context, addr address.Address, ts *types.TipSet, st cid.Cid) (*types.Actor, error) {
+	msgs := pendingStateMessages(ctx, e.messagePool, e.stateManager, ts, address.Undef)
+	tree, err := e.stateManager.StateAfterMessages(ctx, st, msgs, ts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to apply the pending messages: %w", err)
+	}
+	return tree.GetActor(addr)
+}
+
+// EthGetProof returns the account at address with the blocks proving it, and the values of the
+// given storage slots. See ethtypes.EthProof for the format of the proofs.
+func (e *ethLookup) EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	filAddr, err := address.ToFilecoinAddress()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get Filecoin address: %w", err)
+	}
+
+	ts, err := e.tipsetResolver.GetTipsetByBlockNumberOrHash(ctx, blkParam)
+	if err != nil {
+		return nil, err // don't wrap, to preserve ErrNullRound
+	}
+
+	stateCid, _, err := e.stateManager.TipSetState(ctx, ts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to compute tipset state: %w", err)
+	}
+
+	// Load the actor from a fresh state tree, so that all the blocks on the path to it are read.
+	rbs := &recordingBlockstore{Blockstore: e.stateBlockstore, seen: make(map[cid.Cid]struct{})}
+	st, err := state.LoadStateTree(cbor.NewCborStore(rbs), stateCid)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load state tree: %w", err)
+	}
+	actor, err := st.GetActor(filAddr)
+	if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+		return nil, xerrors.Errorf("failed to lookup actor %s: %w", address, err)
+	}
+
+	proof := &ethtypes.EthProof{
+		Address:      address,
+		Balance:      ethtypes.EthBigIntZero,
+		AccountProof: rbs.blocks,
+		StorageProof: make([]ethtypes.EthStorageProof, 0, len(storageKeys)),
+	}
+	if actor != nil {
+		proof.Balance = ethtypes.EthBigInt(actor.Balance)
+		proof.Nonce = ethtypes.EthUint64(actor.Nonce)
+		proof.CodeHash = ethtypes.EthHashFromTxBytes(nil)
+		if builtinactors.IsEvmActor(actor.Code) {
+			if proof.Nonce, err = contractNonce(ctx, e.chainStore, actor); err != nil {
+				return nil, err
+			}
+			evmState, err := builtinevm.Load(e.chainStore.ActorStore(ctx), actor)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to load evm state: %w", err)
+			}
+			codeHash, err := evmState.GetBytecodeHash()
+			if err != nil {
+				return nil, xerrors.Errorf("failed to get bytecode hash: %w", err)
+			}
+			proof.CodeHash = codeHash
+			if proof.StorageHash, err = ethtypes.EthHashFromCid(actor.Head); err != nil {
+				return nil, xerrors.Errorf("failed to hash evm state: %w", err)
+			}
+		}
+	}
+
+	for _, key := range storageKeys {
+		value, err := e.storageAt(ctx, address, key[:], ts)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get storage slot %s: %w", key, err)
+		}
+		proof.StorageProof = append(proof.StorageProof, ethtypes.EthStorageProof{
+			Key:   key,
+			Value: ethtypes.EthBigInt(big.PositiveFromUnsignedBytes(value)),
+			Proof: []ethtypes.EthBytes{},
+		})
+	}
+
+	return proof, nil
+}
+
+// recordingBlockstore records the data of the blocks read through it, in the order they are first
+// read.
+type recordingBlockstore struct {
+	blockstore.Blockstore
+
+	seen   map[cid.Cid]struct{}
+	blocks []ethtypes.EthBytes
+}
+
+func (bs *recordingBlockstore) record(c cid.Cid, data []byte) {
+	if _, ok := bs.seen[c]; ok {
+		return
+	}
+	bs.seen[c] = struct{}{}
+	bs.blocks = append(bs.blocks, bytes.Clone(data))
+}
+
+func (bs *recordingBlockstore) Get(ctx context.Context, c cid.Cid) (blocks.Block, error) {
+	blk, err := bs.Blockstore.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	bs.record(c, blk.RawData())
+	return blk, nil
+}
+
+func (bs *recordingBlockstore) View(ctx context.Context, c cid.Cid, callback func([]byte) error) error {
+	return bs.Blockstore.View(ctx, c, func(data []byte) error {
+		bs.record(c, data)
+		return callback(data)
+	})
+}
+
 func (e *ethLookup) EthChainId(ctx context.Context) (ethtypes.EthUint64, error) {
 	return ethtypes.EthUint64(buildconstants.Eip155ChainId), nil
 }
@@ -309,6 +447,9 @@ func (EthLookupDisabled) EthGetStorageAt(ctx context.Context, ethAddr ethtypes.E
 func (EthLookupDisabled) EthGetBalance(ctx context.Context, address ethtypes.EthAddress, blkParam ethtypes.EthBlockNumberOrHash) (ethtypes.EthBigInt, error) {
 	return ethtypes.EthBigInt{}, ErrModuleDisabled
 }
+func (EthLookupDisabled) EthGetProof(ctx context.Context, address ethtypes.EthAddress, storageKeys []ethtypes.EthHash, blkParam ethtypes.EthBlockNumberOrHash) (*ethtypes.EthProof, error) {
+	return nil, ErrModuleDisabled
+}
 func (EthLookupDisabled) EthChainId(ctx context.Context) (ethtypes.EthUint64, error) {
 	return ethtypes.EthUint64(0), ErrModuleDisabled
 }
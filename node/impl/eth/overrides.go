@@ -0,0 +1,626 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"go.opencensus.io/trace"
+	"golang.org/x/crypto/sha3"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-address"
+	actorstypes "github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/manifest"
+
+	"github.com/filecoin-project/lotus/blockstore"
+	"github.com/filecoin-project/lotus/build/buildconstants"
+	"github.com/filecoin-project/lotus/chain/actors"
+	"github.com/filecoin-project/lotus/chain/actors/adt"
+	builtinactors "github.com/filecoin-project/lotus/chain/actors/builtin"
+	"github.com/filecoin-project/lotus/chain/actors/builtin/evm"
+	"github.com/filecoin-project/lotus/chain/state"
+	"github.com/filecoin-project/lotus/chain/stmgr"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/filecoin-project/lotus/chain/vm"
+)
+
+// maxStubReturnDataSize is the largest return data a stub can be generated for, limited by the
+// two byte operands used to copy it.
+const maxStubReturnDataSize = 0xffff
+
+// stateOverrideFunc returns a function applying the given Ethereum state overrides to a state tree,
+// for use with stmgr.CallOptions.
+func stateOverrideFunc(overrides ethtypes.EthStateOverrides) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+	return func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		for ethAddr, override := range overrides {
+			if err := applyAccountOverride(ctx, bs, st, ethAddr, override); err != nil {
+				return xerrors.Errorf("overriding %s: %w", ethAddr, err)
+			}
+		}
+		return nil
+	}
+}
+
+// withCallNonce returns the overrides with the nonce of the sender of tx set to the nonce of the
+// call, if it has one. The given overrides are left untouched.
+func withCallNonce(overrides ethtypes.EthStateOverrides, tx ethtypes.EthCall) (ethtypes.EthStateOverrides, error) {
+	if tx.Nonce == nil {
+		return overrides, nil
+	}
+	sender := senderEthAddress(tx)
+	override := overrides[sender]
+	if override.Nonce != nil && *override.Nonce != *tx.Nonce {
+		return nil, xerrors.Errorf("nonce of the call conflicts with the nonce override of its sender %s", sender)
+	}
+	override.Nonce = tx.Nonce
+
+	res := make(ethtypes.EthStateOverrides, len(overrides)+1)
+	for ethAddr, o := range overrides {
+		res[ethAddr] = o
+	}
+	res[sender] = override
+	return res, nil
+}
+
+// withCallOrigin returns the overrides routing tx through its sender when it sets an origin other
+// than its sender: the code of the sender is overridden with a stub forwarding the call to its
+// recipient, and msg is sent by the origin to the sender instead. The sender thus looks like a
+// contract to the recipient, which EthCall.Origin documents. The given overrides are left
+// untouched.
+func withCallOrigin(overrides ethtypes.EthStateOverrides, tx ethtypes.EthCall, msg *types.Message) (ethtypes.EthStateOverrides, error) {
+	sender := senderEthAddress(tx)
+	if tx.Origin == nil || *tx.Origin == sender {
+		return overrides, nil
+	}
+	if tx.To == nil {
+		return nil, xerrors.New("the origin of a contract creation can't be set")
+	}
+	if overridesCode(overrides, sender) {
+		return nil, xerrors.Errorf("the origin of a call can't be set when the code of its sender %s is overridden", sender)
+	}
+
+	origin, err := tx.Origin.ToFilecoinAddress()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get Filecoin address of origin: %w", err)
+	}
+	senderAddr, err := sender.ToFilecoinAddress()
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get Filecoin address of sender: %w", err)
+	}
+
+	override := overrides[sender]
+	stub := forwardStub(*tx.To)
+	override.Code = &stub
+
+	res := make(ethtypes.EthStateOverrides, len(overrides)+1)
+	for ethAddr, o := range overrides {
+		res[ethAddr] = o
+	}
+	res[sender] = override
+
+	msg.From = origin
+	msg.To = senderAddr
+	return res, nil
+}
+
+// overridesCode returns true if the overrides replace the code of the given address.
+func overridesCode(overrides ethtypes.EthStateOverrides, ethAddr ethtypes.EthAddress) bool {
+	override, ok := overrides[ethAddr]
+	return ok && (override.Code != nil || override.ReturnData != nil)
+}
+
+func applyAccountOverride(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree, ethAddr ethtypes.EthAddress, override ethtypes.EthAccountOverride) error {
+	addr, err := ethAddr.ToFilecoinAddress()
+	if err != nil {
+		return xerrors.Errorf("cannot get Filecoin address: %w", err)
+	}
+
+	code := override.Code
+	if override.ReturnData != nil {
+		if code != nil {
+			return xerrors.New("code and returnData can't both be overridden")
+		}
+		stub, err := returnDataStub(*override.ReturnData)
+		if err != nil {
+			return err
+		}
+		code = (*ethtypes.EthBytes)(&stub)
+	}
+	if code != nil {
+		if isPrecompile(ethAddr) {
+			return xerrors.New("cannot override the code of a precompile")
+		}
+		if err := overrideCode(ctx, bs, st, addr, *code); err != nil {
+			return xerrors.Errorf("overriding code: %w", err)
+		}
+	}
+
+	if override.Nonce == nil && override.Balance == nil {
+		return nil
+	}
+
+	actor, err := st.GetActor(addr)
+	if err != nil {
+		return xerrors.Errorf("loading actor: %w", err)
+	}
+
+	if override.Nonce != nil {
+		actor.Nonce = uint64(*override.Nonce)
+	}
+	if override.Balance != nil {
+		actor.Balance = big.Int(*override.Balance)
+	}
+
+	return st.SetActor(addr, actor)
+}
+
+// overrideCode sets the bytecode of the EVM actor at addr. Placeholder and Ethereum accounts are
+// turned into EVM actors, and an EVM actor is created if addr doesn't exist yet. The contract's
+// storage is left untouched. Empty code clears the code of a contract, which then accepts every
+// call without running anything, like an account; other accounts are left as they are.
+func overrideCode(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree, addr address.Address, code []byte) error {
+	actor, err := st.GetActor(addr)
+	if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+		return xerrors.Errorf("loading actor: %w", err)
+	}
+	if len(code) == 0 && (actor == nil || builtinactors.IsPlaceholderActor(actor.Code) || builtinactors.IsEthAccountActor(actor.Code)) {
+		return nil
+	}
+
+	// EVM bytecode is stored as a raw block, so it can't be written through the state tree's store.
+	codeCid, err := cid.V1Builder{Codec: cid.Raw, MhType: multihash.BLAKE2B_MIN + 31}.Sum(code)
+	if err != nil {
+		return xerrors.Errorf("computing bytecode cid: %w", err)
+	}
+	blk, err := blocks.NewBlockWithCid(code, codeCid)
+	if err != nil {
+		return err
+	}
+	if err := bs.Put(ctx, blk); err != nil {
+		return xerrors.Errorf("storing bytecode: %w", err)
+	}
+
+	var codeHash [32]byte
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(code)
+	copy(codeHash[:], hasher.Sum(nil))
+
+	store := adt.WrapStore(ctx, st.Store)
+
+	var evmState evm.State
+	if actor != nil && builtinactors.IsEvmActor(actor.Code) {
+		evmState, err = evm.Load(store, actor)
+		if err != nil {
+			return xerrors.Errorf("loading evm state: %w", err)
+		}
+	} else {
+		if actor != nil && !builtinactors.IsPlaceholderActor(actor.Code) && !builtinactors.IsEthAccountActor(actor.Code) {
+			return xerrors.Errorf("cannot override the code of a non-EVM actor")
+		}
+
+		evmCode, av, err := builtinActorCode(st, manifest.EvmKey)
+		if err != nil {
+			return err
+		}
+		evmState, err = evm.MakeState(store, av, codeCid)
+		if err != nil {
+			return xerrors.Errorf("creating evm state: %w", err)
+		}
+
+		if actor == nil {
+			if addr.Protocol() != address.Delegated {
+				return xerrors.Errorf("actor %s not found", addr)
+			}
+			delegated := addr
+			addr, err = st.RegisterNewAddress(delegated)
+			if err != nil {
+				return xerrors.Errorf("registering address: %w", err)
+			}
+			actor = &types.Actor{
+				Balance:          big.Zero(),
+				DelegatedAddress: &delegated,
+			}
+		}
+		actor.Code = evmCode
+	}
+
+	if err := evmState.SetBytecode(codeCid, codeHash); err != nil {
+		return xerrors.Errorf("setting bytecode: %w", err)
+	}
+	actor.Head, err = store.Put(ctx, evmState)
+	if err != nil {
+		return xerrors.Errorf("storing evm state: %w", err)
+	}
+
+	return st.SetActor(addr, actor)
+}
+
+// withStorageOverrides adds the storage overrides among overrides, if any, to opts, which may be
+// nil. The storage of a contract can only be written by the EVM, so the contract's code is swapped
+// for a stub writing the overridden slots, which is run by a setup message before the call, and
+// restored once it ran.
+func withStorageOverrides(opts *stmgr.CallOptions, overrides ethtypes.EthStateOverrides) (*stmgr.CallOptions, error) {
+	var contracts []ethtypes.EthAddress
+	for ethAddr, override := range overrides {
+		if override.State != nil && override.StateDiff != nil {
+			return nil, xerrors.Errorf("overriding %s: state and stateDiff can't both be overridden", ethAddr)
+		}
+		if override.State == nil && override.StateDiff == nil {
+			continue
+		}
+		if isPrecompile(ethAddr) {
+			return nil, xerrors.Errorf("overriding %s: cannot override the storage of a precompile", ethAddr)
+		}
+		contracts = append(contracts, ethAddr)
+	}
+	if len(contracts) == 0 {
+		return opts, nil
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		return bytes.Compare(contracts[i][:], contracts[j][:]) < 0
+	})
+
+	if opts == nil {
+		opts = &stmgr.CallOptions{}
+	}
+	addrs := make([]address.Address, len(contracts))
+	for i, ethAddr := range contracts {
+		addr, err := ethAddr.ToFilecoinAddress()
+		if err != nil {
+			return nil, xerrors.Errorf("overriding %s: cannot get Filecoin address: %w", ethAddr, err)
+		}
+		addrs[i] = addr
+		opts.SetupMessages = append(opts.SetupMessages, &types.Message{
+			From:       builtinactors.SystemActorAddr,
+			To:         addr,
+			Value:      big.Zero(),
+			Method:     builtintypes.MethodsEVM.InvokeContract,
+			GasLimit:   buildconstants.BlockGasLimit,
+			GasFeeCap:  big.Zero(),
+			GasPremium: big.Zero(),
+		})
+	}
+
+	// The code of the contracts, keyed by their index, before it was swapped for the stubs.
+	codes := make([]evmBytecode, len(contracts))
+	prev := opts.StateOverride
+	opts.StateOverride = func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		if prev != nil {
+			if err := prev(ctx, bs, st); err != nil {
+				return err
+			}
+		}
+		for i, ethAddr := range contracts {
+			var err error
+			codes[i], err = installStorageStub(ctx, bs, st, addrs[i], overrides[ethAddr])
+			if err != nil {
+				return xerrors.Errorf("overriding storage of %s: %w", ethAddr, err)
+			}
+		}
+		return nil
+	}
+	opts.AfterSetup = func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		for i, ethAddr := range contracts {
+			if err := setBytecode(ctx, st, addrs[i], codes[i]); err != nil {
+				return xerrors.Errorf("restoring code of %s: %w", ethAddr, err)
+			}
+		}
+		return nil
+	}
+	return opts, nil
+}
+
+// evmBytecode identifies the bytecode of an EVM actor.
+type evmBytecode struct {
+	cid  cid.Cid
+	hash [32]byte
+}
+
+// installStorageStub replaces the code of the contract at addr with a stub writing the storage
+// override to its storage, which is cleared first if the override replaces it entirely. It returns
+// the replaced code.
+func installStorageStub(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree, addr address.Address, override ethtypes.EthAccountOverride) (evmBytecode, error) {
+	actor, err := st.GetActor(addr)
+	if err != nil {
+		return evmBytecode{}, xerrors.Errorf("loading actor: %w", err)
+	}
+	if !builtinactors.IsEvmActor(actor.Code) {
+		return evmBytecode{}, xerrors.New("cannot override the storage of a non-contract account")
+	}
+
+	store := adt.WrapStore(ctx, st.Store)
+	evmState, err := evm.Load(store, actor)
+	if err != nil {
+		return evmBytecode{}, xerrors.Errorf("loading evm state: %w", err)
+	}
+	var code evmBytecode
+	if code.cid, err = evmState.GetBytecodeCID(); err != nil {
+		return evmBytecode{}, err
+	}
+	if code.hash, err = evmState.GetBytecodeHash(); err != nil {
+		return evmBytecode{}, err
+	}
+
+	slots := override.StateDiff
+	if override.State != nil {
+		slots = override.State
+		if err := evmState.ClearStorage(); err != nil {
+			return evmBytecode{}, xerrors.Errorf("clearing storage: %w", err)
+		}
+		if actor.Head, err = store.Put(ctx, evmState); err != nil {
+			return evmBytecode{}, xerrors.Errorf("storing evm state: %w", err)
+		}
+		if err := st.SetActor(addr, actor); err != nil {
+			return evmBytecode{}, err
+		}
+	}
+
+	if err := overrideCode(ctx, bs, st, addr, storageStub(slots)); err != nil {
+		return evmBytecode{}, xerrors.Errorf("installing stub: %w", err)
+	}
+	return code, nil
+}
+
+// setBytecode sets the bytecode of the EVM actor at addr, which must already be stored.
+func setBytecode(ctx context.Context, st *state.StateTree, addr address.Address, code evmBytecode) error {
+	actor, err := st.GetActor(addr)
+	if err != nil {
+		return xerrors.Errorf("loading actor: %w", err)
+	}
+	store := adt.WrapStore(ctx, st.Store)
+	evmState, err := evm.Load(store, actor)
+	if err != nil {
+		return xerrors.Errorf("loading evm state: %w", err)
+	}
+	if err := evmState.SetBytecode(code.cid, code.hash); err != nil {
+		return xerrors.Errorf("setting bytecode: %w", err)
+	}
+	if actor.Head, err = store.Put(ctx, evmState); err != nil {
+		return xerrors.Errorf("storing evm state: %w", err)
+	}
+	return st.SetActor(addr, actor)
+}
+
+// storageStub returns EVM bytecode writing the given values to the given storage slots.
+func storageStub(slots map[ethtypes.EthHash]ethtypes.EthHash) []byte {
+	keys := make([]ethtypes.EthHash, 0, len(slots))
+	for key := range slots {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		value := slots[key]
+		buf.WriteByte(0x7f) // PUSH32 value
+		buf.Write(value[:])
+		buf.WriteByte(0x7f) // PUSH32 key
+		buf.Write(key[:])
+		buf.WriteByte(0x55) // SSTORE
+	}
+	buf.WriteByte(0x00) // STOP
+	return buf.Bytes()
+}
+
+// createMissingSender returns a state override creating a placeholder actor without any funds at
+// the sender's delegated address if there is no actor there, so that calls can be simulated from
+// accounts that were never used. The next override, if any, is applied afterwards, so it can change
+// the created sender, e.g. give it a balance to transfer value from.
+func createMissingSender(sender address.Address, next func(context.Context, blockstore.Blockstore, *state.StateTree) error) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+	return func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		ctx, span := trace.StartSpan(ctx, "eth.createMissingSender")
+		defer span.End()
+
+		_, err := st.GetActor(sender)
+		missing := errors.Is(err, types.ErrActorNotFound)
+		if missing {
+			err = createPlaceholder(st, sender)
+		}
+		if err != nil {
+			return xerrors.Errorf("creating sender: %w", err)
+		}
+		span.AddAttributes(
+			trace.StringAttribute("sender", sender.String()),
+			trace.BoolAttribute("created", missing),
+		)
+
+		if next != nil {
+			return next(ctx, bs, st)
+		}
+		return nil
+	}
+}
+
+// requireSender returns a state override failing if there is no actor at the sender's address once
+// the previous override, if any, was applied. It is used instead of createMissingSender in strict
+// mode.
+func requireSender(sender address.Address, prev func(context.Context, blockstore.Blockstore, *state.StateTree) error) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+	return func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		if prev != nil {
+			if err := prev(ctx, bs, st); err != nil {
+				return err
+			}
+		}
+		_, err := st.GetActor(sender)
+		if errors.Is(err, types.ErrActorNotFound) {
+			return xerrors.Errorf("sender %s does not exist", sender)
+		}
+		if err != nil {
+			return xerrors.Errorf("loading sender: %w", err)
+		}
+		return nil
+	}
+}
+
+// checkSenderExists returns a state override recording whether there is an actor at the sender's
+// address before applying the next override, if any, which may create it.
+func checkSenderExists(sender address.Address, exists *bool, next func(context.Context, blockstore.Blockstore, *state.StateTree) error) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+	return func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		_, err := st.GetActor(sender)
+		if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+			return xerrors.Errorf("loading sender: %w", err)
+		}
+		*exists = err == nil
+
+		if next != nil {
+			return next(ctx, bs, st)
+		}
+		return nil
+	}
+}
+
+// recordSenderBalance returns a state override recording the balance of the sender after applying
+// the next override, if any, which may set it. A sender without an actor has no balance.
+func recordSenderBalance(sender address.Address, balance *big.Int, next func(context.Context, blockstore.Blockstore, *state.StateTree) error) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+	return func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+		if next != nil {
+			if err := next(ctx, bs, st); err != nil {
+				return err
+			}
+		}
+
+		act, err := st.GetActor(sender)
+		switch {
+		case errors.Is(err, types.ErrActorNotFound):
+			*balance = big.Zero()
+		case err != nil:
+			return xerrors.Errorf("loading sender: %w", err)
+		default:
+			*balance = act.Balance
+		}
+		return nil
+	}
+}
+
+// recordStateRoot makes opts, which may be nil, record the root of the state its message is applied
+// on, once the state overrides are applied and the setup messages, if any, are run, and the
+// blockstore holding it.
+func recordStateRoot(opts *stmgr.CallOptions, root *cid.Cid, store *blockstore.Blockstore) *stmgr.CallOptions {
+	if opts == nil {
+		opts = &stmgr.CallOptions{}
+	}
+	record := func(next func(context.Context, blockstore.Blockstore, *state.StateTree) error) func(context.Context, blockstore.Blockstore, *state.StateTree) error {
+		return func(ctx context.Context, bs blockstore.Blockstore, st *state.StateTree) error {
+			if next != nil {
+				if err := next(ctx, bs, st); err != nil {
+					return err
+				}
+			}
+			var err error
+			*root, err = st.Flush(ctx)
+			if err != nil {
+				return xerrors.Errorf("flushing the state the call is applied on: %w", err)
+			}
+			*store = bs
+			return nil
+		}
+	}
+	if len(opts.SetupMessages) > 0 {
+		opts.AfterSetup = record(opts.AfterSetup)
+	} else {
+		opts.StateOverride = record(opts.StateOverride)
+	}
+	return opts
+}
+
+func createPlaceholder(st *state.StateTree, addr address.Address) error {
+	placeholderCode, _, err := builtinActorCode(st, manifest.PlaceholderKey)
+	if err != nil {
+		return err
+	}
+	id, err := st.RegisterNewAddress(addr)
+	if err != nil {
+		return xerrors.Errorf("registering address: %w", err)
+	}
+	return st.SetActor(id, &types.Actor{
+		Code:             placeholderCode,
+		Head:             vm.EmptyObjectCid,
+		Balance:          big.Zero(),
+		DelegatedAddress: &addr,
+	})
+}
+
+// builtinActorCode returns the code of the given builtin actor, for the actors version the state
+// tree is at.
+func builtinActorCode(st *state.StateTree, key string) (cid.Cid, actorstypes.Version, error) {
+	initActor, err := st.GetActor(builtinactors.InitActorAddr)
+	if err != nil {
+		return cid.Undef, 0, xerrors.Errorf("loading init actor: %w", err)
+	}
+	_, av, ok := actors.GetActorMetaByCode(initActor.Code)
+	if !ok {
+		return cid.Undef, 0, xerrors.Errorf("unknown init actor code %s", initActor.Code)
+	}
+	code, ok := actors.GetActorCodeID(av, key)
+	if !ok {
+		return cid.Undef, 0, xerrors.Errorf("no %s actor for actors version %d", key, av)
+	}
+	return code, av, nil
+}
+
+// returnDataStub returns EVM bytecode that returns data to every call.
+func returnDataStub(data []byte) ([]byte, error) {
+	if len(data) > maxStubReturnDataSize {
+		return nil, xerrors.Errorf("return data too large: %d bytes, max %d", len(data), maxStubReturnDataSize)
+	}
+
+	var size [2]byte
+	binary.BigEndian.PutUint16(size[:], uint16(len(data)))
+
+	var buf bytes.Buffer
+	buf.Write([]byte{0x61, size[0], size[1]}) // PUSH2 len
+	buf.Write([]byte{0x61, 0x00, 0x0f})       // PUSH2 offset of the data, i.e. the length of this code
+	buf.Write([]byte{0x60, 0x00})             // PUSH1 0
+	buf.WriteByte(0x39)                       // CODECOPY
+	buf.Write([]byte{0x61, size[0], size[1]}) // PUSH2 len
+	buf.Write([]byte{0x60, 0x00})             // PUSH1 0
+	buf.WriteByte(0xf3)                       // RETURN
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+// forwardStub returns EVM bytecode forwarding every call, along with its value, to the contract at
+// to, and returning or reverting with what it returned.
+func forwardStub(to ethtypes.EthAddress) ethtypes.EthBytes {
+	var buf bytes.Buffer
+	buf.WriteByte(0x36)                             // CALLDATASIZE
+	buf.Write([]byte{0x60, 0x00, 0x60, 0x00})       // PUSH1 0 (offset), PUSH1 0 (destOffset)
+	buf.WriteByte(0x37)                             // CALLDATACOPY
+	buf.Write([]byte{0x60, 0x00, 0x60, 0x00})       // PUSH1 0 (retSize), PUSH1 0 (retOffset)
+	buf.WriteByte(0x36)                             // CALLDATASIZE (argsSize)
+	buf.Write([]byte{0x60, 0x00})                   // PUSH1 0 (argsOffset)
+	buf.WriteByte(0x34)                             // CALLVALUE
+	buf.Write(append([]byte{0x73}, to[:]...))       // PUSH20 to
+	buf.WriteByte(0x5a)                             // GAS
+	buf.WriteByte(0xf1)                             // CALL
+	buf.WriteByte(0x3d)                             // RETURNDATASIZE
+	buf.Write([]byte{0x60, 0x00, 0x60, 0x00})       // PUSH1 0 (offset), PUSH1 0 (destOffset)
+	buf.WriteByte(0x3e)                             // RETURNDATACOPY
+	buf.Write([]byte{0x60, 0x32})                   // PUSH1 offset of the JUMPDEST below
+	buf.WriteByte(0x57)                             // JUMPI
+	buf.Write([]byte{0x3d, 0x60, 0x00, 0xfd})       // RETURNDATASIZE, PUSH1 0, REVERT
+	buf.Write([]byte{0x5b, 0x3d, 0x60, 0x00, 0xf3}) // JUMPDEST, RETURNDATASIZE, PUSH1 0, RETURN
+	return buf.Bytes()
+}
+
+// isPrecompile returns true for the addresses of Ethereum and Filecoin precompiles, which are
+// implemented by the EVM actor rather than by code in the state tree.
+func isPrecompile(ethAddr ethtypes.EthAddress) bool {
+	for _, b := range ethAddr[1:19] {
+		if b != 0 {
+			return false
+		}
+	}
+	return (ethAddr[0] == 0x00 || ethAddr[0] == 0xfe) && ethAddr[19] != 0
+}
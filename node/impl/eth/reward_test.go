@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
 
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
@@ -52,7 +53,23 @@ func TestRewardPercentiles(t *testing.T) {
 				{gasUsed: int64(500), premium: big.NewInt(600)},
 				{gasUsed: int64(300), premium: big.NewInt(700)},
 			},
-			answer: []int64{200, 700, 700, 700},
+			answer: []int64{100, 600, 600, 700},
+		},
+		{
+			percentiles: []float64{0, 50, 100},
+			txGasRewards: []gasRewardTuple{
+				{gasUsed: int64(100), premium: big.NewInt(300)},
+				{gasUsed: int64(100), premium: big.NewInt(100)},
+				{gasUsed: int64(100), premium: big.NewInt(200)},
+			},
+			answer: []int64{100, 200, 300},
+		},
+		{
+			percentiles: []float64{0, 100},
+			txGasRewards: []gasRewardTuple{
+				{gasUsed: int64(100), premium: big.NewInt(400)},
+			},
+			answer: []int64{400, 400},
 		},
 	}
 	for _, tc := range testcases {
@@ -70,3 +87,26 @@ func TestRewardPercentiles(t *testing.T) {
 		require.Equal(t, ans, rewards)
 	}
 }
+
+func TestBlockGasRewards(t *testing.T) {
+	baseFee := big.NewInt(100)
+	msg := func(premium int64) types.Message {
+		return types.Message{GasFeeCap: big.NewInt(1000), GasPremium: big.NewInt(premium)}
+	}
+	msgs := []types.ChainMsg{
+		&types.SignedMessage{Message: msg(200), Signature: crypto.Signature{Type: crypto.SigTypeDelegated}},
+		&types.SignedMessage{Message: msg(300), Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1}},
+		func() *types.Message { m := msg(400); return &m }(),
+		&types.SignedMessage{Message: msg(2000), Signature: crypto.Signature{Type: crypto.SigTypeDelegated}},
+	}
+	rcpts := []types.MessageReceipt{{GasUsed: 10}, {GasUsed: 20}, {GasUsed: 30}, {GasUsed: 40}}
+
+	// Only the Ethereum transactions are priced, but the gas used by all messages is counted.
+	rewards, gasUsed := blockGasRewards(msgs, rcpts, baseFee)
+	require.Equal(t, int64(100), gasUsed)
+	require.Len(t, rewards, 2)
+	require.Equal(t, int64(10), rewards[0].gasUsed)
+	require.Equal(t, int64(200), rewards[0].premium.Int64())
+	require.Equal(t, int64(40), rewards[1].gasUsed)
+	require.Equal(t, int64(900), rewards[1].premium.Int64())
+}
@@ -2,8 +2,15 @@ package eth
 
 import (
 	"context"
+	"errors"
 
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/index"
+	"github.com/filecoin-project/lotus/chain/messagepool"
+	"github.com/filecoin-project/lotus/chain/stmgr"
+	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 )
 
@@ -14,13 +21,29 @@ var (
 
 type ethSend struct {
 	mpoolApi     MpoolAPI
+	messagePool  MessagePool
 	chainIndexer index.Indexer
+	chainStore   ChainStore
+	stateManager StateManager
+
+	simulate bool // see FevmConfig.EthSendRawTransactionSimulate
 }
 
-func NewEthSendAPI(mpoolApi MpoolAPI, chainIndexer index.Indexer) EthSendAPI {
+func NewEthSendAPI(
+	mpoolApi MpoolAPI,
+	messagePool MessagePool,
+	chainIndexer index.Indexer,
+	chainStore ChainStore,
+	stateManager StateManager,
+	simulate bool,
+) EthSendAPI {
 	return &ethSend{
 		mpoolApi:     mpoolApi,
+		messagePool:  messagePool,
 		chainIndexer: chainIndexer,
+		chainStore:   chainStore,
+		stateManager: stateManager,
+		simulate:     simulate,
 	}
 }
 
@@ -48,14 +71,21 @@ func (e *ethSend) ethSendRawTransaction(ctx context.Context, rawTx ethtypes.EthB
 		return ethtypes.EmptyEthHash, err
 	}
 
-	if untrusted {
-		if _, err = e.mpoolApi.MpoolPushUntrusted(ctx, smsg); err != nil {
+	if e.simulate {
+		if err := e.simulateTransaction(ctx, &smsg.Message); err != nil {
 			return ethtypes.EmptyEthHash, err
 		}
+	}
+
+	if untrusted {
+		_, err = e.mpoolApi.MpoolPushUntrusted(ctx, smsg)
 	} else {
-		if _, err = e.mpoolApi.MpoolPush(ctx, smsg); err != nil {
-			return ethtypes.EmptyEthHash, err
-		}
+		_, err = e.mpoolApi.MpoolPush(ctx, smsg)
+	}
+	// The mpool only reports an existing nonce for the very message it already holds, i.e. when the
+	// transaction is submitted again. Like Ethereum clients, accept it and return its hash.
+	if err != nil && !errors.Is(err, messagepool.ErrExistingNonce) {
+		return ethtypes.EmptyEthHash, mpoolPushError(err)
 	}
 
 	// make it immediately available in the transaction hash lookup db, even though it will also
@@ -69,6 +99,64 @@ func (e *ethSend) ethSendRawTransaction(ctx context.Context, rawTx ethtypes.EthB
 	return ethtypes.EthHashFromTxBytes(rawTx), nil
 }
 
+// mpoolPushError prefixes the mpool's nonce validation errors with the messages Ethereum clients
+// return for them, so that wallets can recognise them and react.
+func mpoolPushError(err error) error {
+	switch {
+	case errors.Is(err, messagepool.ErrNonceTooLow):
+		return xerrors.Errorf("nonce too low: %w", err)
+	case errors.Is(err, messagepool.ErrNonceGap):
+		return xerrors.Errorf("nonce too high: %w", err)
+	}
+	return err
+}
+
+// simulateTransaction applies the message on the state of the heaviest tipset, behind the messages
+// of its sender pending in the mpool with lower nonces, and returns an api.ErrExecutionReverted
+// carrying the revert reason if it fails. The message is executed with the next nonce of its
+// sender, so it isn't simulated if pending messages are missing between the nonce of the sender
+// and its own: it would run ahead of messages it depends on.
+func (e *ethSend) simulateTransaction(ctx context.Context, msg *types.Message) error {
+	ts := e.chainStore.GetHeaviestTipSet()
+	st, _, err := e.stateManager.TipSetState(ctx, ts)
+	if err != nil {
+		return xerrors.Errorf("cannot get tipset state: %w", err)
+	}
+
+	var nonce uint64
+	actor, err := e.stateManager.LoadActorRaw(ctx, msg.From, st)
+	if err == nil {
+		nonce = actor.Nonce
+	} else if !errors.Is(err, types.ErrActorNotFound) {
+		return xerrors.Errorf("loading sender: %w", err)
+	}
+	pending, _ := e.messagePool.PendingFor(ctx, msg.From)
+	var priorMsgs []types.ChainMsg
+	for _, m := range pending {
+		if nonce >= msg.Nonce || m.Message.Nonce != nonce {
+			break
+		}
+		priorMsgs = append(priorMsgs, m)
+		nonce++
+	}
+	if nonce != msg.Nonce {
+		return nil
+	}
+
+	var opts *stmgr.CallOptions
+	if len(priorMsgs) > 0 {
+		opts = &stmgr.CallOptions{PriorMessages: priorMsgs}
+	}
+	res, err := e.stateManager.ApplyOnStateWithOptions(ctx, st, msg, ts, opts)
+	if err != nil {
+		return xerrors.Errorf("failed to simulate transaction: %w", err)
+	}
+	if res.MsgRct.ExitCode.IsError() {
+		return api.NewErrExecutionRevertedFromResult(res)
+	}
+	return nil
+}
+
 type EthSendDisabled struct{}
 
 func (EthSendDisabled) EthSendRawTransaction(ctx context.Context, rawTx ethtypes.EthBytes) (ethtypes.EthHash, error) {
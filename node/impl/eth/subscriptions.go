@@ -93,6 +93,7 @@ type ethSubscription struct {
 
 	mu      sync.Mutex
 	filters []filter.Filter
+	logPost logPostFilter
 	quit    func()
 
 	sendLk       sync.Mutex
@@ -181,7 +182,7 @@ func (e *ethSubscription) start(ctx context.Context) {
 		case v := <-e.in:
 			switch vt := v.(type) {
 			case *index.CollectedEvent:
-				evs, err := ethFilterResultFromEvents(ctx, []*index.CollectedEvent{vt}, e.chainStore, e.stateManager)
+				evs, err := ethFilterResultFromEvents(ctx, e.logPost.apply([]*index.CollectedEvent{vt}), e.chainStore, e.stateManager)
 				if err != nil {
 					continue
 				}
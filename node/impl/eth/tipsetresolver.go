@@ -197,6 +197,7 @@ func (tsr *tipSetResolver) GetTipsetByBlockNumberOrHash(ctx context.Context, blk
 		if height > head.Height()-1 {
 			return nil, xerrors.New("requested a future epoch (beyond 'latest')")
 		}
+		// Null rounds resolve to the preceding tipset, as their state is the one that tipset left.
 		ts, err := tsr.cs.GetTipsetByHeight(ctx, height, head, true)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to get tipset at height: %v", height)
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/builtin"
 	eam12 "github.com/filecoin-project/go-state-types/builtin/v12/eam"
@@ -167,18 +169,8 @@ func (e *ethTrace) EthTraceReplayBlockTransactions(ctx context.Context, blkNum s
 			return nil, xerrors.Errorf("failed building traces for msg %s: %w", ir.MsgCid, err)
 		}
 
-		var output []byte
-		if len(env.traces) > 0 {
-			switch r := env.traces[0].Result.(type) {
-			case *ethtypes.EthCallTraceResult:
-				output = r.Output
-			case *ethtypes.EthCreateTraceResult:
-				output = r.Code
-			}
-		}
-
 		allTraces = append(allTraces, &ethtypes.EthTraceReplayBlockTransaction{
-			Output:          output,
+			Output:          traceOutput(env.traces),
 			TransactionHash: txHash,
 			Trace:           env.traces,
 			StateDiff:       nil,
@@ -233,6 +225,189 @@ func (e *ethTrace) EthTraceTransaction(ctx context.Context, txHash string) ([]*e
 	return txTraces, nil
 }
 
+func (e *ethTrace) EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	tx, err := e.ethTransactionApi.EthGetTransactionByHash(ctx, &txHash)
+	if err != nil {
+		return nil, xerrors.Errorf("cannot get transaction by hash: %w", err)
+	}
+	if tx == nil {
+		return nil, xerrors.New("transaction not found")
+	}
+	// tx.BlockNumber is nil when the transaction is still in the mpool/pending
+	if tx.BlockNumber == nil {
+		return nil, xerrors.New("cannot replay pending transactions")
+	}
+
+	ts, err := e.tipsetResolver.GetTipsetByBlockNumber(ctx, strconv.FormatUint(uint64(*tx.BlockNumber), 10), true)
+	if err != nil {
+		return nil, err // don't wrap, to preserve ErrNullRound
+	}
+
+	// The messages of the tipset are executed on top of its parent state, up to the transaction.
+	stRoot, trace, err := e.stateManager.ExecutionTrace(ctx, ts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed when calling ExecutionTrace: %w", err)
+	}
+
+	st, err := e.stateManager.StateTree(stRoot)
+	if err != nil {
+		return nil, xerrors.Errorf("failed load computed state-tree: %w", err)
+	}
+
+	for _, ir := range trace {
+		if ir.Msg.From == builtinactors.SystemActorAddr {
+			continue
+		}
+
+		irHash, err := getTransactionHashByCid(ctx, e.chainStore, ir.MsgCid)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get transaction hash by cid: %w", err)
+		}
+		if irHash != txHash {
+			continue
+		}
+
+		env, err := baseEnvironment(st, ir.Msg.From)
+		if err != nil {
+			return nil, xerrors.Errorf("when processing message %s: %w", ir.MsgCid, err)
+		}
+
+		err = buildTraces(env, []int{}, &ir.ExecutionTrace)
+		if err != nil {
+			return nil, xerrors.Errorf("failed building traces for msg %s: %w", ir.MsgCid, err)
+		}
+
+		res := &ethtypes.EthReplayTransactionResult{
+			TransactionHash: txHash,
+			BlockNumber:     *tx.BlockNumber,
+			GasUsed:         ethtypes.EthUint64(ir.MsgRct.GasUsed),
+			Output:          traceOutput(env.traces),
+			Error:           ir.Error,
+			Trace:           env.traces,
+		}
+		if tx.BlockHash != nil {
+			res.BlockHash = *tx.BlockHash
+		}
+		if ir.MsgRct.ExitCode.IsSuccess() {
+			res.Status = 1
+		}
+		return res, nil
+	}
+
+	return nil, xerrors.Errorf("transaction %s not found in the execution of its block", txHash)
+}
+
+func (e *ethTrace) EthTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	params, err := decodeCallParams[ethtypes.EthTraceBlockParams](p)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := e.tipsetResolver.GetTipsetByBlockNumber(ctx, params.Block, true)
+	if err != nil {
+		return nil, err // don't wrap, to preserve ErrNullRound
+	}
+	return e.traceBlockCalls(ctx, ts, params.Config)
+}
+
+func (e *ethTrace) EthTraceBlockByHash(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	params, err := decodeCallParams[ethtypes.EthTraceBlockParams](p)
+	if err != nil {
+		return nil, err
+	}
+	blkHash, err := ethtypes.ParseEthHash(params.Block)
+	if err != nil {
+		return nil, api.NewErrInvalidParams(xerrors.Errorf("cannot parse block hash: %w", err))
+	}
+	ts, err := e.tipsetResolver.GetTipSetByHash(ctx, blkHash)
+	if err != nil {
+		return nil, err // don't wrap, to preserve ErrNullRound
+	}
+	return e.traceBlockCalls(ctx, ts, params.Config)
+}
+
+// traceBlockCalls replays the messages of the tipset and returns the call frames of their traces,
+// keyed by transaction hash, in execution order. Messages from the system actor are left out, as
+// they are by EthTraceBlock.
+func (e *ethTrace) traceBlockCalls(ctx context.Context, ts *types.TipSet, config ethtypes.EthTraceBlockConfig) ([]*ethtypes.EthTraceBlockResult, error) {
+	switch config.Tracer {
+	case ethtypes.EthCallTracer:
+	case ethtypes.EthPrestateTracer:
+		return nil, api.NewErrInvalidParams(xerrors.New("the prestateTracer isn't supported for blocks, as the state between their transactions isn't kept"))
+	case "":
+		return nil, api.NewErrInvalidParams(xerrors.New("the opcode logger isn't supported, as the FVM doesn't trace EVM instructions: use the callTracer"))
+	default:
+		return nil, api.NewErrInvalidParams(xerrors.Errorf("unsupported tracer %q", config.Tracer))
+	}
+
+	stRoot, trace, err := e.stateManager.ExecutionTrace(ctx, ts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed when calling ExecutionTrace: %w", err)
+	}
+
+	st, err := e.stateManager.StateTree(stRoot)
+	if err != nil {
+		return nil, xerrors.Errorf("failed load computed state-tree: %w", err)
+	}
+
+	results := make([]*ethtypes.EthTraceBlockResult, 0, len(trace))
+	traced := make(map[ethtypes.EthHash]struct{}, len(trace))
+	for _, ir := range trace {
+		if ir.Msg.From == builtinactors.SystemActorAddr {
+			continue
+		}
+		if config.EVMOnly && !invokesEVM(&ir.ExecutionTrace) {
+			continue
+		}
+
+		txHash, err := getTransactionHashByCid(ctx, e.chainStore, ir.MsgCid)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get transaction hash by cid: %w", err)
+		}
+		if txHash == ethtypes.EmptyEthHash {
+			return nil, xerrors.Errorf("cannot find transaction hash for cid %s", ir.MsgCid)
+		}
+		// A transaction is a single message, which is executed once even if several blocks of the
+		// tipset include it, so its trace is the one of its first execution.
+		if _, ok := traced[txHash]; ok {
+			continue
+		}
+		traced[txHash] = struct{}{}
+
+		env, err := baseEnvironment(st, ir.Msg.From)
+		if err != nil {
+			return nil, xerrors.Errorf("when processing message %s: %w", ir.MsgCid, err)
+		}
+		if err := buildTraces(env, []int{}, &ir.ExecutionTrace); err != nil {
+			return nil, xerrors.Errorf("failed building traces for msg %s: %w", ir.MsgCid, err)
+		}
+		frame, err := callFrames(env.traces)
+		if err != nil {
+			return nil, xerrors.Errorf("nesting traces of msg %s: %w", ir.MsgCid, err)
+		}
+		if config.TracerConfig != nil && config.TracerConfig.OnlyTopCall {
+			frame.Calls = nil
+		}
+		results = append(results, &ethtypes.EthTraceBlockResult{TxHash: txHash, Result: frame})
+	}
+
+	return results, nil
+}
+
+// traceOutput returns the output of the top level trace of a transaction: its return data, or the
+// code of the contract it created.
+func traceOutput(traces []*ethtypes.EthTrace) ethtypes.EthBytes {
+	if len(traces) == 0 {
+		return nil
+	}
+	switch r := traces[0].Result.(type) {
+	case *ethtypes.EthCallTraceResult:
+		return r.Output
+	case *ethtypes.EthCreateTraceResult:
+		return r.Code
+	}
+	return nil
+}
+
 func (e *ethTrace) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	// Define EthBlockNumberFromString as a private function within EthTraceFilter
 	// TODO(rv): this all moves to TipSetProvider I think, then we get rid of TipSetProvider for this module
@@ -278,7 +453,8 @@ func (e *ethTrace) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceF
 		return nil, xerrors.Errorf("cannot parse toBlock: %w", err)
 	}
 
-	var results []*ethtypes.EthTraceFilterResult
+	// Ethereum clients expect an empty array rather than null when no trace matches.
+	results := []*ethtypes.EthTraceFilterResult{}
 
 	if filter.Count != nil {
 		// If filter.Count is specified and it is 0, return an empty result set immediately.
@@ -294,6 +470,11 @@ func (e *ethTrace) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceF
 
 	traceCounter := ethtypes.EthUint64(0)
 	for blkNum := fromBlock; blkNum <= toBlock; blkNum++ {
+		// Stop tracing the range once the caller gave up, as large ranges can take a while.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		blockTraces, err := e.EthTraceBlock(ctx, strconv.FormatUint(uint64(blkNum), 10))
 		if err != nil {
 			if errors.Is(err, &api.ErrNullRound{}) {
@@ -332,9 +513,10 @@ func (e *ethTrace) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceF
 	return results, nil
 }
 
-// matchFilterCriteria checks if a trace matches the filter criteria.
+// matchFilterCriteria checks if a trace matches the filter criteria. Failed contract creations have
+// no recipient, so they only match filters without any to address.
 func matchFilterCriteria(trace *ethtypes.EthTraceBlock, fromDecodedAddresses []ethtypes.EthAddress, toDecodedAddresses []ethtypes.EthAddress) (bool, error) {
-	var traceTo ethtypes.EthAddress
+	var traceTo *ethtypes.EthAddress
 	var traceFrom ethtypes.EthAddress
 
 	switch trace.Type {
@@ -343,7 +525,7 @@ func matchFilterCriteria(trace *ethtypes.EthTraceBlock, fromDecodedAddresses []e
 		if !ok {
 			return false, xerrors.New("invalid call trace action")
 		}
-		traceTo = action.To
+		traceTo = &action.To
 		traceFrom = action.From
 	case "create":
 		result, okResult := trace.Result.(*ethtypes.EthCreateTraceResult)
@@ -356,11 +538,7 @@ func matchFilterCriteria(trace *ethtypes.EthTraceBlock, fromDecodedAddresses []e
 			return false, xerrors.New("invalid create trace action")
 		}
 
-		if result.Address == nil {
-			return false, xerrors.New("address is nil in create trace result")
-		}
-
-		traceTo = *result.Address
+		traceTo = result.Address
 		traceFrom = action.From
 	default:
 		return false, xerrors.Errorf("invalid trace type: %s", trace.Type)
@@ -384,7 +562,7 @@ func matchFilterCriteria(trace *ethtypes.EthTraceBlock, fromDecodedAddresses []e
 	if len(toDecodedAddresses) > 0 {
 		toMatch := false
 		for _, ethAddr := range toDecodedAddresses {
-			if traceTo == ethAddr {
+			if traceTo != nil && *traceTo == ethAddr {
 				toMatch = true
 				break
 			}
@@ -431,6 +609,16 @@ func traceIsEVMOrEAM(et *types.ExecutionTrace) bool {
 		et.InvokedActor.Id != abi.ActorID(builtin.EthereumAddressManagerActorID)
 }
 
+// invokesEVM returns true if the message of the trace invokes an EVM actor or the Ethereum Address
+// Manager.
+func invokesEVM(et *types.ExecutionTrace) bool {
+	if et.InvokedActor == nil {
+		return false
+	}
+	return builtinactors.IsEvmActor(et.InvokedActor.State.Code) ||
+		et.InvokedActor.Id == abi.ActorID(builtin.EthereumAddressManagerActorID)
+}
+
 func traceErrMsg(et *types.ExecutionTrace) string {
 	code := et.MsgRct.ExitCode
 
@@ -918,6 +1106,63 @@ func traceEVMPrivate(env *environment, addr []int, et *types.ExecutionTrace) (*e
 	return nil, nil, nil
 }
 
+// callFrame returns the call frame of an applied call, resolving its sender in st.
+func callFrame(st *state.StateTree, ir *api.InvocResult) (*ethtypes.EthCallFrame, error) {
+	env, err := baseEnvironment(st, ir.Msg.From)
+	if err != nil {
+		return nil, err
+	}
+	if err := buildTraces(env, []int{}, &ir.ExecutionTrace); err != nil {
+		return nil, xerrors.Errorf("failed building traces: %w", err)
+	}
+	return callFrames(env.traces)
+}
+
+// callFrames nests the traces of a call, as built by buildTraces, into the call frames reported by
+// Geth's callTracer, and returns the frame of the call itself.
+func callFrames(traces []*ethtypes.EthTrace) (*ethtypes.EthCallFrame, error) {
+	if len(traces) == 0 {
+		return nil, xerrors.New("the call wasn't executed")
+	}
+
+	// The traces are ordered depth first, so the frames enclosing each trace are those of the
+	// traces last seen at lower depths.
+	var enclosing []*ethtypes.EthCallFrame
+	for _, trace := range traces {
+		frame := &ethtypes.EthCallFrame{Error: trace.Error}
+		switch action := trace.Action.(type) {
+		case *ethtypes.EthCallTraceAction:
+			to := action.To
+			frame.Type = strings.ToUpper(action.CallType)
+			frame.From, frame.To = action.From, &to
+			frame.Value, frame.Gas, frame.Input = action.Value, action.Gas, action.Input
+		case *ethtypes.EthCreateTraceAction:
+			frame.Type = "CREATE"
+			frame.From = action.From
+			frame.Value, frame.Gas, frame.Input = action.Value, action.Gas, action.Init
+		default:
+			return nil, xerrors.Errorf("unexpected trace action %T", trace.Action)
+		}
+		switch result := trace.Result.(type) {
+		case *ethtypes.EthCallTraceResult:
+			frame.GasUsed, frame.Output = result.GasUsed, result.Output
+		case *ethtypes.EthCreateTraceResult:
+			frame.GasUsed, frame.Output, frame.To = result.GasUsed, result.Code, result.Address
+		}
+
+		depth := len(trace.TraceAddress)
+		if depth > len(enclosing) || (depth == 0 && len(enclosing) > 0) {
+			return nil, xerrors.Errorf("unexpected trace at %v", trace.TraceAddress)
+		}
+		if depth > 0 {
+			parent := enclosing[depth-1]
+			parent.Calls = append(parent.Calls, frame)
+		}
+		enclosing = append(enclosing[:depth], frame)
+	}
+	return enclosing[0], nil
+}
+
 type EthTraceDisabled struct{}
 
 func (EthTraceDisabled) EthTraceBlock(ctx context.Context, block string) ([]*ethtypes.EthTraceBlock, error) {
@@ -929,6 +1174,15 @@ func (EthTraceDisabled) EthTraceReplayBlockTransactions(ctx context.Context, blk
 func (EthTraceDisabled) EthTraceTransaction(ctx context.Context, ethTxHash string) ([]*ethtypes.EthTraceTransaction, error) {
 	return nil, ErrModuleDisabled
 }
+func (EthTraceDisabled) EthReplayTransaction(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthReplayTransactionResult, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthTraceDisabled) EthTraceBlockByNumber(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthTraceDisabled) EthTraceBlockByHash(ctx context.Context, p jsonrpc.RawParams) ([]*ethtypes.EthTraceBlockResult, error) {
+	return nil, ErrModuleDisabled
+}
 func (EthTraceDisabled) EthTraceFilter(ctx context.Context, filter ethtypes.EthTraceFilterCriteria) ([]*ethtypes.EthTraceFilterResult, error) {
 	return nil, ErrModuleDisabled
 }
@@ -0,0 +1,45 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+)
+
+func TestMatchFilterCriteria(t *testing.T) {
+	from, to, other := ethtypes.EthAddress{0x01}, ethtypes.EthAddress{0x02}, ethtypes.EthAddress{0x03}
+
+	call := &ethtypes.EthTraceBlock{EthTrace: &ethtypes.EthTrace{
+		Type:   "call",
+		Action: &ethtypes.EthCallTraceAction{From: from, To: to},
+		Result: &ethtypes.EthCallTraceResult{},
+	}}
+	match := func(trace *ethtypes.EthTraceBlock, fromAddrs, toAddrs []ethtypes.EthAddress) bool {
+		ok, err := matchFilterCriteria(trace, fromAddrs, toAddrs)
+		require.NoError(t, err)
+		return ok
+	}
+	require.True(t, match(call, nil, nil))
+	require.True(t, match(call, []ethtypes.EthAddress{other, from}, []ethtypes.EthAddress{to}))
+	require.False(t, match(call, []ethtypes.EthAddress{other}, nil))
+	require.False(t, match(call, nil, []ethtypes.EthAddress{other}))
+
+	created := &ethtypes.EthTraceBlock{EthTrace: &ethtypes.EthTrace{
+		Type:   "create",
+		Action: &ethtypes.EthCreateTraceAction{From: from},
+		Result: &ethtypes.EthCreateTraceResult{Address: &to},
+	}}
+	require.True(t, match(created, []ethtypes.EthAddress{from}, []ethtypes.EthAddress{to}))
+
+	// Failed creations didn't create any contract, so they can't match a to address.
+	failed := &ethtypes.EthTraceBlock{EthTrace: &ethtypes.EthTrace{
+		Type:   "create",
+		Action: &ethtypes.EthCreateTraceAction{From: from},
+		Result: &ethtypes.EthCreateTraceResult{},
+		Error:  "Reverted",
+	}}
+	require.True(t, match(failed, []ethtypes.EthAddress{from}, nil))
+	require.False(t, match(failed, nil, []ethtypes.EthAddress{to}))
+}
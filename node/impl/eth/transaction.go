@@ -10,11 +10,13 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
 
 	"github.com/filecoin-project/lotus/api"
 	builtinactors "github.com/filecoin-project/lotus/chain/actors/builtin"
 	builtinevm "github.com/filecoin-project/lotus/chain/actors/builtin/evm"
 	"github.com/filecoin-project/lotus/chain/index"
+	"github.com/filecoin-project/lotus/chain/state"
 	"github.com/filecoin-project/lotus/chain/stmgr"
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/types/ethtypes"
@@ -121,7 +123,9 @@ func (e *ethTransaction) EthGetBlockByHash(ctx context.Context, blkHash ethtypes
 }
 
 func (e *ethTransaction) EthGetBlockByNumber(ctx context.Context, blkParam string, fullTxInfo bool) (ethtypes.EthBlock, error) {
-	ts, err := e.tipsetResolver.GetTipsetByBlockNumber(ctx, blkParam, true)
+	// Null rounds don't have a block of their own; the block preceding them occupies their numbers,
+	// so that every number up to eth_blockNumber resolves to a block.
+	ts, err := e.tipsetResolver.GetTipsetByBlockNumber(ctx, blkParam, false)
 	if err != nil {
 		return ethtypes.EthBlock{}, err // don't wrap, to preserve ErrNullRound
 	}
@@ -248,6 +252,20 @@ func (e *ethTransaction) EthGetTransactionCount(ctx context.Context, sender etht
 
 	// Handle "pending" block parameter separately
 	if blkParam.PredefinedBlock != nil && *blkParam.PredefinedBlock == "pending" {
+		// Contracts can't send messages, so the mpool knows nothing about their nonce: report
+		// the one at the head of the chain instead.
+		stateCid, _, err := e.stateManager.TipSetState(ctx, e.chainStore.GetHeaviestTipSet())
+		if err != nil {
+			return 0, err
+		}
+		actor, err := e.stateManager.LoadActorRaw(ctx, addr, stateCid)
+		if err != nil && !errors.Is(err, types.ErrActorNotFound) {
+			return 0, xerrors.Errorf("failed to lookup actor %s: %w", sender, err)
+		}
+		if actor != nil && builtinactors.IsEvmActor(actor.Code) {
+			return contractNonce(ctx, e.chainStore, actor)
+		}
+
 		nonce, err := e.mpoolApi.MpoolGetNonce(ctx, addr)
 		if err != nil {
 			return ethtypes.EthUint64(0), xerrors.Errorf("failed to get nonce from mpool: %w", err)
@@ -277,28 +295,85 @@ func (e *ethTransaction) EthGetTransactionCount(ctx context.Context, sender etht
 
 	// Handle EVM actor case
 	if builtinactors.IsEvmActor(actor.Code) {
-		evmState, err := builtinevm.Load(e.chainStore.ActorStore(ctx), actor)
-		if err != nil {
-			return 0, xerrors.Errorf("failed to load evm state: %w", err)
-		}
-		if alive, err := evmState.IsAlive(); err != nil {
-			return 0, err
-		} else if !alive {
-			return 0, nil
-		}
-		nonce, err := evmState.Nonce()
-		return ethtypes.EthUint64(nonce), err
+		return contractNonce(ctx, e.chainStore, actor)
 	}
 
 	// For non-EVM actors, get the nonce from the actor state
 	return ethtypes.EthUint64(actor.Nonce), nil
 }
 
+// contractNonce returns the nonce of an EVM actor, which counts the contracts it created as in
+// Ethereum rather than the messages it sent. Dead contracts have a zero nonce.
+func contractNonce(ctx context.Context, chainStore ChainStore, actor *types.Actor) (ethtypes.EthUint64, error) {
+	evmState, err := builtinevm.Load(chainStore.ActorStore(ctx), actor)
+	if err != nil {
+		return 0, xerrors.Errorf("failed to load evm state: %w", err)
+	}
+	if alive, err := evmState.IsAlive(); err != nil {
+		return 0, err
+	} else if !alive {
+		return 0, nil
+	}
+	nonce, err := evmState.Nonce()
+	return ethtypes.EthUint64(nonce), err
+}
+
 func (e *ethTransaction) EthGetTransactionReceipt(ctx context.Context, txHash ethtypes.EthHash) (*ethtypes.EthTxReceipt, error) {
 	return e.EthGetTransactionReceiptLimited(ctx, txHash, api.LookbackNoLimit)
 }
 
 func (e *ethTransaction) EthGetTransactionReceiptLimited(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*ethtypes.EthTxReceipt, error) {
+	msgLookup, err := e.searchTransaction(ctx, txHash, limit)
+	if err != nil || msgLookup == nil {
+		return nil, err
+	}
+
+	blk, err := e.loadReceiptBlock(ctx, msgLookup.TipSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return blk.receipt(ctx, e.chainStore, e.ethEvents, txHash, msgLookup)
+}
+
+func (e *ethTransaction) EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	return e.EthGetTransactionReceiptsLimited(ctx, txHashes, api.LookbackNoLimit)
+}
+
+func (e *ethTransaction) EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	receipts := make([]*ethtypes.EthTxReceipt, len(txHashes))
+
+	// Transactions are typically fetched a block at a time, so load each block only once.
+	blocks := make(map[types.TipSetKey]*receiptBlock)
+	for i, txHash := range txHashes {
+		msgLookup, err := e.searchTransaction(ctx, txHash, limit)
+		if err != nil {
+			return nil, err
+		} else if msgLookup == nil {
+			continue
+		}
+
+		blk, ok := blocks[msgLookup.TipSet]
+		if !ok {
+			blk, err = e.loadReceiptBlock(ctx, msgLookup.TipSet)
+			if err != nil {
+				return nil, err
+			}
+			blocks[msgLookup.TipSet] = blk
+		}
+
+		receipts[i], err = blk.receipt(ctx, e.chainStore, e.ethEvents, txHash, msgLookup)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+// searchTransaction looks up where the transaction with the given hash was executed. It returns nil
+// if the transaction can't be found.
+func (e *ethTransaction) searchTransaction(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*api.MsgLookup, error) {
 	c, err := e.getCidForTransaction(ctx, &txHash)
 	if err != nil {
 		return nil, err
@@ -311,22 +386,30 @@ func (e *ethTransaction) EthGetTransactionReceiptLimited(ctx context.Context, tx
 			return nil, nil
 		}
 		return nil, xerrors.Errorf("could not find transaction %s: %w", txHash, err)
-	} else if msgLookup == nil {
-		// This is the best we can do. We may just not have indexed this transaction, or we may have a
-		// limit applied and not searched far back enough, but we don't have a way to go. Because
-		// Ethereum tooling expects an empty response for transaction-not-found, we don't have a way of
-		// differentiating between "can't find" and "doesn't exist".
-		return nil, nil
 	}
+	// If msgLookup is nil, this is the best we can do. We may just not have indexed this
+	// transaction, or we may have a limit applied and not searched far back enough, but we don't
+	// have a way to go. Because Ethereum tooling expects an empty response for
+	// transaction-not-found, we don't have a way of differentiating between "can't find" and
+	// "doesn't exist".
+	return msgLookup, nil
+}
 
-	tx, err := newEthTxFromMessageLookup(ctx, msgLookup, -1, e.chainStore, e.stateManager)
-	if err != nil {
-		return nil, xerrors.Errorf("failed to convert %s into an Eth Txn: %w", txHash, err)
-	}
+// receiptBlock holds what's needed to build the receipts of the transactions included in a block.
+type receiptBlock struct {
+	parentTs    *types.TipSet
+	parentTsCid cid.Cid
+	stateTree   *state.StateTree
+	baseFee     big.Int
+	txIndexes   map[cid.Cid]int
+}
 
-	ts, err := e.chainStore.GetTipSetFromKey(ctx, msgLookup.TipSet)
+// loadReceiptBlock loads the block for transactions whose execution was looked up at the given
+// tipset. The transactions are included in its parent.
+func (e *ethTransaction) loadReceiptBlock(ctx context.Context, tsk types.TipSetKey) (*receiptBlock, error) {
+	ts, err := e.chainStore.GetTipSetFromKey(ctx, tsk)
 	if err != nil {
-		return nil, xerrors.Errorf("failed to lookup tipset %s when constructing the eth txn receipt: %w", msgLookup.TipSet, err)
+		return nil, xerrors.Errorf("failed to lookup tipset %s when constructing the eth txn receipt: %w", tsk, err)
 	}
 
 	// The tx is located in the parent tipset
@@ -335,9 +418,46 @@ func (e *ethTransaction) EthGetTransactionReceiptLimited(ctx context.Context, tx
 		return nil, xerrors.Errorf("failed to lookup tipset %s when constructing the eth txn receipt: %w", ts.Parents(), err)
 	}
 
-	baseFee := parentTs.Blocks()[0].ParentBaseFee
+	parentTsCid, err := parentTs.Key().Cid()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get tipset key cid: %w", err)
+	}
+
+	msgs, err := e.chainStore.MessagesForTipset(ctx, parentTs)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load messages for tipset %s: %w", parentTs.Key(), err)
+	}
+	txIndexes := make(map[cid.Cid]int, len(msgs))
+	for i, msg := range msgs {
+		txIndexes[msg.Cid()] = i
+	}
+
+	stateTree, err := e.stateManager.StateTree(ts.ParentState())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load message state tree: %w", err)
+	}
+
+	return &receiptBlock{
+		parentTs:    parentTs,
+		parentTsCid: parentTsCid,
+		stateTree:   stateTree,
+		baseFee:     parentTs.Blocks()[0].ParentBaseFee,
+		txIndexes:   txIndexes,
+	}, nil
+}
+
+func (b *receiptBlock) receipt(ctx context.Context, cs ChainStore, ev EthEventsInternal, txHash ethtypes.EthHash, msgLookup *api.MsgLookup) (*ethtypes.EthTxReceipt, error) {
+	txIdx, ok := b.txIndexes[msgLookup.Message]
+	if !ok {
+		return nil, xerrors.Errorf("failed to convert %s into an Eth Txn: cannot find the msg in the tipset", txHash)
+	}
+
+	tx, err := newEthTx(ctx, cs, b.stateTree, b.parentTs.Height(), b.parentTsCid, msgLookup.Message, txIdx)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to convert %s into an Eth Txn: %w", txHash, err)
+	}
 
-	receipt, err := newEthTxReceipt(ctx, tx, baseFee, msgLookup.Receipt, e.ethEvents)
+	receipt, err := newEthTxReceipt(ctx, tx, b.baseFee, msgLookup.Receipt, ev)
 	if err != nil {
 		return nil, xerrors.Errorf("failed to create Eth receipt: %w", err)
 	}
@@ -551,6 +671,12 @@ func (EthTransactionDisabled) EthGetTransactionReceipt(ctx context.Context, txHa
 func (EthTransactionDisabled) EthGetTransactionReceiptLimited(ctx context.Context, txHash ethtypes.EthHash, limit abi.ChainEpoch) (*ethtypes.EthTxReceipt, error) {
 	return nil, ErrModuleDisabled
 }
+func (EthTransactionDisabled) EthGetTransactionReceipts(ctx context.Context, txHashes []ethtypes.EthHash) ([]*ethtypes.EthTxReceipt, error) {
+	return nil, ErrModuleDisabled
+}
+func (EthTransactionDisabled) EthGetTransactionReceiptsLimited(ctx context.Context, txHashes []ethtypes.EthHash, limit abi.ChainEpoch) ([]*ethtypes.EthTxReceipt, error) {
+	return nil, ErrModuleDisabled
+}
 func (EthTransactionDisabled) EthGetBlockReceipts(ctx context.Context, blockParam ethtypes.EthBlockNumberOrHash) ([]*ethtypes.EthTxReceipt, error) {
 	return nil, ErrModuleDisabled
 }
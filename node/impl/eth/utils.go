@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/ipfs/go-cid"
 	"github.com/multiformats/go-multicodec"
@@ -142,6 +143,62 @@ func executeTipset(ctx context.Context, ts *types.TipSet, cs ChainStore, sm Stat
 	return stRoot, msgs, rcpts, nil
 }
 
+// pendingStateMessages returns the messages of the message pool that are applied on top of the
+// state resulting from the head, ts, to build the state of the pending block. The messages of
+// sender, if set, come first so they're never left out, followed by those of the other senders,
+// pendingStateGasLimit bounds the gas of the messages applied to build the state of the pending
+// block, a tenth of a block's gas limit.
+const pendingStateGasLimit = buildconstants.BlockGasLimit / 10
+
+// each in nonce order. The messages are applied for every request at the pending block, so to
+// bound the work done for a large message pool, messages stop being added once they would take more
+// than pendingStateGasLimit. The messages are left out if they can't be looked up.
+func pendingStateMessages(ctx context.Context, mp MessagePool, sm StateManager, ts *types.TipSet, sender address.Address) []types.ChainMsg {
+	var (
+		msgs     []types.ChainMsg
+		included = make(map[cid.Cid]struct{})
+		gasLimit int64
+		// blocked is the sender of the last message left out, whose following messages can't be
+		// applied without it.
+		blocked address.Address
+	)
+	add := func(m *types.SignedMessage) {
+		if m.Message.From == blocked {
+			return
+		}
+		if gasLimit+m.Message.GasLimit > pendingStateGasLimit {
+			blocked = m.Message.From
+			return
+		}
+		gasLimit += m.Message.GasLimit
+		msgs = append(msgs, m)
+		included[m.Cid()] = struct{}{}
+	}
+
+	if sender != address.Undef {
+		if senderKey, err := sm.ResolveToDeterministicAddress(ctx, sender, ts); err == nil {
+			own, _ := mp.PendingFor(ctx, senderKey)
+			for _, m := range own {
+				add(m)
+			}
+		}
+	}
+
+	// The message pool returns the messages of each sender in nonce order, but the senders in no
+	// particular order, so they're sorted to leave out the same messages every time.
+	pending, _ := mp.Pending(ctx)
+	sort.SliceStable(pending, func(i, j int) bool {
+		return bytes.Compare(pending[i].Message.From.Bytes(), pending[j].Message.From.Bytes()) < 0
+	})
+	blocked = address.Undef
+	for _, m := range pending {
+		if _, ok := included[m.Cid()]; !ok {
+			add(m)
+		}
+	}
+	return msgs
+}
+
 // lookupEthAddress makes its best effort at finding the Ethereum address for a
 // Filecoin address. It does the following:
 //
@@ -318,7 +375,7 @@ func ethTxFromNativeMessage(msg *types.Message, st *state.StateTree) (ethtypes.E
 		Gas:                  ethtypes.EthUint64(msg.GasLimit),
 		MaxFeePerGas:         &maxFeePerGas,
 		MaxPriorityFeePerGas: &maxPriorityFeePerGas,
-		AccessList:           []ethtypes.EthHash{},
+		AccessList:           []ethtypes.EthAccessTuple{},
 	}
 
 	// Then we try to see if it's "special". If we fail, we ignore the error and keep treating
@@ -504,6 +561,7 @@ func newEthTxReceipt(ctx context.Context, tx ethtypes.EthTx, baseFee big.Int, ms
 		effectiveGasPrice = big.Div(totalSpent, big.NewInt(msgReceipt.GasUsed))
 	}
 	txReceipt.EffectiveGasPrice = ethtypes.EthBigInt(effectiveGasPrice)
+	txReceipt.EffectivePriorityFeePerGas = ethtypes.EthBigInt(effectivePriorityFee(big.Int(gasFeeCap), big.Int(gasPremium), baseFee))
 
 	if txReceipt.To == nil && msgReceipt.ExitCode.IsSuccess() {
 		// Create and Create2 return the same things.
@@ -515,7 +573,9 @@ func newEthTxReceipt(ctx context.Context, tx ethtypes.EthTx, baseFee big.Int, ms
 		txReceipt.ContractAddress = &addr
 	}
 
-	if rct := msgReceipt; rct.EventsRoot != nil {
+	// Events emitted by a failed message are reverted with it, so its receipt has no logs even if
+	// the index holds any for the transaction.
+	if rct := msgReceipt; rct.ExitCode.IsSuccess() && rct.EventsRoot != nil {
 		logs, err := ev.GetEthLogsForBlockAndTransaction(ctx, &blockHash, tx.Hash)
 		if err != nil {
 			return ethtypes.EthTxReceipt{}, xerrors.Errorf("failed to get eth logs for block and transaction: %w", err)
@@ -535,6 +595,12 @@ func newEthTxReceipt(ctx context.Context, tx ethtypes.EthTx, baseFee big.Int, ms
 	return txReceipt, nil
 }
 
+// effectivePriorityFee returns the priority fee per unit of gas a message pays: its premium, capped
+// by what its fee cap leaves above the base fee.
+func effectivePriorityFee(feeCap, premium, baseFee big.Int) big.Int {
+	return big.Max(big.Min(premium, big.Sub(feeCap, baseFee)), big.Zero())
+}
+
 func encodeFilecoinParamsAsABI(method abi.MethodNum, codec uint64, params []byte) []byte {
 	buf := []byte{0x86, 0x8e, 0x10, 0xc4} // Native method selector.
 	return append(buf, encodeAsABIHelper(uint64(method), codec, params)...)
@@ -2,12 +2,19 @@ package eth
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"testing"
 
 	"github.com/multiformats/go-multicodec"
 	"github.com/stretchr/testify/require"
 	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/crypto"
+
+	"github.com/filecoin-project/lotus/chain/types"
 )
 
 func TestABIEncoding(t *testing.T) {
@@ -60,3 +67,65 @@ func TestDecodePayload(t *testing.T) {
 	_, err = decodePayload(w.Bytes(), 42)
 	require.Error(t, err)
 }
+
+func TestEffectivePriorityFee(t *testing.T) {
+	baseFee := big.NewInt(100)
+	// The premium is paid in full when the fee cap leaves enough above the base fee.
+	require.Equal(t, big.NewInt(10), effectivePriorityFee(big.NewInt(1000), big.NewInt(10), baseFee))
+	// Otherwise, only what the fee cap leaves is.
+	require.Equal(t, big.NewInt(5), effectivePriorityFee(big.NewInt(105), big.NewInt(10), baseFee))
+	// Nothing is, when the fee cap doesn't cover the base fee.
+	require.Equal(t, big.Zero(), effectivePriorityFee(big.NewInt(90), big.NewInt(10), baseFee))
+}
+
+type pendingTestMessagePool struct {
+	MessagePool
+	pending []*types.SignedMessage
+}
+
+func (mp *pendingTestMessagePool) Pending(context.Context) ([]*types.SignedMessage, *types.TipSet) {
+	return append([]*types.SignedMessage(nil), mp.pending...), nil
+}
+
+func (mp *pendingTestMessagePool) PendingFor(_ context.Context, a address.Address) ([]*types.SignedMessage, *types.TipSet) {
+	var out []*types.SignedMessage
+	for _, m := range mp.pending {
+		if m.Message.From == a {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+type keyAddressStateManager struct {
+	StateManager
+}
+
+func (keyAddressStateManager) ResolveToDeterministicAddress(_ context.Context, addr address.Address, _ *types.TipSet) (address.Address, error) {
+	return addr, nil
+}
+
+func TestPendingStateMessages(t *testing.T) {
+	ctx := context.Background()
+	a, b, c := mustIDAddress(t, 100), mustIDAddress(t, 101), mustIDAddress(t, 102)
+	msg := func(from address.Address, nonce uint64, gasLimit int64) *types.SignedMessage {
+		return &types.SignedMessage{Message: types.Message{From: from, To: a, Nonce: nonce, GasLimit: gasLimit, Value: big.Zero(), GasFeeCap: big.Zero(), GasPremium: big.Zero()}, Signature: crypto.Signature{Type: crypto.SigTypeSecp256k1}}
+	}
+	c0, c1 := msg(c, 0, 1), msg(c, 1, 1)
+	b0, b1 := msg(b, 0, 3), msg(b, 1, 1)
+	a0 := msg(a, 0, pendingStateGasLimit-4)
+	mp := &pendingTestMessagePool{pending: []*types.SignedMessage{c0, c1, b0, b1, a0}}
+
+	// The messages are taken by sender until they'd take more gas than the budget, and the
+	// messages following one left out are left out too.
+	require.Equal(t, []types.ChainMsg{a0, b0, b1}, pendingStateMessages(ctx, mp, keyAddressStateManager{}, nil, address.Undef))
+
+	// The messages of the sender of a call come first.
+	require.Equal(t, []types.ChainMsg{c0, c1, a0}, pendingStateMessages(ctx, mp, keyAddressStateManager{}, nil, c))
+}
+
+func mustIDAddress(t *testing.T, id uint64) address.Address {
+	addr, err := address.NewIDAddress(id)
+	require.NoError(t, err)
+	return addr
+}
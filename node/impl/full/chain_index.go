@@ -15,6 +15,8 @@ import (
 
 type ChainIndexerAPI interface {
 	ChainValidateIndex(ctx context.Context, epoch abi.ChainEpoch, backfill bool) (*types.IndexValidation, error)
+	ChainBackfillIndex(ctx context.Context, from, to abi.ChainEpoch) (*types.IndexBackfill, error)
+	ChainBackfillIndexStatus(ctx context.Context) (*types.IndexBackfill, error)
 }
 
 var (
@@ -37,6 +39,20 @@ func (ch *ChainIndexHandler) ChainValidateIndex(ctx context.Context, epoch abi.C
 	return ch.indexer.ChainValidateIndex(ctx, epoch, backfill)
 }
 
+func (ch *ChainIndexHandler) ChainBackfillIndex(ctx context.Context, from, to abi.ChainEpoch) (*types.IndexBackfill, error) {
+	if ch.indexer == nil {
+		return nil, errors.New("chain indexer is disabled")
+	}
+	return ch.indexer.ChainBackfillIndex(ctx, from, to)
+}
+
+func (ch *ChainIndexHandler) ChainBackfillIndexStatus(ctx context.Context) (*types.IndexBackfill, error) {
+	if ch.indexer == nil {
+		return nil, errors.New("chain indexer is disabled")
+	}
+	return ch.indexer.ChainBackfillIndexStatus(ctx)
+}
+
 var _ ChainIndexerAPI = (*ChainIndexHandler)(nil)
 
 func NewChainIndexHandler(indexer index.Indexer) *ChainIndexHandler {
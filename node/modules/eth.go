@@ -63,40 +63,76 @@ func MakeEthLookupV1(
 	chainStore eth.ChainStore,
 	stateManager eth.StateManager,
 	syncApi eth.SyncAPI,
+	messagePool eth.MessagePool,
 	stateBlockstore dtypes.StateBlockstore,
 	tipsetResolver full.EthTipSetResolverV1,
 ) full.EthLookupAPIV1 {
-	return eth.NewEthLookupAPI(chainStore, stateManager, syncApi, stateBlockstore, tipsetResolver)
+	return eth.NewEthLookupAPI(chainStore, stateManager, syncApi, messagePool, stateBlockstore, tipsetResolver)
 }
 
 func MakeEthLookupV2(
 	chainStore eth.ChainStore,
 	stateManager eth.StateManager,
 	syncApi eth.SyncAPI,
+	messagePool eth.MessagePool,
 	stateBlockstore dtypes.StateBlockstore,
 	tipsetResolver full.EthTipSetResolverV2,
 ) full.EthLookupAPIV2 {
-	return eth.NewEthLookupAPI(chainStore, stateManager, syncApi, stateBlockstore, tipsetResolver)
+	return eth.NewEthLookupAPI(chainStore, stateManager, syncApi, messagePool, stateBlockstore, tipsetResolver)
+}
+
+func MakeEthSend(cfg config.FevmConfig) func(
+	mpoolApi eth.MpoolAPI,
+	messagePool eth.MessagePool,
+	chainIndexer index.Indexer,
+	chainStore eth.ChainStore,
+	stateManager eth.StateManager,
+) eth.EthSendAPI {
+	return func(
+		mpoolApi eth.MpoolAPI,
+		messagePool eth.MessagePool,
+		chainIndexer index.Indexer,
+		chainStore eth.ChainStore,
+		stateManager eth.StateManager,
+	) eth.EthSendAPI {
+		return eth.NewEthSendAPI(mpoolApi, messagePool, chainIndexer, chainStore, stateManager, cfg.EthSendRawTransactionSimulate)
+	}
 }
 
-func MakeEthGasV1(
+func MakeEthGasV1(cfg config.FevmConfig) func(
 	chainStore eth.ChainStore,
 	stateManager eth.StateManager,
 	messagePool eth.MessagePool,
 	gasApi eth.GasAPI,
 	tipsetResolver full.EthTipSetResolverV1,
 ) full.EthGasAPIV1 {
-	return eth.NewEthGasAPI(chainStore, stateManager, messagePool, gasApi, tipsetResolver)
+	return func(
+		chainStore eth.ChainStore,
+		stateManager eth.StateManager,
+		messagePool eth.MessagePool,
+		gasApi eth.GasAPI,
+		tipsetResolver full.EthTipSetResolverV1,
+	) full.EthGasAPIV1 {
+		return eth.NewEthGasAPI(chainStore, stateManager, messagePool, gasApi, tipsetResolver, cfg.EthCallStrictMode)
+	}
 }
 
-func MakeEthGasV2(
+func MakeEthGasV2(cfg config.FevmConfig) func(
 	chainStore eth.ChainStore,
 	stateManager eth.StateManager,
 	messagePool eth.MessagePool,
 	gasApi eth.GasAPI,
 	tipsetResolver full.EthTipSetResolverV2,
 ) full.EthGasAPIV2 {
-	return eth.NewEthGasAPI(chainStore, stateManager, messagePool, gasApi, tipsetResolver)
+	return func(
+		chainStore eth.ChainStore,
+		stateManager eth.StateManager,
+		messagePool eth.MessagePool,
+		gasApi eth.GasAPI,
+		tipsetResolver full.EthTipSetResolverV2,
+	) full.EthGasAPIV2 {
+		return eth.NewEthGasAPI(chainStore, stateManager, messagePool, gasApi, tipsetResolver, cfg.EthCallStrictMode)
+	}
 }
 
 type EthTransactionParams struct {
@@ -223,6 +259,9 @@ func MakeEthEventsExtended(cfg config.EventsConfig, enableEthRPC bool) func(EthE
 			filterStore          filter.FilterStore
 			subscriptionManager  *eth.EthSubscriptionManager
 			maxFilterHeightRange = abi.ChainEpoch(cfg.MaxFilterHeightRange)
+			maxGetLogsResults    = cfg.MaxGetLogsResults
+			getLogsTimeout       = time.Duration(cfg.GetLogsTimeout)
+			maxFilterAddresses   = cfg.MaxFilterAddresses
 		)
 
 		if !enableEthRPC {
@@ -239,6 +278,9 @@ func MakeEthEventsExtended(cfg config.EventsConfig, enableEthRPC bool) func(EthE
 				filterStore,
 				subscriptionManager,
 				maxFilterHeightRange,
+				maxGetLogsResults,
+				getLogsTimeout,
+				maxFilterAddresses,
 			), nil
 		}
 
@@ -259,6 +301,9 @@ func MakeEthEventsExtended(cfg config.EventsConfig, enableEthRPC bool) func(EthE
 			filterStore,
 			subscriptionManager,
 			maxFilterHeightRange,
+			maxGetLogsResults,
+			getLogsTimeout,
+			maxFilterAddresses,
 		)
 
 		params.Lifecycle.Append(fx.Hook{